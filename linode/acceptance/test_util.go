@@ -56,6 +56,13 @@ func initOptInTests() {
 
 // initTestImages grabs the latest Linode Alpine images for acceptance test configurations
 func initTestImages() {
+	// Unit tests run against the mock API without a token, which knows these images
+	if os.Getenv("LINODE_TOKEN") == "" {
+		TestImageLatest = "linode/alpine3.15"
+		TestImagePrevious = "linode/alpine3.14"
+		return
+	}
+
 	client, err := GetClientForSweepers()
 	if err != nil {
 		log.Fatalf("failed to get client: %s", err)
@@ -19,6 +19,7 @@ func Resource() *schema.Resource {
 		CreateContext: createResource,
 		UpdateContext: updateResource,
 		DeleteContext: deleteResource,
+		CustomizeDiff: helper.CustomizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -56,7 +57,7 @@ func readResource(ctx context.Context, d *schema.ResourceData, meta interface{})
 	d.Set("expire_sec", domain.ExpireSec)
 	d.Set("refresh_sec", domain.RefreshSec)
 	d.Set("soa_email", domain.SOAEmail)
-	d.Set("tags", domain.Tags)
+	helper.SetTags(d, meta, domain.Tags)
 
 	return nil
 }
@@ -76,11 +77,7 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		TTLSec:      d.Get("ttl_sec").(int),
 	}
 
-	if tagsRaw, tagsOk := d.GetOk("tags"); tagsOk {
-		for _, tag := range tagsRaw.(*schema.Set).List() {
-			createOpts.Tags = append(createOpts.Tags, tag.(string))
-		}
-	}
+	createOpts.Tags = helper.ExpandTags(d, meta)
 
 	if v, ok := d.GetOk("master_ips"); ok {
 		v := v.(*schema.Set).List()
@@ -147,13 +144,8 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		}
 	}
 
-	if d.HasChange("tags") {
-		tags := []string{}
-		for _, tag := range d.Get("tags").(*schema.Set).List() {
-			tags = append(tags, tag.(string))
-		}
-
-		updateOpts.Tags = tags
+	if d.HasChanges("tags", "tags_all") {
+		updateOpts.Tags = helper.ExpandTags(d, meta)
 	}
 
 	_, err = client.UpdateDomain(ctx, int(id), updateOpts)
@@ -13,6 +13,7 @@ import (
 	"github.com/linode/terraform-provider-linode/linode/acceptance"
 	"github.com/linode/terraform-provider-linode/linode/domain/tmpl"
 	"github.com/linode/terraform-provider-linode/linode/helper"
+	"github.com/linode/terraform-provider-linode/linode/mockapi"
 )
 
 func init() {
@@ -289,3 +290,34 @@ resource "linode_domain" "foobar" {
 	tags = ["tf_test"]
 }`, domain)
 }
+
+func TestUnitResourceDomain_basic(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+
+	resName := "linode_domain.foobar"
+	domainName := acctest.RandomWithPrefix("tf-test-") + ".example"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { mockapi.PreCheck(t) },
+		ProviderFactories: mockapi.ProviderFactories,
+		CheckDestroy:      server.CheckDestroy("linode_domain", "domains"),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + tmpl.Basic(t, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "domain", domainName),
+					resource.TestCheckResourceAttr(resName, "soa_email", "example@"+domainName),
+					resource.TestCheckResourceAttr(resName, "tags.#", "1"),
+				),
+			},
+			{
+				Config: server.ProviderConfig() + tmpl.Updates(t, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "domain", "renamed-"+domainName),
+					resource.TestCheckResourceAttr(resName, "tags.#", "2"),
+				),
+			},
+		},
+	})
+}
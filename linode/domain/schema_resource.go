@@ -101,4 +101,5 @@ var resourceSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "An array of tags applied to this object. Tags are for organizational purposes only.",
 	},
+	"tags_all": helper.TagsAllSchema(),
 }
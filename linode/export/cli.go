@@ -0,0 +1,102 @@
+package export
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/linode/terraform-provider-linode/linode"
+)
+
+const usage = `Usage: terraform-provider-linode export [options]
+
+Writes Terraform configuration and import blocks for the existing entities of a Linode account.
+The provider is configured from the LINODE_TOKEN environment variable or a Linode CLI config file.
+
+Options:
+`
+
+// Run runs the export subcommand with the given arguments, returning its exit code.
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	types := flags.String("type", "",
+		"Comma-separated resource types to export. Defaults to "+strings.Join(SupportedTypes, ", ")+".")
+	tags := flags.String("tag", "", "Comma-separated tags; only entities with one of them are exported.")
+	label := flags.String("label", "", "Only entities whose label matches this regular expression are exported.")
+	output := flags.String("output", "", "The file to write the configuration to. Defaults to stdout.")
+	configPath := flags.String("config-path", "", "The path to the Linode CLI config file to load credentials from.")
+	configProfile := flags.String("config-profile", "", "The Linode CLI config file profile to load credentials from.")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	opts := Options{
+		Types: splitList(*types),
+		Tags:  splitList(*tags),
+	}
+
+	if *label != "" {
+		re, err := regexp.Compile(*label)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: invalid label expression: %s\n", err)
+			return 2
+		}
+		opts.Label = re
+	}
+
+	provider := linode.Provider()
+
+	raw := map[string]interface{}{}
+	if *configPath != "" {
+		raw["config_path"] = *configPath
+	}
+	if *configProfile != "" {
+		raw["config_profile"] = *configProfile
+	}
+
+	if diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+		fmt.Fprintf(stderr, "Error: failed to configure the provider: %s\n", diagsError(diags))
+		return 1
+	}
+
+	w := stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %s\n", err)
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := Export(ctx, provider, opts, w); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// splitList returns the non-empty items of a comma-separated list.
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,557 @@
+// Package export renders the existing entities of a Linode account as Terraform configuration,
+// along with the import blocks that bring them under management.
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const header = `# Generated by terraform-provider-linode export.
+# Review this configuration before applying it: sensitive and write-only arguments such as
+# root_pass are not exported, and arguments the API does not return are left unset.
+
+`
+
+// SupportedTypes are the resource types that can be exported.
+var SupportedTypes = []string{
+	"linode_domain",
+	"linode_domain_record",
+	"linode_firewall",
+	"linode_instance",
+	"linode_lke_cluster",
+	"linode_nodebalancer",
+	"linode_nodebalancer_config",
+	"linode_nodebalancer_node",
+	"linode_sshkey",
+	"linode_volume",
+}
+
+// Options select the entities to export.
+type Options struct {
+	// Types are the resource types to export, or every supported type if empty.
+	Types []string
+
+	// Tags are the tags an entity must have one of, or any tags if empty.
+	// Entities without tags, such as domain records, are selected along with their parent.
+	Tags []string
+
+	// Label selects the entities whose label matches it, if set.
+	// Entities such as domain records are selected along with their parent.
+	Label *regexp.Regexp
+}
+
+// entity is an account entity to export as a resource.
+type entity struct {
+	resourceType string
+	importID     string
+	label        string
+
+	// references are the parent entities of the entity by the attribute holding their ID,
+	// such as the domain of a record.
+	references map[string]*entity
+
+	// name is the name of the exported resource
+	name string
+}
+
+// exporter lists and renders the entities selected by the export options.
+type exporter struct {
+	provider *schema.Provider
+	meta     *helper.ProviderMeta
+	opts     Options
+	types    map[string]bool
+
+	names    map[string]map[string]bool
+	exported map[*entity]bool
+}
+
+// Export writes the configuration and import blocks of the entities selected by the options to w.
+// The provider must already be configured.
+func Export(ctx context.Context, provider *schema.Provider, opts Options, w io.Writer) error {
+	meta, ok := provider.Meta().(*helper.ProviderMeta)
+	if !ok {
+		return fmt.Errorf("the provider must be configured before exporting")
+	}
+
+	e := &exporter{
+		provider: provider,
+		meta:     meta,
+		opts:     opts,
+		types:    make(map[string]bool),
+		names:    make(map[string]map[string]bool),
+		exported: make(map[*entity]bool),
+	}
+
+	types := opts.Types
+	if len(types) == 0 {
+		types = SupportedTypes
+	}
+
+	for _, t := range types {
+		if _, ok := provider.ResourcesMap[t]; !ok || !isSupportedType(t) {
+			return fmt.Errorf("unsupported resource type %q, expected one of %s", t, strings.Join(SupportedTypes, ", "))
+		}
+		e.types[t] = true
+	}
+
+	entities, err := e.listEntities(ctx)
+	if err != nil {
+		return err
+	}
+
+	file := hclwrite.NewEmptyFile()
+	body := file.Body()
+
+	for _, ent := range entities {
+		if !e.types[ent.resourceType] {
+			continue
+		}
+
+		d, err := e.readEntity(ctx, ent)
+		if err != nil {
+			return err
+		}
+
+		// The entity was deleted after it was listed
+		if d == nil {
+			continue
+		}
+
+		ent.name = e.resourceName(ent.resourceType, ent.label)
+		e.exported[ent] = true
+
+		e.writeResource(body, ent, d)
+		body.AppendNewline()
+	}
+
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+
+	_, err = w.Write(hclwrite.Format(file.Bytes()))
+	return err
+}
+
+func isSupportedType(resourceType string) bool {
+	for _, t := range SupportedTypes {
+		if t == resourceType {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns whether an entity is selected by the tag and label filters.
+func (e *exporter) matches(label string, tags []string) bool {
+	if e.opts.Label != nil && !e.opts.Label.MatchString(label) {
+		return false
+	}
+
+	if len(e.opts.Tags) == 0 {
+		return true
+	}
+
+	for _, tag := range tags {
+		for _, selected := range e.opts.Tags {
+			if tag == selected {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// wants returns whether any of the given resource types are exported.
+func (e *exporter) wants(types ...string) bool {
+	for _, t := range types {
+		if e.types[t] {
+			return true
+		}
+	}
+	return false
+}
+
+// listEntities lists the entities of the account selected by the options, parents before their children.
+func (e *exporter) listEntities(ctx context.Context) ([]*entity, error) {
+	client := &e.meta.Client
+
+	var entities []*entity
+
+	add := func(ent *entity) *entity {
+		entities = append(entities, ent)
+		return ent
+	}
+
+	if e.wants("linode_instance") {
+		instances, err := client.ListInstances(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list Linode Instances: %s", err)
+		}
+
+		lkeNodes, err := e.listLKENodeInstanceIDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, instance := range instances {
+			// The nodes of LKE clusters are managed through their cluster
+			if lkeNodes[instance.ID] || hasLKETag(instance.Tags) {
+				continue
+			}
+
+			if e.matches(instance.Label, instance.Tags) {
+				add(&entity{
+					resourceType: "linode_instance", importID: strconv.Itoa(instance.ID),
+					label: instance.Label,
+				})
+			}
+		}
+	}
+
+	if e.wants("linode_domain", "linode_domain_record") {
+		domains, err := client.ListDomains(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list Linode Domains: %s", err)
+		}
+
+		for _, domain := range domains {
+			if !e.matches(domain.Domain, domain.Tags) {
+				continue
+			}
+
+			parent := add(&entity{
+				resourceType: "linode_domain", importID: strconv.Itoa(domain.ID),
+				label: domain.Domain,
+			})
+
+			if !e.wants("linode_domain_record") {
+				continue
+			}
+
+			records, err := client.ListDomainRecords(ctx, domain.ID, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list Linode Domain %d Records: %s", domain.ID, err)
+			}
+
+			for _, record := range records {
+				name := record.Name
+				if name == "" {
+					name = string(record.Type)
+				}
+
+				add(&entity{
+					resourceType: "linode_domain_record",
+					importID:     fmt.Sprintf("%d,%d", domain.ID, record.ID),
+					label:        domain.Domain + "_" + name,
+					references:   map[string]*entity{"domain_id": parent},
+				})
+			}
+		}
+	}
+
+	if e.wants("linode_firewall") {
+		firewalls, err := client.ListFirewalls(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list Linode Firewalls: %s", err)
+		}
+
+		for _, firewall := range firewalls {
+			if e.matches(firewall.Label, firewall.Tags) {
+				add(&entity{
+					resourceType: "linode_firewall", importID: strconv.Itoa(firewall.ID),
+					label: firewall.Label,
+				})
+			}
+		}
+	}
+
+	if e.wants("linode_volume") {
+		volumes, err := client.ListVolumes(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list Linode Volumes: %s", err)
+		}
+
+		for _, volume := range volumes {
+			if e.matches(volume.Label, volume.Tags) {
+				add(&entity{
+					resourceType: "linode_volume", importID: strconv.Itoa(volume.ID),
+					label: volume.Label,
+				})
+			}
+		}
+	}
+
+	if e.wants("linode_nodebalancer", "linode_nodebalancer_config", "linode_nodebalancer_node") {
+		if err := e.listNodeBalancers(ctx, add); err != nil {
+			return nil, err
+		}
+	}
+
+	if e.wants("linode_lke_cluster") {
+		clusters, err := client.ListLKEClusters(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list LKE Clusters: %s", err)
+		}
+
+		for _, cluster := range clusters {
+			if e.matches(cluster.Label, cluster.Tags) {
+				add(&entity{
+					resourceType: "linode_lke_cluster", importID: strconv.Itoa(cluster.ID),
+					label: cluster.Label,
+				})
+			}
+		}
+	}
+
+	if e.wants("linode_sshkey") {
+		keys, err := client.ListSSHKeys(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list SSH Keys: %s", err)
+		}
+
+		for _, key := range keys {
+			if e.matches(key.Label, nil) {
+				add(&entity{resourceType: "linode_sshkey", importID: strconv.Itoa(key.ID), label: key.Label})
+			}
+		}
+	}
+
+	return entities, nil
+}
+
+// listLKENodeInstanceIDs returns the IDs of the instances backing the nodes of every LKE cluster.
+func (e *exporter) listLKENodeInstanceIDs(ctx context.Context) (map[int]bool, error) {
+	client := &e.meta.Client
+
+	clusters, err := client.ListLKEClusters(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list LKE Clusters: %s", err)
+	}
+
+	result := make(map[int]bool)
+
+	for _, cluster := range clusters {
+		pools, err := client.ListLKENodePools(ctx, cluster.ID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list LKE Cluster %d Node Pools: %s", cluster.ID, err)
+		}
+
+		for _, pool := range pools {
+			for _, node := range pool.Linodes {
+				result[node.InstanceID] = true
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// lkeTag is the tag LKE applies to the instances of the nodes of a cluster.
+var lkeTag = regexp.MustCompile(`^lke\d+$`)
+
+// hasLKETag returns whether the tags include the tag of the nodes of an LKE cluster.
+func hasLKETag(tags []string) bool {
+	for _, tag := range tags {
+		if lkeTag.MatchString(tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// listNodeBalancers lists the selected NodeBalancers along with their configs and nodes.
+func (e *exporter) listNodeBalancers(ctx context.Context, add func(*entity) *entity) error {
+	client := &e.meta.Client
+
+	nodeBalancers, err := client.ListNodeBalancers(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list Linode NodeBalancers: %s", err)
+	}
+
+	for _, nodeBalancer := range nodeBalancers {
+		label := ""
+		if nodeBalancer.Label != nil {
+			label = *nodeBalancer.Label
+		}
+
+		if !e.matches(label, nodeBalancer.Tags) {
+			continue
+		}
+
+		parent := add(&entity{
+			resourceType: "linode_nodebalancer", importID: strconv.Itoa(nodeBalancer.ID),
+			label: label,
+		})
+
+		if !e.wants("linode_nodebalancer_config", "linode_nodebalancer_node") {
+			continue
+		}
+
+		configs, err := client.ListNodeBalancerConfigs(ctx, nodeBalancer.ID, nil)
+		if err != nil {
+			return fmt.Errorf("failed to list Linode NodeBalancer %d Configs: %s", nodeBalancer.ID, err)
+		}
+
+		for _, config := range configs {
+			configEntity := add(&entity{
+				resourceType: "linode_nodebalancer_config",
+				importID:     fmt.Sprintf("%d,%d", nodeBalancer.ID, config.ID),
+				label:        fmt.Sprintf("%s_%d", label, config.Port),
+				references:   map[string]*entity{"nodebalancer_id": parent},
+			})
+
+			if !e.wants("linode_nodebalancer_node") {
+				continue
+			}
+
+			nodes, err := client.ListNodeBalancerNodes(ctx, nodeBalancer.ID, config.ID, nil)
+			if err != nil {
+				return fmt.Errorf("failed to list Linode NodeBalancer %d Config %d Nodes: %s",
+					nodeBalancer.ID, config.ID, err)
+			}
+
+			for _, node := range nodes {
+				add(&entity{
+					resourceType: "linode_nodebalancer_node",
+					importID:     fmt.Sprintf("%d,%d,%d", nodeBalancer.ID, config.ID, node.ID),
+					label:        configEntity.label + "_" + node.Label,
+					references:   map[string]*entity{"nodebalancer_id": parent, "config_id": configEntity},
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// readEntity imports and reads an entity through its resource, exactly as terraform import would.
+// It returns nil if the entity no longer exists.
+func (e *exporter) readEntity(ctx context.Context, ent *entity) (*schema.ResourceData, error) {
+	r := e.provider.ResourcesMap[ent.resourceType]
+
+	d := r.Data(nil)
+	d.SetId(ent.importID)
+
+	// Arguments the resource does not read back keep their defaults, as they would in configuration
+	for k, s := range r.Schema {
+		if s.Default != nil {
+			if err := d.Set(k, s.Default); err != nil {
+				return nil, fmt.Errorf("failed to set the default of %s.%s: %s", ent.resourceType, k, err)
+			}
+		}
+	}
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		imported, err := r.Importer.StateContext(ctx, d, e.meta)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import %s %s: %s", ent.resourceType, ent.importID, err)
+		}
+
+		if len(imported) == 0 {
+			return nil, nil
+		}
+		d = imported[0]
+	}
+
+	if diags := r.ReadContext(ctx, d, e.meta); diags.HasError() {
+		return nil, fmt.Errorf("failed to read %s %s: %s", ent.resourceType, ent.importID, diagsError(diags))
+	}
+
+	if d.Id() == "" {
+		return nil, nil
+	}
+
+	return d, nil
+}
+
+func diagsError(diags diag.Diagnostics) string {
+	var errs []string
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			errs = append(errs, d.Summary)
+		}
+	}
+	return strings.Join(errs, "; ")
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// resourceName returns a unique resource name for an entity of the given type from its label.
+func (e *exporter) resourceName(resourceType, label string) string {
+	name := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(label), "_"), "_")
+	if name == "" || !(name[0] == '_' || (name[0] >= 'a' && name[0] <= 'z')) {
+		name = "r_" + name
+	}
+
+	names := e.names[resourceType]
+	if names == nil {
+		names = make(map[string]bool)
+		e.names[resourceType] = names
+	}
+
+	unique := name
+	for i := 2; names[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	names[unique] = true
+
+	return unique
+}
+
+// writeResource writes the resource and import blocks of an entity.
+func (e *exporter) writeResource(body *hclwrite.Body, ent *entity, d *schema.ResourceData) {
+	resourceSchema := e.provider.ResourcesMap[ent.resourceType].Schema
+
+	values := make(map[string]interface{}, len(resourceSchema))
+	for k := range resourceSchema {
+		values[k] = d.Get(k)
+	}
+
+	// Parent IDs reference the exported parent resources
+	references := make(map[string]hcl.Traversal)
+	for k, parent := range ent.references {
+		if e.exported[parent] {
+			references[k] = hcl.Traversal{
+				hcl.TraverseRoot{Name: parent.resourceType},
+				hcl.TraverseAttr{Name: parent.name},
+				hcl.TraverseAttr{Name: "id"},
+			}
+		}
+	}
+
+	resource := body.AppendNewBlock("resource", []string{ent.resourceType, ent.name})
+	writeBody(resource.Body(), resourceSchema, values, references)
+
+	body.AppendNewline()
+
+	importBlock := body.AppendNewBlock("import", nil).Body()
+	importBlock.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: ent.resourceType},
+		hcl.TraverseAttr{Name: ent.name},
+	})
+	importBlock.SetAttributeValue("id", cty.StringVal(ent.importID))
+}
+
+func sortedKeys(m map[string]*schema.Schema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
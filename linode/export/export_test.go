@@ -0,0 +1,229 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/linode/linodego"
+	"github.com/linode/terraform-provider-linode/linode"
+	"github.com/linode/terraform-provider-linode/linode/export"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+	"github.com/linode/terraform-provider-linode/linode/mockapi"
+)
+
+// testAccount returns a provider configured against a fake API seeded with entities of every exported type.
+func testAccount(t *testing.T) *schema.Provider {
+	t.Helper()
+
+	server := mockapi.NewServer()
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+
+	client := (&helper.Config{AccessToken: "mock", APIURL: server.URL, APIVersion: "v4"}).Client()
+
+	instance, err := client.CreateInstance(ctx, linodego.InstanceCreateOptions{
+		Region: "us-east", Type: "g6-nanode-1", Label: "web-1", Image: "linode/alpine3.15",
+		RootPass: "terraform-test", Tags: []string{"web"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	domain, err := client.CreateDomain(ctx, linodego.DomainCreateOptions{
+		Domain: "example.com", Type: linodego.DomainTypeMaster, SOAEmail: "admin@example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateDomainRecord(ctx, domain.ID, linodego.DomainRecordCreateOptions{
+		Type: linodego.RecordTypeA, Name: "www", Target: "${ip}",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateVolume(ctx, linodego.VolumeCreateOptions{
+		Label: "data", Region: "us-east", Size: 20, Tags: []string{"db"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	label := "balancer"
+	nodeBalancer, err := client.CreateNodeBalancer(ctx, linodego.NodeBalancerCreateOptions{
+		Label: &label, Region: "us-east", Tags: []string{"web"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := client.CreateNodeBalancerConfig(ctx, nodeBalancer.ID, linodego.NodeBalancerConfigCreateOptions{
+		Port: 80, Protocol: linodego.ProtocolHTTP,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateNodeBalancerNode(ctx, nodeBalancer.ID, config.ID, linodego.NodeBalancerNodeCreateOptions{
+		Label: "web-1", Address: instance.IPv4[0].String() + ":80", Weight: 50,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateSSHKey(ctx, linodego.SSHKeyCreateOptions{
+		Label: "admin", SSHKey: "ssh-ed25519 AAAA admin@example.com",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	cluster, err := client.CreateLKECluster(ctx, linodego.LKEClusterCreateOptions{
+		Label: "k8s", Region: "us-east", K8sVersion: "1.22",
+		NodePools: []linodego.LKENodePoolCreateOptions{{Type: "g6-standard-1", Count: 2}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pools, err := client.ListLKENodePools(ctx, cluster.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nodes are recognized by their instance ID even without the tag of their cluster
+	tags := []string{}
+	if _, err := client.UpdateInstance(ctx, pools[0].Linodes[0].InstanceID, linodego.InstanceUpdateOptions{
+		Tags: &tags,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := linode.Provider()
+	if diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"url":           server.URL,
+		"api_version":   "v4",
+		"token":         "mock",
+		"event_poll_ms": 10,
+	})); diags.HasError() {
+		t.Fatalf("failed to configure the provider: %v", diags)
+	}
+
+	return provider
+}
+
+func testExport(t *testing.T, provider *schema.Provider, opts export.Options) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	if err := export.Export(context.Background(), provider, opts, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	// The output must be valid HCL
+	if _, diags := hclparse.NewParser().ParseHCL(out.Bytes(), "export.tf"); diags.HasErrors() {
+		t.Fatalf("invalid HCL: %s\n%s", diags, out.String())
+	}
+
+	return out.String()
+}
+
+// containsHCL returns whether the HCL contains the given text, ignoring differences in whitespace.
+func containsHCL(hcl, s string) bool {
+	return strings.Contains(strings.Join(strings.Fields(hcl), " "), s)
+}
+
+func TestExport(t *testing.T) {
+	provider := testAccount(t)
+
+	out := testExport(t, provider, export.Options{})
+
+	for _, expected := range []string{
+		`resource "linode_instance" "web-1" {`,
+		`type = "g6-nanode-1"`,
+		`resource "linode_domain" "example_com" {`,
+		`domain = "example.com"`,
+		`resource "linode_domain_record" "example_com_www" {`,
+		`domain_id = linode_domain.example_com.id`,
+		`target = "$${ip}"`,
+		`resource "linode_volume" "data" {`,
+		`resource "linode_nodebalancer" "balancer" {`,
+		`resource "linode_nodebalancer_config" "balancer_80" {`,
+		`nodebalancer_id = linode_nodebalancer.balancer.id`,
+		`check_passive = true`,
+		`resource "linode_nodebalancer_node" "balancer_80_web-1" {`,
+		`config_id = linode_nodebalancer_config.balancer_80.id`,
+		`resource "linode_sshkey" "admin" {`,
+		`resource "linode_lke_cluster" "k8s" {`,
+		`import { to = linode_instance.web-1 id = "`,
+	} {
+		if !containsHCL(out, expected) {
+			t.Errorf("expected %q in the export:\n%s", expected, out)
+		}
+	}
+
+	// Sensitive arguments are never exported, and arguments the API does not return keep their defaults.
+	// The instances of LKE nodes are managed through their cluster.
+	for _, excluded := range []string{
+		"terraform-test", "root_pass =", "reboot_policy", "port =", `resource "linode_instance" "lke`,
+	} {
+		if containsHCL(out, excluded) {
+			t.Errorf("expected %q not to be exported:\n%s", excluded, out)
+		}
+	}
+}
+
+func TestExportFilters(t *testing.T) {
+	provider := testAccount(t)
+
+	for _, tc := range []struct {
+		name     string
+		opts     export.Options
+		expected []string
+		excluded []string
+	}{
+		{
+			name:     "types",
+			opts:     export.Options{Types: []string{"linode_domain_record"}},
+			expected: []string{`resource "linode_domain_record"`, `domain_id = `},
+			excluded: []string{`resource "linode_domain"`, `linode_domain.example_com.id`, `resource "linode_instance"`},
+		},
+		{
+			name:     "tags",
+			opts:     export.Options{Tags: []string{"web"}},
+			expected: []string{`resource "linode_instance"`, `resource "linode_nodebalancer_node"`},
+			excluded: []string{`resource "linode_volume"`, `resource "linode_domain"`, `resource "linode_sshkey"`},
+		},
+		{
+			name:     "label",
+			opts:     export.Options{Label: regexp.MustCompile(`^(data|admin)$`)},
+			expected: []string{`resource "linode_volume"`, `resource "linode_sshkey"`},
+			excluded: []string{`resource "linode_instance"`, `resource "linode_nodebalancer"`},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := testExport(t, provider, tc.opts)
+
+			for _, expected := range tc.expected {
+				if !containsHCL(out, expected) {
+					t.Errorf("expected %q in the export:\n%s", expected, out)
+				}
+			}
+
+			for _, excluded := range tc.excluded {
+				if containsHCL(out, excluded) {
+					t.Errorf("expected %q not to be exported:\n%s", excluded, out)
+				}
+			}
+		})
+	}
+
+	if err := export.Export(context.Background(), provider, export.Options{Types: []string{"linode_user"}},
+		&bytes.Buffer{}); err == nil {
+		t.Error("expected an error exporting an unsupported type")
+	}
+}
@@ -0,0 +1,170 @@
+package export
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// writeBody writes the arguments of a resource or nested block to an HCL body.
+// Computed-only, sensitive and deprecated attributes are skipped, as are values that are unset,
+// equal to their default, or that conflict with an argument that has already been written.
+func writeBody(body *hclwrite.Body, schemaMap map[string]*schema.Schema, values map[string]interface{},
+	references map[string]hcl.Traversal) {
+	written := make(map[string]bool)
+	var blocks []string
+
+	for _, k := range sortedKeys(schemaMap) {
+		s := schemaMap[k]
+		v := values[k]
+
+		if !isArgument(s) || conflicts(s, written) {
+			continue
+		}
+
+		if traversal, ok := references[k]; ok {
+			body.SetAttributeTraversal(k, traversal)
+			written[k] = true
+			continue
+		}
+
+		if isDefault(s, v) {
+			continue
+		}
+
+		written[k] = true
+
+		if _, ok := s.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, k)
+			continue
+		}
+
+		body.SetAttributeValue(k, ctyValue(s, v))
+	}
+
+	for _, k := range blocks {
+		s := schemaMap[k]
+		elem := s.Elem.(*schema.Resource)
+
+		for _, item := range listValue(values[k]) {
+			itemValues, _ := item.(map[string]interface{})
+			writeBody(body.AppendNewBlock(k, nil).Body(), elem.Schema, itemValues, nil)
+		}
+	}
+}
+
+// isArgument returns whether an attribute can be set in configuration and should be exported.
+func isArgument(s *schema.Schema) bool {
+	return (s.Required || s.Optional) && !s.Sensitive && s.Deprecated == ""
+}
+
+// conflicts returns whether an attribute conflicts with an attribute that has already been written.
+func conflicts(s *schema.Schema, written map[string]bool) bool {
+	for _, k := range s.ConflictsWith {
+		if written[strings.SplitN(k, ".", 2)[0]] || written[k] {
+			return true
+		}
+	}
+	return false
+}
+
+// isDefault returns whether a value can be left out of the configuration.
+func isDefault(s *schema.Schema, v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	if s.Default != nil {
+		return reflect.DeepEqual(v, s.Default)
+	}
+
+	if s.Required {
+		return false
+	}
+
+	switch v := v.(type) {
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+
+	return len(listValue(v)) == 0
+}
+
+// listValue returns the items of a list or set value.
+func listValue(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		return v
+	case *schema.Set:
+		return v.List()
+	}
+	return nil
+}
+
+// ctyValue converts a value of the given attribute to a cty value.
+func ctyValue(s *schema.Schema, v interface{}) cty.Value {
+	switch s.Type {
+	case schema.TypeString:
+		return cty.StringVal(v.(string))
+	case schema.TypeInt:
+		return cty.NumberIntVal(int64(v.(int)))
+	case schema.TypeFloat:
+		return cty.NumberFloatVal(v.(float64))
+	case schema.TypeBool:
+		return cty.BoolVal(v.(bool))
+	case schema.TypeMap:
+		elem := elemSchema(s)
+		m := v.(map[string]interface{})
+
+		if len(m) == 0 {
+			return cty.MapValEmpty(cty.String)
+		}
+
+		values := make(map[string]cty.Value, len(m))
+		for k, item := range m {
+			values[k] = ctyValue(elem, item)
+		}
+		return cty.MapVal(values)
+	}
+
+	elem := elemSchema(s)
+	items := listValue(v)
+	if len(items) == 0 {
+		return cty.ListValEmpty(cty.String)
+	}
+
+	values := make([]cty.Value, len(items))
+	for i, item := range items {
+		values[i] = ctyValue(elem, item)
+	}
+
+	// Sets are written in a stable order
+	if s.Type == schema.TypeSet {
+		sort.Slice(values, func(i, j int) bool {
+			return values[i].GoString() < values[j].GoString()
+		})
+	}
+
+	return cty.ListVal(values)
+}
+
+// elemSchema returns the schema of the elements of a primitive collection attribute.
+func elemSchema(s *schema.Schema) *schema.Schema {
+	if elem, ok := s.Elem.(*schema.Schema); ok {
+		return elem
+	}
+	return &schema.Schema{Type: schema.TypeString}
+}
@@ -30,6 +30,7 @@ func Resource() *schema.Resource {
 		CreateContext: createResource,
 		UpdateContext: updateResource,
 		DeleteContext: deleteResource,
+		CustomizeDiff: helper.CustomizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -64,7 +65,7 @@ func readResource(ctx context.Context, d *schema.ResourceData, meta interface{})
 
 	d.Set("label", firewall.Label)
 	d.Set("disabled", firewall.Status == linodego.FirewallDisabled)
-	d.Set("tags", firewall.Tags)
+	helper.SetTags(d, meta, firewall.Tags)
 	d.Set("status", firewall.Status)
 	d.Set("inbound", flattenFirewallRules(rules.Inbound))
 	d.Set("outbound", flattenFirewallRules(rules.Outbound))
@@ -80,7 +81,7 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 
 	createOpts := linodego.FirewallCreateOptions{
 		Label: d.Get("label").(string),
-		Tags:  helper.ExpandStringSet(d.Get("tags").(*schema.Set)),
+		Tags:  helper.ExpandTags(d, meta),
 	}
 
 	createOpts.Devices.Linodes = helper.ExpandIntSet(d.Get("linodes").(*schema.Set))
@@ -117,13 +118,13 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		return diag.Errorf("failed to parse Firewall %s as int: %s", d.Id(), err)
 	}
 
-	if d.HasChanges("label", "tags", "disabled") {
+	if d.HasChanges("label", "tags", "tags_all", "disabled") {
 		updateOpts := linodego.FirewallUpdateOptions{}
 		if d.HasChange("label") {
 			updateOpts.Label = d.Get("label").(string)
 		}
-		if d.HasChange("tags") {
-			tags := helper.ExpandStringSet(d.Get("tags").(*schema.Set))
+		if d.HasChanges("tags", "tags_all") {
+			tags := helper.ExpandTags(d, meta)
 			updateOpts.Tags = &tags
 		}
 		if d.HasChange("disabled") {
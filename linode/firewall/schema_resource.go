@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/linode/terraform-provider-linode/linode/helper"
 )
 
 var resourceRuleSchema = map[string]*schema.Schema{
@@ -93,6 +94,7 @@ var resourceSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Set:         schema.HashString,
 	},
+	"tags_all": helper.TagsAllSchema(),
 	"disabled": {
 		Type:        schema.TypeBool,
 		Description: "If true, the Firewall is inactive.",
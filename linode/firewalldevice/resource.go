@@ -93,6 +93,8 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		Type: linodego.FirewallDeviceType(entityType),
 	}
 
+	defer helper.LockEntity("firewall", firewallID)()
+
 	device, err := client.CreateFirewallDevice(ctx, firewallID, createOpts)
 	if err != nil {
 		return diag.Errorf("Error creating a Linode Firewall Device: %s", err)
@@ -116,6 +118,8 @@ func deleteResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		return diag.Errorf("Error parsing Linode Firewall Device ID %v as int", d.Get("firewall_id"))
 	}
 
+	defer helper.LockEntity("firewall", firewallID)()
+
 	err = client.DeleteFirewallDevice(ctx, firewallID, int(id))
 	if err != nil {
 		return diag.Errorf("Error deleting Linode Firewall Device %d: %s", id, err)
@@ -8,7 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 	"github.com/linode/linodego"
 	"github.com/linode/terraform-provider-linode/version"
@@ -20,9 +19,21 @@ const uaEnvVar = "TF_APPEND_USER_AGENT"
 // DefaultLinodeURL is the Linode APIv4 URL to use.
 const DefaultLinodeURL = "https://api.linode.com"
 
+const (
+	tokenEnvVar      = "LINODE_TOKEN"
+	urlEnvVar        = "LINODE_URL"
+	apiVersionEnvVar = "LINODE_API_VERSION"
+)
+
 type ProviderMeta struct {
 	Client linodego.Client
 	Config *Config
+
+	// Events is shared by every resource waiting on the events of the account.
+	Events *EventWatcher
+
+	// ObjectStorage builds the S3 clients of the Object Storage resources.
+	ObjectStorage *ObjectStorageClients
 }
 
 // Config represents the Linode provider configuration.
@@ -32,15 +43,31 @@ type Config struct {
 	APIVersion  string
 	UAPrefix    string
 
+	ConfigPath    string
+	ConfigProfile string
+
 	TerraformVersion string
 
+	DefaultTags []string
+
 	SkipInstanceReadyPoll        bool
 	SkipInstanceDeletePoll       bool
 	MinRetryDelayMilliseconds    int
 	MaxRetryDelayMilliseconds    int
+	APIRequestsPerSecond         float64
+	APIBurst                     int
+	MaxConcurrentRequests        int
 	EventPollMilliseconds        int
-	LKEEventPollMilliseconds     int
 	LKENodeReadyPollMilliseconds int
+
+	ObjEndpoint           string
+	ObjUsePathStyle       bool
+	ObjInsecureSkipVerify bool
+	ObjCABundle           string
+
+	ObjAccessKey   string
+	ObjSecretKey   string
+	ObjUseTempKeys bool
 }
 
 // Client returns a fully initialized Linode client.
@@ -49,7 +76,15 @@ func (c *Config) Client() linodego.Client {
 	oauthTransport := &oauth2.Transport{
 		Source: tokenSource,
 	}
-	loggingTransport := logging.NewTransport("Linode", oauthTransport)
+	rateLimitTransport := NewRateLimitTransport(
+		oauthTransport, c.APIRequestsPerSecond, c.APIBurst, c.MaxConcurrentRequests)
+	if c.MinRetryDelayMilliseconds != 0 {
+		rateLimitTransport.MinRetryDelay = time.Duration(c.MinRetryDelayMilliseconds) * time.Millisecond
+	}
+	if c.MaxRetryDelayMilliseconds != 0 {
+		rateLimitTransport.MaxRetryDelay = time.Duration(c.MaxRetryDelayMilliseconds) * time.Millisecond
+	}
+	loggingTransport := NewLoggingTransport("Linode", rateLimitTransport)
 
 	oauth2Client := &http.Client{
 		Transport: loggingTransport,
@@ -85,6 +120,34 @@ func (c *Config) Client() linodego.Client {
 	return client
 }
 
+// LoadConfigProfile populates any fields that have not been explicitly configured
+// from the given linode-cli config file profile.
+func (c *Config) LoadConfigProfile(profile *ConfigProfile) {
+	if c.AccessToken == "" {
+		c.AccessToken = profile.Token
+	}
+	if c.APIURL == "" {
+		c.APIURL = profile.APIURL
+	}
+	if c.APIVersion == "" {
+		c.APIVersion = profile.APIVersion
+	}
+}
+
+// LoadEnvironment populates any fields that have not been configured explicitly
+// or through a config file from the environment.
+func (c *Config) LoadEnvironment() {
+	if c.AccessToken == "" {
+		c.AccessToken = os.Getenv(tokenEnvVar)
+	}
+	if c.APIURL == "" {
+		c.APIURL = os.Getenv(urlEnvVar)
+	}
+	if c.APIVersion == "" {
+		c.APIVersion = os.Getenv(apiVersionEnvVar)
+	}
+}
+
 func terraformUserAgent(version string) string {
 	ua := fmt.Sprintf("HashiCorp Terraform/%s (+https://www.terraform.io) Terraform Plugin SDK/%s",
 		version, meta.SDKVersionString())
@@ -0,0 +1,146 @@
+package helper
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DefaultConfigProfile is the name of the profile used when no profile is specified
+	// and the config file does not declare a default user.
+	DefaultConfigProfile = "default"
+
+	configFileDefaultSection = "DEFAULT"
+	configFileDefaultUserKey = "default-user"
+)
+
+// ConfigProfile represents a single profile in a linode-cli configuration file.
+// Other keys of the profile, such as the default region, are ignored.
+type ConfigProfile struct {
+	Token      string
+	APIURL     string
+	APIVersion string
+}
+
+// DefaultConfigPath returns the path of the linode-cli configuration file.
+func DefaultConfigPath() string {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+
+		configDir = filepath.Join(homeDir, ".config")
+	}
+
+	return filepath.Join(configDir, "linode-cli")
+}
+
+// GetConfigProfile reads the given profile from the linode-cli configuration file at the given path.
+// If no profile is specified, the file's `default-user` is used.
+func GetConfigProfile(path, profile string) (*ConfigProfile, error) {
+	sections, err := parseConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile == "" {
+		profile = DefaultConfigProfile
+
+		if defaultUser, ok := sections[configFileDefaultSection][configFileDefaultUserKey]; ok && defaultUser != "" {
+			profile = defaultUser
+		}
+	}
+
+	section, ok := sections[profile]
+	if !ok {
+		return nil, fmt.Errorf("profile %q was not found in config file %s", profile, path)
+	}
+
+	// Keys that are not set on the profile fall back to the DEFAULT section
+	get := func(key string) string {
+		if value, ok := section[key]; ok {
+			return value
+		}
+
+		return sections[configFileDefaultSection][key]
+	}
+
+	result := &ConfigProfile{
+		Token:      get("token"),
+		APIURL:     get("api_url"),
+		APIVersion: get("api_version"),
+	}
+
+	// linode-cli stores the API location as separate host and scheme keys
+	if apiHost := get("api_host"); result.APIURL == "" && apiHost != "" {
+		scheme := get("api_scheme")
+		if scheme == "" {
+			scheme = "https"
+		}
+
+		result.APIURL = fmt.Sprintf("%s://%s", scheme, apiHost)
+	}
+
+	return result, nil
+}
+
+// parseConfigFile parses an INI file into a map of sections to keys and values.
+func parseConfigFile(path string) (map[string]map[string]string, error) {
+	if strings.HasPrefix(path, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve home directory: %s", err)
+		}
+
+		path = filepath.Join(homeDir, path[2:])
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config file: %s", err)
+	}
+	defer file.Close()
+
+	sections := map[string]map[string]string{
+		configFileDefaultSection: {},
+	}
+	current := configFileDefaultSection
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := sections[current]; !ok {
+				sections[current] = make(map[string]string)
+			}
+			continue
+		}
+
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("failed to parse config file %s: invalid line %d", path, lineNumber)
+		}
+
+		sections[current][strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %s", path, err)
+	}
+
+	return sections, nil
+}
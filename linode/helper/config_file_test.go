@@ -0,0 +1,153 @@
+package helper_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/linode/terraform-provider-linode/linode/helper"
+)
+
+const testConfigFile = `
+[DEFAULT]
+default-user = primary
+api_version = v4beta
+
+[primary]
+token = primary-token
+region = us-east
+
+[secondary]
+token = secondary-token
+api_host = api.example.com
+api_version = v4
+region = eu-west
+
+; comments are ignored
+[custom-url]
+token = custom-token
+api_url = http://localhost:8080
+`
+
+func writeTestConfigFile(t *testing.T, content string) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "linode-cli")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %s", err)
+	}
+
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+
+	path := filepath.Join(dir, "linode-cli")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config file: %s", err)
+	}
+
+	return path
+}
+
+func TestGetConfigProfile(t *testing.T) {
+	path := writeTestConfigFile(t, testConfigFile)
+
+	for _, tc := range []struct {
+		name     string
+		profile  string
+		expected helper.ConfigProfile
+	}{
+		{
+			name:    "default user",
+			profile: "",
+			expected: helper.ConfigProfile{
+				Token:      "primary-token",
+				APIVersion: "v4beta",
+			},
+		},
+		{
+			name:    "named profile with api host",
+			profile: "secondary",
+			expected: helper.ConfigProfile{
+				Token:      "secondary-token",
+				APIURL:     "https://api.example.com",
+				APIVersion: "v4",
+			},
+		},
+		{
+			name:    "named profile with api url",
+			profile: "custom-url",
+			expected: helper.ConfigProfile{
+				Token:      "custom-token",
+				APIURL:     "http://localhost:8080",
+				APIVersion: "v4beta",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			profile, err := helper.GetConfigProfile(path, tc.profile)
+			if err != nil {
+				t.Fatalf("failed to get config profile: %s", err)
+			}
+
+			if !reflect.DeepEqual(*profile, tc.expected) {
+				t.Errorf("expected %+v; got %+v", tc.expected, *profile)
+			}
+		})
+	}
+}
+
+func TestGetConfigProfile_errors(t *testing.T) {
+	path := writeTestConfigFile(t, testConfigFile)
+
+	if _, err := helper.GetConfigProfile(path, "missing"); err == nil {
+		t.Error("expected error for missing profile")
+	}
+
+	if _, err := helper.GetConfigProfile(filepath.Join(filepath.Dir(path), "missing"), ""); err == nil {
+		t.Error("expected error for missing config file")
+	}
+
+	invalidPath := writeTestConfigFile(t, "[default]\nnot a key value pair\n")
+	if _, err := helper.GetConfigProfile(invalidPath, ""); err == nil {
+		t.Error("expected error for invalid config file")
+	}
+}
+
+func TestConfigPrecedence(t *testing.T) {
+	path := writeTestConfigFile(t, testConfigFile)
+
+	profile, err := helper.GetConfigProfile(path, "secondary")
+	if err != nil {
+		t.Fatalf("failed to get config profile: %s", err)
+	}
+
+	t.Setenv("LINODE_TOKEN", "env-token")
+	t.Setenv("LINODE_URL", "https://env.example.com")
+	t.Setenv("LINODE_API_VERSION", "env-version")
+
+	// explicit values take precedence over the config file
+	config := &helper.Config{AccessToken: "explicit-token"}
+	config.LoadConfigProfile(profile)
+	config.LoadEnvironment()
+
+	if config.AccessToken != "explicit-token" {
+		t.Errorf("expected explicit token; got %s", config.AccessToken)
+	}
+
+	// config file values take precedence over the environment
+	if config.APIURL != "https://api.example.com" {
+		t.Errorf("expected config file api url; got %s", config.APIURL)
+	}
+
+	// the environment is used when neither is present
+	config = &helper.Config{}
+	config.LoadEnvironment()
+
+	if config.AccessToken != "env-token" || config.APIURL != "https://env.example.com" ||
+		config.APIVersion != "env-version" {
+		t.Errorf("expected values from environment; got %+v", config)
+	}
+}
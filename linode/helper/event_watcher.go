@@ -0,0 +1,472 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/linode/linodego"
+)
+
+const (
+	// DefaultEventPollInterval is the default interval between event polls.
+	DefaultEventPollInterval = 300 * time.Millisecond
+
+	// eventTimeFormat is the format of the created filter of the events endpoint.
+	eventTimeFormat = "2006-01-02T15:04:05"
+
+	// eventClockSkew is how far the watermark of a subscription is kept behind the local time
+	// to account for differences between the local and API clocks.
+	eventClockSkew = 10 * time.Second
+
+	// eventPageSize is the number of events listed by each poll.
+	eventPageSize = 100
+
+	// eventSubscriptionBuffer is the number of events buffered for each subscription.
+	// Events that do not fit are delivered again on a later poll.
+	eventSubscriptionBuffer = 64
+)
+
+// eventEntityFilterTypes are the entity types the events endpoint can filter on.
+var eventEntityFilterTypes = map[linodego.EntityType]bool{
+	linodego.EntityDisk:         true,
+	linodego.EntityLinode:       true,
+	linodego.EntityDomain:       true,
+	linodego.EntityNodebalancer: true,
+}
+
+// EventSource lists account events. It is implemented by *linodego.Client.
+type EventSource interface {
+	ListEvents(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Event, error)
+}
+
+// EventFilter selects the events delivered to an EventSubscription.
+type EventFilter struct {
+	// EntityID is the ID of the entity of the events, or 0 for any entity of the type.
+	EntityID   int
+	EntityType linodego.EntityType
+
+	// SecondaryEntityID is the ID of the secondary entity of the events, such as the disk of a disk event,
+	// or 0 for any secondary entity.
+	SecondaryEntityID int
+
+	// Actions are the actions of the events, or any action if empty.
+	Actions []linodego.EventAction
+
+	// MinStart is the earliest creation time of the events.
+	MinStart time.Time
+}
+
+func (f EventFilter) matches(event linodego.Event) bool {
+	if event.Entity == nil || event.Entity.Type != f.EntityType {
+		return false
+	}
+
+	if f.EntityID != 0 && eventEntityID(event.Entity) != f.EntityID {
+		return false
+	}
+
+	if f.SecondaryEntityID != 0 &&
+		(event.SecondaryEntity == nil || eventEntityID(event.SecondaryEntity) != f.SecondaryEntityID) {
+		return false
+	}
+
+	if event.Created != nil && event.Created.Before(f.MinStart.Truncate(time.Second)) {
+		return false
+	}
+
+	if len(f.Actions) == 0 {
+		return true
+	}
+
+	for _, action := range f.Actions {
+		if event.Action == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+// eventEntityID returns the numeric ID of an event entity, or 0 if it has none.
+func eventEntityID(entity *linodego.EventEntity) int {
+	switch id := entity.ID.(type) {
+	case float64:
+		return int(id)
+	case int:
+		return id
+	}
+
+	return 0
+}
+
+// EventSubscription receives the events matching a filter each time their status changes.
+type EventSubscription struct {
+	filter  EventFilter
+	watcher *EventWatcher
+
+	events chan linodego.Event
+	errs   chan error
+	done   chan struct{}
+	once   sync.Once
+
+	// since is the creation time from which events must still be listed for this subscription
+	since    time.Time
+	caughtUp bool
+	statuses map[int]linodego.EventStatus
+}
+
+// Events returns the channel the matching events are delivered on.
+func (s *EventSubscription) Events() <-chan linodego.Event {
+	return s.events
+}
+
+// Errors returns the channel errors listing events are delivered on.
+func (s *EventSubscription) Errors() <-chan error {
+	return s.errs
+}
+
+// Close stops the delivery of events to the subscription.
+func (s *EventSubscription) Close() {
+	s.once.Do(func() {
+		close(s.done)
+		s.watcher.unsubscribe(s)
+	})
+}
+
+// deliver sends the events of the subscription whose status has changed since they were last delivered,
+// without blocking on a subscriber that is behind. The watermark of the subscription is moved up to the
+// given time, or to the oldest event that has not finished or could not be delivered.
+func (s *EventSubscription) deliver(events []linodego.Event, since time.Time) {
+	var pending *time.Time
+	full := false
+
+	for _, event := range events {
+		if !s.filter.matches(event) {
+			continue
+		}
+
+		if event.Created != nil && event.Status != linodego.EventFinished && event.Status != linodego.EventFailed &&
+			event.Status != linodego.EventNotification && (pending == nil || event.Created.Before(*pending)) {
+			pending = event.Created
+		}
+
+		if status, ok := s.statuses[event.ID]; ok && status == event.Status {
+			continue
+		}
+
+		if !full {
+			select {
+			case s.events <- event:
+				s.statuses[event.ID] = event.Status
+				continue
+			case <-s.done:
+				return
+			default:
+				full = true
+			}
+		}
+
+		// Events that could not be delivered are listed again
+		if event.Created != nil && (pending == nil || event.Created.Before(*pending)) {
+			pending = event.Created
+		}
+	}
+
+	// Events that have not finished must be listed again until they do
+	if pending != nil && pending.Before(since) {
+		since = *pending
+	}
+
+	if since.After(s.since) {
+		s.since = since
+	}
+}
+
+func (s *EventSubscription) fail(err error) {
+	select {
+	case s.errs <- err:
+	default:
+	}
+}
+
+// EventWatcher polls the events of an account once for every resource waiting on them,
+// delivering each event to the subscriptions it matches.
+type EventWatcher struct {
+	source       EventSource
+	pollInterval time.Duration
+
+	mu            sync.Mutex
+	subscriptions map[*EventSubscription]struct{}
+	stop          chan struct{}
+
+	// pollMu keeps a poller that is being stopped from delivering alongside its replacement
+	pollMu sync.Mutex
+
+	// page is the page of the events since watermark listed by the next poll. Later pages are listed
+	// while the watermark stays the same after a full page, such as when it is held by an event
+	// that has not finished or a page of events was created in the same second.
+	watermark time.Time
+	page      int
+}
+
+// NewEventWatcher returns an EventWatcher listing events from the given source every pollInterval.
+// Events are only polled while there are subscriptions.
+func NewEventWatcher(source EventSource, pollInterval time.Duration) *EventWatcher {
+	if pollInterval <= 0 {
+		pollInterval = DefaultEventPollInterval
+	}
+
+	return &EventWatcher{
+		source:        source,
+		pollInterval:  pollInterval,
+		subscriptions: make(map[*EventSubscription]struct{}),
+	}
+}
+
+// Subscribe returns a subscription receiving the events matching the filter until it is closed.
+func (w *EventWatcher) Subscribe(filter EventFilter) *EventSubscription {
+	sub := &EventSubscription{
+		filter:   filter,
+		watcher:  w,
+		events:   make(chan linodego.Event, eventSubscriptionBuffer),
+		errs:     make(chan error, 1),
+		done:     make(chan struct{}),
+		since:    filter.MinStart,
+		statuses: make(map[int]linodego.EventStatus),
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subscriptions[sub] = struct{}{}
+
+	if w.stop == nil {
+		w.stop = make(chan struct{})
+		go w.run(w.stop)
+	}
+
+	return sub
+}
+
+func (w *EventWatcher) unsubscribe(sub *EventSubscription) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.subscriptions, sub)
+
+	if len(w.subscriptions) == 0 && w.stop != nil {
+		close(w.stop)
+		w.stop = nil
+	}
+}
+
+func (w *EventWatcher) run(stop chan struct{}) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.poll()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// poll lists a page of the events created since the watermark of every subscription and delivers them.
+// New subscriptions first catch up on the latest events of their entity, which may have been
+// created long before the watermark of the other subscriptions.
+func (w *EventWatcher) poll() {
+	w.pollMu.Lock()
+	defer w.pollMu.Unlock()
+
+	w.mu.Lock()
+	subs := make([]*EventSubscription, 0, len(w.subscriptions))
+	for sub := range w.subscriptions {
+		subs = append(subs, sub)
+	}
+	w.mu.Unlock()
+
+	var caughtUp []*EventSubscription
+
+	for _, sub := range subs {
+		if sub.caughtUp {
+			caughtUp = append(caughtUp, sub)
+			continue
+		}
+
+		listed := time.Now()
+
+		events, err := w.listEvents(sub.catchUpFilter(), 1)
+		if err != nil {
+			sub.fail(err)
+			continue
+		}
+
+		sub.deliver(events, listed.Add(-eventClockSkew))
+		sub.caughtUp = true
+	}
+
+	if len(caughtUp) == 0 {
+		return
+	}
+
+	watermark := caughtUp[0].since
+	for _, sub := range caughtUp[1:] {
+		if sub.since.Before(watermark) {
+			watermark = sub.since
+		}
+	}
+
+	if !watermark.Equal(w.watermark) {
+		w.watermark, w.page = watermark, 1
+	}
+
+	listed := time.Now()
+
+	events, err := w.listEvents(map[string]interface{}{
+		"created":   map[string]string{"+gte": watermark.UTC().Format(eventTimeFormat)},
+		"+order_by": "created",
+		"+order":    "asc",
+	}, w.page)
+	if err != nil {
+		for _, sub := range caughtUp {
+			sub.fail(err)
+		}
+		return
+	}
+
+	// The events after a full page are listed by the next poll. The events of the earlier pages are not
+	// listed again, so only the first page may move the watermark past them.
+	since := listed.Add(-eventClockSkew)
+	switch {
+	case w.page > 1:
+		since = watermark
+	case len(events) >= eventPageSize:
+		since = latestEventCreated(events)
+	}
+
+	if len(events) >= eventPageSize {
+		w.page++
+	} else {
+		w.page = 1
+	}
+
+	for _, sub := range caughtUp {
+		sub.deliver(events, since)
+	}
+}
+
+// latestEventCreated returns the creation time of the latest of the given events.
+func latestEventCreated(events []linodego.Event) time.Time {
+	var latest time.Time
+
+	for _, event := range events {
+		if event.Created != nil && event.Created.After(latest) {
+			latest = *event.Created
+		}
+	}
+
+	return latest
+}
+
+// catchUpFilter returns the API filter for the latest events that may match the subscription.
+func (s *EventSubscription) catchUpFilter() map[string]interface{} {
+	filter := map[string]interface{}{
+		"created":   map[string]string{"+gte": s.filter.MinStart.UTC().Format(eventTimeFormat)},
+		"+order_by": "created",
+		"+order":    "desc",
+	}
+
+	if len(s.filter.Actions) == 1 {
+		filter["action"] = s.filter.Actions[0]
+	}
+
+	if eventEntityFilterTypes[s.filter.EntityType] {
+		filter["entity.type"] = s.filter.EntityType
+
+		if s.filter.EntityID != 0 {
+			filter["entity.id"] = s.filter.EntityID
+		}
+	}
+
+	return filter
+}
+
+// listEvents lists a page of the events matching an API filter in the order they were created.
+func (w *EventWatcher) listEvents(filter map[string]interface{}, page int) ([]linodego.Event, error) {
+	filterJSON, err := json.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := w.source.ListEvents(context.Background(), &linodego.ListOptions{
+		PageOptions: &linodego.PageOptions{Page: page},
+		PageSize:    eventPageSize,
+		Filter:      string(filterJSON),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %s", err)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ID < events[j].ID
+	})
+
+	return events, nil
+}
+
+// WaitForEventFinished waits for an event of the given action on an entity, created after minStart,
+// to finish. It behaves like linodego's Client.WaitForEventFinished.
+func (w *EventWatcher) WaitForEventFinished(ctx context.Context, entityID int, entityType linodego.EntityType,
+	action linodego.EventAction, minStart time.Time, timeoutSeconds int) (*linodego.Event, error) {
+	return w.WaitForFilteredEventFinished(ctx, EventFilter{
+		EntityID:   entityID,
+		EntityType: entityType,
+		Actions:    []linodego.EventAction{action},
+		MinStart:   minStart,
+	}, timeoutSeconds)
+}
+
+// WaitForFilteredEventFinished waits for the first event matching a filter of a single action to finish.
+func (w *EventWatcher) WaitForFilteredEventFinished(
+	ctx context.Context, filter EventFilter, timeoutSeconds int) (*linodego.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+
+	entityType, entityID, action := filter.EntityType, filter.EntityID, filter.Actions[0]
+
+	log.Printf("[INFO] Waiting %d seconds for %s events since %v for %s %d",
+		timeoutSeconds, action, filter.MinStart, entityType, entityID)
+
+	sub := w.Subscribe(filter)
+	defer sub.Close()
+
+	for {
+		select {
+		case event := <-sub.Events():
+			switch event.Status {
+			case linodego.EventFailed:
+				return &event, fmt.Errorf("%s %d action %s failed", entityType, entityID, action)
+			case linodego.EventFinished:
+				log.Printf("[INFO] %s %d action %s is finished", entityType, entityID, action)
+				return &event, nil
+			}
+
+			log.Printf("[INFO] %s %d action %s is %s", entityType, entityID, action, event.Status)
+
+		case err := <-sub.Errors():
+			return nil, err
+
+		case <-ctx.Done():
+			return nil, fmt.Errorf("Error waiting for Event Status '%s' of %s %d action '%s': %s",
+				linodego.EventFinished, entityType, entityID, action, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package helper_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+)
+
+// testEventSource is a fake event source that filters events on their creation time, action and entity,
+// and pages them in the order they were created.
+type testEventSource struct {
+	mu       sync.Mutex
+	events   []linodego.Event
+	calls    int
+	maxCount int
+	err      error
+}
+
+func (s *testEventSource) add(id int, entityID int, action linodego.EventAction, status linodego.EventStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	created := time.Now().UTC().Truncate(time.Second)
+	s.events = append(s.events, linodego.Event{
+		ID:      id,
+		Action:  action,
+		Status:  status,
+		Created: &created,
+		Entity:  &linodego.EventEntity{ID: float64(entityID), Type: linodego.EntityLinode},
+	})
+}
+
+func (s *testEventSource) setStatus(id int, status linodego.EventStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.events {
+		if s.events[i].ID == id {
+			s.events[i].Status = status
+		}
+	}
+}
+
+func (s *testEventSource) ListEvents(ctx context.Context, opts *linodego.ListOptions) ([]linodego.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	var filter struct {
+		Created    map[string]string `json:"created"`
+		Order      string            `json:"+order"`
+		Action     string            `json:"action"`
+		EntityType string            `json:"entity.type"`
+		EntityID   int               `json:"entity.id"`
+	}
+	if err := json.Unmarshal([]byte(opts.Filter), &filter); err != nil {
+		return nil, err
+	}
+
+	since, err := time.Parse("2006-01-02T15:04:05", filter.Created["+gte"])
+	if err != nil {
+		return nil, err
+	}
+
+	var result []linodego.Event
+	for _, event := range s.events {
+		if event.Created.Before(since) ||
+			(filter.Action != "" && string(event.Action) != filter.Action) ||
+			(filter.EntityType != "" && string(event.Entity.Type) != filter.EntityType) ||
+			(filter.EntityID != 0 && int(event.Entity.ID.(float64)) != filter.EntityID) {
+			continue
+		}
+
+		result = append(result, event)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if filter.Order == "desc" {
+			return result[i].Created.After(*result[j].Created)
+		}
+		return result[i].Created.Before(*result[j].Created)
+	})
+
+	if opts.PageOptions != nil && opts.PageSize > 0 {
+		start := (opts.PageOptions.Page - 1) * opts.PageSize
+		if start > len(result) {
+			start = len(result)
+		}
+
+		end := start + opts.PageSize
+		if end > len(result) {
+			end = len(result)
+		}
+
+		result = result[start:end]
+	}
+
+	if len(result) > s.maxCount {
+		s.maxCount = len(result)
+	}
+
+	return result, nil
+}
+
+func (s *testEventSource) listCalls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls
+}
+
+func TestEventWatcherWaitForEventFinished(t *testing.T) {
+	source := &testEventSource{}
+	watcher := helper.NewEventWatcher(source, 10*time.Millisecond)
+	minStart := time.Now().Add(-time.Second)
+
+	// An event from before minStart must be ignored
+	source.events = append(source.events, linodego.Event{
+		ID: 1, Action: linodego.ActionLinodeBoot, Status: linodego.EventFinished,
+		Created: &time.Time{}, Entity: &linodego.EventEntity{ID: float64(100), Type: linodego.EntityLinode},
+	})
+	source.add(2, 100, linodego.ActionLinodeBoot, linodego.EventStarted)
+	source.add(3, 200, linodego.ActionLinodeBoot, linodego.EventStarted)
+
+	var wg sync.WaitGroup
+	results := make([]*linodego.Event, 2)
+	errs := make([]error, 2)
+
+	for i, entityID := range []int{100, 200} {
+		wg.Add(1)
+		go func(i, entityID int) {
+			defer wg.Done()
+			results[i], errs[i] = watcher.WaitForEventFinished(
+				context.Background(), entityID, linodego.EntityLinode, linodego.ActionLinodeBoot, minStart, 5)
+		}(i, entityID)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	source.setStatus(2, linodego.EventFinished)
+	source.setStatus(3, linodego.EventFailed)
+	wg.Wait()
+
+	if errs[0] != nil || results[0] == nil || results[0].ID != 2 {
+		t.Errorf("expected event 2 to finish, got %v: %v", results[0], errs[0])
+	}
+
+	if errs[1] == nil || results[1] == nil || results[1].ID != 3 {
+		t.Errorf("expected event 3 to fail, got %v: %v", results[1], errs[1])
+	}
+
+	// Both waiters share a single poll of every event after catching up
+	if calls := source.listCalls(); calls > 30 {
+		t.Errorf("expected events to be polled once per interval, got %d calls", calls)
+	}
+
+	// Polling stops once nothing is waiting
+	calls := source.listCalls()
+	time.Sleep(50 * time.Millisecond)
+	if source.listCalls() != calls {
+		t.Errorf("expected polling to stop without subscriptions")
+	}
+}
+
+func TestEventWatcherSubscribe(t *testing.T) {
+	source := &testEventSource{}
+	watcher := helper.NewEventWatcher(source, 10*time.Millisecond)
+
+	sub := watcher.Subscribe(helper.EventFilter{
+		EntityType: linodego.EntityLinode,
+		Actions:    []linodego.EventAction{linodego.ActionLinodeDelete},
+		MinStart:   time.Now().Add(-time.Second),
+	})
+	defer sub.Close()
+
+	source.add(1, 100, linodego.ActionLinodeDelete, linodego.EventStarted)
+	source.add(2, 200, linodego.ActionLinodeBoot, linodego.EventFinished)
+	source.add(3, 300, linodego.ActionLinodeDelete, linodego.EventFinished)
+
+	expected := []struct {
+		id     int
+		status linodego.EventStatus
+	}{
+		{1, linodego.EventStarted},
+		{3, linodego.EventFinished},
+		{1, linodego.EventFinished},
+	}
+
+	for i, e := range expected {
+		if i == 2 {
+			source.setStatus(1, linodego.EventFinished)
+		}
+
+		select {
+		case event := <-sub.Events():
+			if event.ID != e.id || event.Status != e.status {
+				t.Fatalf("expected event %d to be %s, got event %d %s", e.id, e.status, event.ID, event.Status)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for event %d", e.id)
+		}
+	}
+
+	// Events are only delivered again when their status changes
+	select {
+	case event := <-sub.Events():
+		t.Fatalf("unexpected event %d %s", event.ID, event.Status)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestEventWatcherErrors(t *testing.T) {
+	source := &testEventSource{err: errors.New("unavailable")}
+	watcher := helper.NewEventWatcher(source, 10*time.Millisecond)
+
+	_, err := watcher.WaitForEventFinished(
+		context.Background(), 100, linodego.EntityLinode, linodego.ActionLinodeBoot, time.Now(), 5)
+	if err == nil {
+		t.Fatal("expected the error listing events to be returned")
+	}
+}
+
+func TestEventWatcherPaging(t *testing.T) {
+	source := &testEventSource{}
+	watcher := helper.NewEventWatcher(source, 10*time.Millisecond)
+
+	// More events than fit in a page or in the buffer of a subscription are created in the same second
+	for id := 1; id <= 250; id++ {
+		source.add(id, id, linodego.ActionLinodeDelete, linodego.EventFinished)
+	}
+
+	sub := watcher.Subscribe(helper.EventFilter{
+		EntityType: linodego.EntityLinode,
+		Actions:    []linodego.EventAction{linodego.ActionLinodeDelete},
+		MinStart:   time.Now().Add(-time.Minute),
+	})
+	defer sub.Close()
+
+	delivered := make(map[int]bool)
+	timeout := time.After(5 * time.Second)
+
+	for len(delivered) < 250 {
+		select {
+		case event := <-sub.Events():
+			if delivered[event.ID] {
+				t.Fatalf("event %d was delivered twice", event.ID)
+			}
+			delivered[event.ID] = true
+		case <-timeout:
+			t.Fatalf("timed out after %d of 250 events were delivered", len(delivered))
+		}
+	}
+
+	source.mu.Lock()
+	defer source.mu.Unlock()
+
+	if source.maxCount > 100 {
+		t.Errorf("expected events to be listed a page at a time, got %d events in a single list", source.maxCount)
+	}
+}
+
+func TestEventWatcherPagingPastPendingEvent(t *testing.T) {
+	source := &testEventSource{}
+	watcher := helper.NewEventWatcher(source, 10*time.Millisecond)
+
+	// An event that has not finished holds the watermark of its subscription
+	created := time.Now().UTC().Add(-5 * time.Second).Truncate(time.Second)
+	source.events = append(source.events, linodego.Event{
+		ID: 1, Action: linodego.ActionLinodeBoot, Status: linodego.EventStarted,
+		Created: &created, Entity: &linodego.EventEntity{ID: float64(1), Type: linodego.EntityLinode},
+	})
+
+	pending := watcher.Subscribe(helper.EventFilter{
+		EntityID:   1,
+		EntityType: linodego.EntityLinode,
+		Actions:    []linodego.EventAction{linodego.ActionLinodeBoot},
+		MinStart:   time.Now().Add(-time.Minute),
+	})
+	defer pending.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	waitDone := make(chan error, 1)
+	go func() {
+		_, err := watcher.WaitForEventFinished(ctx, 2, linodego.EntityLinode, linodego.ActionLinodeDelete,
+			time.Now().Add(-time.Minute), 5)
+		waitDone <- err
+	}()
+
+	// Both subscriptions catch up before more than a page of events is created
+	for calls := source.listCalls(); source.listCalls() < calls+4; {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	for id := 3; id <= 152; id++ {
+		source.add(id, id, linodego.ActionLinodeCreate, linodego.EventFinished)
+	}
+	source.add(153, 2, linodego.ActionLinodeDelete, linodego.EventFinished)
+
+	if err := <-waitDone; err != nil {
+		t.Fatalf("expected the event after a full page to be delivered: %s", err)
+	}
+
+	// The pending event is still listed once it finishes
+	source.setStatus(1, linodego.EventFinished)
+
+	for {
+		select {
+		case event := <-pending.Events():
+			if event.ID == 1 && event.Status == linodego.EventFinished {
+				return
+			}
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for the pending event to finish")
+		}
+	}
+}
+
+func TestEventWatcherSlowSubscriber(t *testing.T) {
+	source := &testEventSource{}
+	watcher := helper.NewEventWatcher(source, 10*time.Millisecond)
+
+	// A subscription that never receives its events must not hold up the others
+	idle := watcher.Subscribe(helper.EventFilter{
+		EntityID:   100,
+		EntityType: linodego.EntityLinode,
+		MinStart:   time.Now().Add(-time.Minute),
+	})
+	defer idle.Close()
+
+	for id := 1; id <= 90; id++ {
+		source.add(id, 100, linodego.ActionLinodeBoot, linodego.EventStarted)
+	}
+	source.add(91, 200, linodego.ActionLinodeBoot, linodego.EventFinished)
+
+	event, err := watcher.WaitForEventFinished(
+		context.Background(), 200, linodego.EntityLinode, linodego.ActionLinodeBoot, time.Now().Add(-time.Minute), 5)
+	if err != nil || event.ID != 91 {
+		t.Fatalf("expected event 91 to finish, got %v: %v", event, err)
+	}
+}
+
+func TestEventWatcherSecondaryEntity(t *testing.T) {
+	source := &testEventSource{}
+	watcher := helper.NewEventWatcher(source, 10*time.Millisecond)
+
+	source.add(1, 100, linodego.ActionDiskDelete, linodego.EventFinished)
+	source.add(2, 100, linodego.ActionDiskDelete, linodego.EventStarted)
+	source.events[0].SecondaryEntity = &linodego.EventEntity{ID: float64(10), Type: linodego.EntityDisk}
+	source.events[1].SecondaryEntity = &linodego.EventEntity{ID: float64(20), Type: linodego.EntityDisk}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		source.setStatus(2, linodego.EventFinished)
+	}()
+
+	// The finished deletion of another disk of the instance must not be returned
+	event, err := watcher.WaitForFilteredEventFinished(context.Background(), helper.EventFilter{
+		EntityID:          100,
+		EntityType:        linodego.EntityLinode,
+		SecondaryEntityID: 20,
+		Actions:           []linodego.EventAction{linodego.ActionDiskDelete},
+		MinStart:          time.Now().Add(-time.Minute),
+	}, 5)
+	if err != nil || event.ID != 2 {
+		t.Fatalf("expected event 2 to finish, got %v: %v", event, err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -34,6 +35,10 @@ type FilterFlattenFunc func(object interface{}) map[string]interface{}
 
 // FilterAttribute stores various configuration options about a single
 // filterable field.
+//
+// Nested fields are declared with a dotted path (e.g. `specs.memory`), which is resolved
+// against every element of any lists of maps along the way. Nested fields are always
+// handled on the client.
 type FilterAttribute struct {
 	// Whether this field can be filtered on at an API level.
 	// If false, this filter will be handled on the client.
@@ -43,46 +48,97 @@ type FilterAttribute struct {
 	TypeFunc FilterTypeFunc
 }
 
+// filterMatchModes are the accepted values of a filter's match_by field.
+var filterMatchModes = []string{
+	"exact", "substring", "sub", "re", "regex", "gt", "gte", "lt", "lte", "not", "not_regex",
+}
+
+// filterAPIOperators maps the match_by modes that can be handled at an API level
+// to their Linode filter operators.
+var filterAPIOperators = map[string]string{
+	"gt":  "+gt",
+	"gte": "+gte",
+	"lt":  "+lt",
+	"lte": "+lte",
+	"not": "+neq",
+}
+
+// filterNegatedModes maps the negated match_by modes to the modes they negate.
+var filterNegatedModes = map[string]string{
+	"not":       "exact",
+	"not_regex": "regex",
+}
+
 // FilterSchema should be referenced in a schema configuration in order to
 // enable filter functionality
 func (f FilterConfig) FilterSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem:     f.filterResource(),
+	}
+}
+
+// FilterGroupSchema should be referenced in a schema configuration alongside FilterSchema
+// in order to enable grouped filter functionality
+func (f FilterConfig) FilterGroupSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeList,
 		Optional: true,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
-				"name": {
-					Type:             schema.TypeString,
-					Description:      "The name of the attribute to filter on.",
-					ValidateDiagFunc: f.ValidateDiagFunc(false),
-					Required:         true,
+				"match": {
+					Type:         schema.TypeString,
+					Description:  "Whether any or all of the filters in this group must match.",
+					Optional:     true,
+					Default:      "any",
+					ValidateFunc: validation.StringInSlice([]string{"any", "all"}, false),
 				},
-				"values": {
+				"filter": {
 					Type:        schema.TypeList,
-					Elem:        &schema.Schema{Type: schema.TypeString},
-					Description: "The value(s) to be used in the filter.",
+					Description: "The filters in this group.",
 					Required:    true,
-				},
-				"match_by": {
-					Type:        schema.TypeString,
-					Description: "The type of comparison to use for this filter.",
-					Optional:    true,
-					Default:     "exact",
-					ValidateFunc: validation.StringInSlice([]string{"exact", "substring", "sub", "re", "regex"},
-						false),
+					MinItems:    1,
+					Elem:        f.filterResource(),
 				},
 			},
 		},
 	}
 }
 
+func (f FilterConfig) filterResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Description:      "The name of the attribute to filter on.",
+				ValidateDiagFunc: f.ValidateDiagFunc(false),
+				Required:         true,
+			},
+			"values": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The value(s) to be used in the filter.",
+				Required:    true,
+			},
+			"match_by": {
+				Type:         schema.TypeString,
+				Description:  "The type of comparison to use for this filter.",
+				Optional:     true,
+				Default:      "exact",
+				ValidateFunc: validation.StringInSlice(filterMatchModes, false),
+			},
+		},
+	}
+}
+
 // OrderBySchema should be referenced in a schema configuration in order to
 // enable filter ordering functionality
 func (f FilterConfig) OrderBySchema() *schema.Schema {
 	return &schema.Schema{
 		Type:             schema.TypeString,
 		Optional:         true,
-		ValidateDiagFunc: f.ValidateDiagFunc(true),
+		ValidateDiagFunc: f.ValidateDiagFunc(false),
 		Description:      "The attribute to order the results by.",
 	}
 }
@@ -99,68 +155,128 @@ func (f FilterConfig) OrderSchema() *schema.Schema {
 
 // ConstructFilterString constructs a Linode filter JSON string from each filter element in the schema
 func (f FilterConfig) ConstructFilterString(d *schema.ResourceData) (string, error) {
-	filters := d.Get("filter").([]interface{})
 	resultMap := make(map[string]interface{})
 
-	if len(filters) < 1 {
+	rootFilter, err := f.constructAPIFilters(d.Get("filter").([]interface{}))
+	if err != nil {
+		return "", err
+	}
+
+	for _, group := range getFilterGroups(d) {
+		groupFilter, err := f.constructAPIGroupFilter(group.(map[string]interface{}))
+		if err != nil {
+			return "", err
+		}
+
+		if groupFilter != nil {
+			rootFilter = append(rootFilter, groupFilter)
+		}
+	}
+
+	if len(rootFilter) > 0 {
+		resultMap["+and"] = rootFilter
+	}
+
+	// Other attributes are ordered on the client
+	if orderBy, ok := d.GetOk("order_by"); ok && f.apiFilterable(orderBy.(string)) {
+		resultMap["+order_by"] = orderBy
+		resultMap["+order"] = d.Get("order")
+	}
+
+	if len(resultMap) < 1 {
 		return "{}", nil
 	}
 
-	var rootFilter []interface{}
+	result, err := json.Marshal(resultMap)
+	if err != nil {
+		return "", err
+	}
 
-	for _, filter := range filters {
-		filter := filter.(map[string]interface{})
+	return string(result), nil
+}
 
-		name := filter["name"].(string)
-		values := filter["values"].([]interface{})
-		matchBy := filter["match_by"].(string)
+// constructAPIFilters returns the API filters for each of the given filters that can be
+// handled at an API level.
+func (f FilterConfig) constructAPIFilters(filters []interface{}) ([]interface{}, error) {
+	var result []interface{}
 
-		// Defer this logic to the client
-		if matchBy != "exact" {
-			continue
+	for _, filter := range filters {
+		apiFilter, err := f.constructAPIFilter(filter.(map[string]interface{}))
+		if err != nil {
+			return nil, err
 		}
 
-		// Defer this logic to the client if not API-filterable
-		if cfg, ok := f[name]; !ok || !cfg.APIFilterable {
-			continue
+		if apiFilter != nil {
+			result = append(result, apiFilter)
 		}
+	}
 
-		subFilter := make([]interface{}, len(values))
+	return result, nil
+}
 
-		for i, value := range values {
-			value, err := f[name].TypeFunc(value.(string))
-			if err != nil {
-				return "", err
-			}
+// constructAPIFilter returns the API filter for a single filter element,
+// or nil if the filter must be handled on the client.
+func (f FilterConfig) constructAPIFilter(filter map[string]interface{}) (map[string]interface{}, error) {
+	// Defer this logic to the client
+	if !f.isAPIFilter(filter) {
+		return nil, nil
+	}
+
+	name := filter["name"].(string)
+	values := filter["values"].([]interface{})
+	matchBy := filter["match_by"].(string)
+	operator := filterAPIOperators[matchBy]
+
+	subFilter := make([]interface{}, len(values))
 
-			valueFilter := make(map[string]interface{})
-			valueFilter[name] = value
+	for i, value := range values {
+		value, err := f[name].TypeFunc(value.(string))
+		if err != nil {
+			return nil, err
+		}
 
-			subFilter[i] = valueFilter
+		if operator != "" {
+			value = map[string]interface{}{operator: value}
 		}
 
-		rootFilter = append(rootFilter, map[string]interface{}{
-			"+or": subFilter,
-		})
+		valueFilter := make(map[string]interface{})
+		valueFilter[name] = value
+
+		subFilter[i] = valueFilter
 	}
 
-	if len(rootFilter) < 1 {
-		return "{}", nil
+	// An item must differ from every value, but only match one of the others
+	if matchBy == "not" {
+		return map[string]interface{}{"+and": subFilter}, nil
 	}
 
-	resultMap["+and"] = rootFilter
+	return map[string]interface{}{"+or": subFilter}, nil
+}
 
-	if orderBy, ok := d.GetOk("order_by"); ok {
-		resultMap["+order_by"] = orderBy
-		resultMap["+order"] = d.Get("order")
-	}
+// constructAPIGroupFilter returns the API filter for a filter group, or nil if
+// the group must be handled on the client.
+func (f FilterConfig) constructAPIGroupFilter(group map[string]interface{}) (map[string]interface{}, error) {
+	filters := group["filter"].([]interface{})
 
-	result, err := json.Marshal(resultMap)
+	subFilter, err := f.constructAPIFilters(filters)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return string(result), nil
+	if len(subFilter) < 1 {
+		return nil, nil
+	}
+
+	if group["match"].(string) == "all" {
+		return map[string]interface{}{"+and": subFilter}, nil
+	}
+
+	// Narrowing an `any` group on the API would drop items matched by its client-side filters
+	if len(subFilter) < len(filters) {
+		return nil, nil
+	}
+
+	return map[string]interface{}{"+or": subFilter}, nil
 }
 
 // FilterResults filters the given results on the client-side filters present in the resource.
@@ -208,22 +324,9 @@ func (f FilterConfig) FilterDataSource(
 		return nil, fmt.Errorf("failed to construct filter: %s", err)
 	}
 
-	// Call linode list function defined by data source
-	items, err := listFunc(ctx, &client, &linodego.ListOptions{
-		Filter: filter,
-	})
+	itemsFiltered, err := f.ListFilteredItems(ctx, d, &client, filter, listFunc, flattenFunc)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list linode items: %s", err)
-	}
-
-	itemsFlattened := make([]interface{}, len(items))
-	for i, image := range items {
-		itemsFlattened[i] = flattenFunc(image)
-	}
-
-	itemsFiltered, err := f.FilterResults(d, itemsFlattened)
-	if err != nil {
-		return nil, fmt.Errorf("failed to filter returned data: %s", err)
+		return nil, err
 	}
 
 	d.SetId(filterID)
@@ -231,34 +334,144 @@ func (f FilterConfig) FilterDataSource(
 	return itemsFiltered, nil
 }
 
-// GetValidFilters returns a slice of valid filters for the filter config.
+// GetValidFilters returns a sorted slice of valid filters for the filter config.
 func (f FilterConfig) GetValidFilters(apiOnly bool) []string {
 	result := make([]string, 0)
 
-	for k, v := range f {
-		if apiOnly && !v.APIFilterable {
+	for k := range f {
+		if apiOnly && !f.apiFilterable(k) {
 			continue
 		}
 
 		result = append(result, k)
 	}
 
+	sort.Strings(result)
+
 	return result
 }
 
+// FiltersOn returns whether any filter of the data source, including those in filter groups,
+// is on the given field or one of its nested fields.
+func (f FilterConfig) FiltersOn(d *schema.ResourceData, name string) bool {
+	filters := d.Get("filter").([]interface{})
+
+	for _, group := range getFilterGroups(d) {
+		filters = append(filters, group.(map[string]interface{})["filter"].([]interface{})...)
+	}
+
+	for _, filter := range filters {
+		filterName := filter.(map[string]interface{})["name"].(string)
+
+		if filterOn(filterName, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OrdersOn returns whether the results of a data source are ordered on the attribute with
+// the given name or on any attribute nested under it.
+func (f FilterConfig) OrdersOn(d *schema.ResourceData, name string) bool {
+	orderBy, _ := d.Get("order_by").(string)
+
+	return filterOn(orderBy, name)
+}
+
+// PrefilterFunc returns a function reporting whether an item may match the filters of a data source
+// before its attributes under the given name are known, such as attributes that must be listed for
+// each item. Filters that depend on these attributes are assumed to match. The filters are read
+// once so that the function can be called while listing pages concurrently.
+func (f FilterConfig) PrefilterFunc(
+	d *schema.ResourceData, name string) func(item map[string]interface{}) (bool, error) {
+	filters := filtersNotOn(d.Get("filter").([]interface{}), name)
+
+	type filterGroup struct {
+		filters []interface{}
+		all     bool
+	}
+
+	var groups []filterGroup
+
+	for _, group := range getFilterGroups(d) {
+		group := group.(map[string]interface{})
+		groupFilters := group["filter"].([]interface{})
+		all := group["match"].(string) == "all"
+
+		known := filtersNotOn(groupFilters, name)
+
+		// A group matching any of its filters may match on an unknown attribute
+		if !all && len(known) < len(groupFilters) {
+			continue
+		}
+
+		groups = append(groups, filterGroup{filters: known, all: all})
+	}
+
+	return func(item map[string]interface{}) (bool, error) {
+		match, err := f.itemMatchesFilters(filters, true, item)
+		if err != nil || !match {
+			return false, err
+		}
+
+		for _, group := range groups {
+			match, err := f.itemMatchesFilters(group.filters, group.all, item)
+			if err != nil || !match {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+}
+
+// filterOn returns whether an attribute is the one with the given name or is nested under it.
+func filterOn(attribute, name string) bool {
+	return attribute == name || strings.HasPrefix(attribute, name+".")
+}
+
+// filtersNotOn returns the filters that are not on the attribute with the given name or nested under it.
+func filtersNotOn(filters []interface{}, name string) []interface{} {
+	result := make([]interface{}, 0, len(filters))
+
+	for _, filter := range filters {
+		if !filterOn(filter.(map[string]interface{})["name"].(string), name) {
+			result = append(result, filter)
+		}
+	}
+
+	return result
+}
+
+// isAPIFilter returns whether the given filter element can be handled at an API level.
+func (f FilterConfig) isAPIFilter(filter map[string]interface{}) bool {
+	matchBy := filter["match_by"].(string)
+
+	if _, ok := filterAPIOperators[matchBy]; !ok && matchBy != "exact" {
+		return false
+	}
+
+	return f.apiFilterable(filter["name"].(string))
+}
+
+// apiFilterable returns whether the given field can be filtered on at an API level.
+func (f FilterConfig) apiFilterable(name string) bool {
+	cfg, ok := f[name]
+	return ok && cfg.APIFilterable && !strings.Contains(name, ".")
+}
+
 // ValidateDiagFunc should be plugged into the `filter` field of a filterable data source.
 func (f FilterConfig) ValidateDiagFunc(apiOnly bool) schema.SchemaValidateDiagFunc {
 	return func(i interface{}, path cty.Path) diag.Diagnostics {
 		val := i.(string)
 
-		cfg, ok := f[val]
-
-		if !ok {
+		if _, ok := f[val]; !ok {
 			return diag.Errorf("\"%s\" is not a filterable field. Valid filters: %s",
 				val, strings.Join(f.GetValidFilters(false), ", "))
 		}
 
-		if apiOnly && !cfg.APIFilterable {
+		if apiOnly && !f.apiFilterable(val) {
 			return diag.Errorf("\"%s\" is an unsupported filter for this field. Valid filters: %s",
 				val, strings.Join(f.GetValidFilters(true), ", "))
 		}
@@ -270,9 +483,11 @@ func (f FilterConfig) ValidateDiagFunc(apiOnly bool) schema.SchemaValidateDiagFu
 // GetFilterID creates a unique ID specific to the current filter data source
 func (f FilterConfig) GetFilterID(d *schema.ResourceData) (string, error) {
 	idMap := map[string]interface{}{
-		"filter":   d.Get("filter"),
-		"order":    d.Get("order"),
-		"order_by": d.Get("order_by"),
+		"filter":       d.Get("filter"),
+		"filter_group": getFilterGroups(d),
+		"limit":        d.Get("limit"),
+		"order":        d.Get("order"),
+		"order_by":     d.Get("order_by"),
 	}
 
 	result, err := json.Marshal(idMap)
@@ -285,13 +500,14 @@ func (f FilterConfig) GetFilterID(d *schema.ResourceData) (string, error) {
 }
 
 // FilterLatest returns only the latest element in the given slice only if `latest` == true.
+// Of several elements created at the same time, the first in the given order is returned.
 func (f FilterConfig) FilterLatest(d *schema.ResourceData, items []map[string]interface{}) []map[string]interface{} {
 	if !d.Get("latest").(bool) {
 		return items
 	}
 
 	if item := f.GetLatestCreated(items); item != nil {
-		return []map[string]interface{}{f.GetLatestCreated(items)}
+		return []map[string]interface{}{item}
 	}
 
 	return []map[string]interface{}{}
@@ -310,7 +526,7 @@ func (f FilterConfig) GetLatestCreated(data []map[string]interface{}) map[string
 
 		createdTime, err := time.Parse(time.RFC3339, created.(string))
 		if err != nil {
-			return nil
+			continue
 		}
 
 		if latestEntity != nil && !createdTime.After(latestCreated) {
@@ -328,7 +544,28 @@ func (f FilterConfig) itemMatchesFilter(
 	d *schema.ResourceData,
 	item map[string]interface{}) (bool, error) {
 
-	filters := d.Get("filter").([]interface{})
+	match, err := f.itemMatchesFilters(d.Get("filter").([]interface{}), true, item)
+	if err != nil || !match {
+		return false, err
+	}
+
+	for _, group := range getFilterGroups(d) {
+		group := group.(map[string]interface{})
+
+		match, err := f.itemMatchesFilters(group["filter"].([]interface{}), group["match"].(string) == "all", item)
+		if err != nil || !match {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// itemMatchesFilters returns whether the item matches all or any of the given filters.
+func (f FilterConfig) itemMatchesFilters(
+	filters []interface{},
+	all bool,
+	item map[string]interface{}) (bool, error) {
 
 	for _, filter := range filters {
 		filter := filter.(map[string]interface{})
@@ -337,22 +574,48 @@ func (f FilterConfig) itemMatchesFilter(
 		values := filter["values"].([]interface{})
 		matchBy := filter["match_by"].(string)
 
-		itemValue, ok := item[name]
+		itemValues, ok := lookupFilterPath(item, name)
 		if !ok {
 			return false, fmt.Errorf("\"%v\" is not a valid attribute", name)
 		}
 
-		valid, err := f.validateFilter(matchBy, name, ExpandStringList(values), itemValue)
+		valid, err := f.validateFilterValues(matchBy, name, ExpandStringList(values), itemValues)
 		if err != nil {
 			return false, err
 		}
 
-		if !valid {
-			return false, nil
+		if valid != all {
+			return valid, nil
 		}
 	}
 
-	return true, nil
+	return all, nil
+}
+
+// validateFilterValues returns whether any of the values found at a filter's path match the filter,
+// or whether none of them do for negated filters.
+func (f FilterConfig) validateFilterValues(
+	matchBy, name string,
+	values []string,
+	itemValues []interface{}) (bool, error) {
+
+	positive, negated := filterNegatedModes[matchBy]
+	if !negated {
+		positive = matchBy
+	}
+
+	for _, itemValue := range itemValues {
+		valid, err := f.validateFilter(positive, name, values, itemValue)
+		if err != nil {
+			return false, err
+		}
+
+		if valid {
+			return !negated, nil
+		}
+	}
+
+	return negated, nil
 }
 
 func (f FilterConfig) validateFilter(
@@ -395,6 +658,8 @@ func (f FilterConfig) validateFilter(
 		return validateFilterSubstring(name, valuesNormalized, itemValue)
 	case "re", "regex":
 		return validateFilterRegex(name, valuesNormalized, itemValue)
+	case "gt", "gte", "lt", "lte":
+		return validateFilterCompare(matchBy, name, valuesNormalized, itemValue)
 	}
 
 	return true, nil
@@ -447,6 +712,130 @@ func validateFilterRegex(name string, values []interface{}, result interface{})
 	return false, nil
 }
 
+func validateFilterCompare(matchBy, name string, values []interface{}, result interface{}) (bool, error) {
+	for _, value := range values {
+		cmp, err := compareFilterValues(name, result, value)
+		if err != nil {
+			return false, err
+		}
+
+		switch {
+		case matchBy == "gt" && cmp > 0,
+			matchBy == "gte" && cmp >= 0,
+			matchBy == "lt" && cmp < 0,
+			matchBy == "lte" && cmp <= 0:
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// compareFilterValues compares two numbers or two strings, returning -1, 0 or 1.
+func compareFilterValues(name string, a, b interface{}) (int, error) {
+	aValue, bValue := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	if aNum, ok := filterNumber(aValue); ok {
+		if bNum, ok := filterNumber(bValue); ok {
+			switch {
+			case aNum < bNum:
+				return -1, nil
+			case aNum > bNum:
+				return 1, nil
+			}
+
+			return 0, nil
+		}
+	}
+
+	if aValue.Kind() == reflect.String && bValue.Kind() == reflect.String {
+		return strings.Compare(aValue.String(), bValue.String()), nil
+	}
+
+	return 0, fmt.Errorf("\"%s\" (type %s) cannot be compared to %v", name, reflect.TypeOf(a), b)
+}
+
+func filterNumber(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	}
+
+	return 0, false
+}
+
+// lookupFilterPath returns every value at the given dotted path of a flattened item.
+// Lists along the path are traversed element by element, and lists found at the end
+// of the path are expanded into their elements.
+func lookupFilterPath(item map[string]interface{}, name string) ([]interface{}, bool) {
+	path := strings.Split(name, ".")
+
+	value, ok := item[path[0]]
+	if !ok {
+		return nil, false
+	}
+
+	return collectFilterValues(reflect.ValueOf(value), path[1:]), true
+}
+
+func collectFilterValues(value reflect.Value, path []string) []interface{} {
+	for value.IsValid() && (value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr) {
+		if value.IsNil() {
+			value = reflect.Value{}
+			break
+		}
+
+		value = value.Elem()
+	}
+
+	if !value.IsValid() {
+		if len(path) > 0 {
+			return nil
+		}
+
+		return []interface{}{nil}
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		var result []interface{}
+
+		for i := 0; i < value.Len(); i++ {
+			result = append(result, collectFilterValues(value.Index(i), path)...)
+		}
+
+		return result
+
+	case reflect.Map:
+		if len(path) == 0 || value.Type().Key().Kind() != reflect.String {
+			break
+		}
+
+		next := value.MapIndex(reflect.ValueOf(path[0]).Convert(value.Type().Key()))
+		if !next.IsValid() {
+			return nil
+		}
+
+		return collectFilterValues(next, path[1:])
+	}
+
+	if len(path) > 0 {
+		return nil
+	}
+
+	return []interface{}{value.Interface()}
+}
+
+// getFilterGroups returns the filter groups of a data source, if it supports them.
+func getFilterGroups(d *schema.ResourceData) []interface{} {
+	groups, _ := d.Get("filter_group").([]interface{})
+	return groups
+}
+
 func FilterTypeString(value string) (interface{}, error) {
 	return value, nil
 }
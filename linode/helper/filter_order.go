@@ -0,0 +1,118 @@
+package helper
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// OrderResults stably sorts the given results on the `order_by` attribute of the data source, if any.
+// Items without the attribute are always placed last.
+func (f FilterConfig) OrderResults(d *schema.ResourceData, items []map[string]interface{}) {
+	orderBy, _ := d.Get("order_by").(string)
+	if orderBy == "" {
+		return
+	}
+
+	order, _ := d.Get("order").(string)
+	desc := order == "desc"
+
+	keys := make([]interface{}, len(items))
+	for i, item := range items {
+		keys[i] = orderKey(item, orderBy)
+	}
+
+	sort.Stable(orderedResults{items: items, keys: keys, desc: desc})
+}
+
+// orderedResults sorts results alongside their precomputed order keys.
+type orderedResults struct {
+	items []map[string]interface{}
+	keys  []interface{}
+	desc  bool
+}
+
+func (o orderedResults) Len() int {
+	return len(o.items)
+}
+
+func (o orderedResults) Swap(i, j int) {
+	o.items[i], o.items[j] = o.items[j], o.items[i]
+	o.keys[i], o.keys[j] = o.keys[j], o.keys[i]
+}
+
+func (o orderedResults) Less(i, j int) bool {
+	a, b := o.keys[i], o.keys[j]
+
+	if a == nil || b == nil {
+		return a != nil && b == nil
+	}
+
+	if o.desc {
+		return compareOrderValues(b, a) < 0
+	}
+
+	return compareOrderValues(a, b) < 0
+}
+
+// orderKey returns the value of the given attribute of an item to order it by.
+// The first value is used for nested attributes with several values.
+func orderKey(item map[string]interface{}, name string) interface{} {
+	values, ok := lookupFilterPath(item, name)
+	if !ok || len(values) < 1 {
+		return nil
+	}
+
+	return values[0]
+}
+
+// compareOrderValues compares two attribute values, returning -1, 0 or 1.
+// Numbers, booleans and RFC3339 timestamps are compared by value, and anything else as strings.
+func compareOrderValues(a, b interface{}) int {
+	aValue, bValue := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	if aNum, ok := filterNumber(aValue); ok {
+		if bNum, ok := filterNumber(bValue); ok {
+			switch {
+			case aNum < bNum:
+				return -1
+			case aNum > bNum:
+				return 1
+			}
+
+			return 0
+		}
+	}
+
+	if aValue.Kind() == reflect.Bool && bValue.Kind() == reflect.Bool {
+		switch {
+		case !aValue.Bool() && bValue.Bool():
+			return -1
+		case aValue.Bool() && !bValue.Bool():
+			return 1
+		}
+
+		return 0
+	}
+
+	aString, bString := fmt.Sprint(a), fmt.Sprint(b)
+
+	if aTime, err := time.Parse(time.RFC3339, aString); err == nil {
+		if bTime, err := time.Parse(time.RFC3339, bString); err == nil {
+			switch {
+			case aTime.Before(bTime):
+				return -1
+			case aTime.After(bTime):
+				return 1
+			}
+
+			return 0
+		}
+	}
+
+	return strings.Compare(aString, bString)
+}
@@ -0,0 +1,239 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/linode/linodego"
+)
+
+// FilterPageConcurrency is the number of pages fetched at once for limited
+// data sources with filters that are handled on the client.
+var FilterPageConcurrency = 4
+
+// LimitSchema should be referenced in a schema configuration in order to
+// enable limiting the number of results
+func (f FilterConfig) LimitSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+		Description: "The maximum number of results to return. " +
+			"Pages are only fetched until this many results have been found.",
+	}
+}
+
+// PageSizeSchema should be referenced in a schema configuration in order to
+// enable control over the size of the pages requested from the API
+func (f FilterConfig) PageSizeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntBetween(25, 500),
+		Description:  "The number of results to request from the API per page.",
+	}
+}
+
+// ListFilteredItems lists, flattens, filters and orders the items matching the filters of a data source.
+// If the data source has a limit, pages are fetched one at a time until enough results have been
+// found, or several at a time if some filters must be handled on the client.
+func (f FilterConfig) ListFilteredItems(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client *linodego.Client,
+	filter string,
+	listFunc FilterListFunc,
+	flattenFunc FilterFlattenFunc,
+) ([]map[string]interface{}, error) {
+	limit, _ := d.Get("limit").(int)
+	orderBy, _ := d.Get("order_by").(string)
+	latest, _ := d.Get("latest").(bool)
+
+	var result []map[string]interface{}
+	var err error
+
+	// Every item must be listed to find the latest item or to order items on the client
+	if limit < 1 || latest || (orderBy != "" && !f.apiFilterable(orderBy)) {
+		result, err = f.listAllFilteredItems(ctx, d, client, filter, listFunc, flattenFunc)
+	} else {
+		result, err = f.listLimitedFilteredItems(ctx, d, client, filter, listFunc, flattenFunc, limit)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	f.OrderResults(d, result)
+
+	// The latest item is chosen from every result
+	if limit > 0 && !latest && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+func (f FilterConfig) listAllFilteredItems(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client *linodego.Client,
+	filter string,
+	listFunc FilterListFunc,
+	flattenFunc FilterFlattenFunc,
+) ([]map[string]interface{}, error) {
+	pageSize, _ := d.Get("page_size").(int)
+
+	items, err := listFlattenedItems(ctx, client, listFunc, flattenFunc, &linodego.ListOptions{
+		PageSize: pageSize,
+		Filter:   filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return f.filterItems(d, items)
+}
+
+// listLimitedFilteredItems lists pages until at least limit items matching the filters have been found.
+func (f FilterConfig) listLimitedFilteredItems(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client *linodego.Client,
+	filter string,
+	listFunc FilterListFunc,
+	flattenFunc FilterFlattenFunc,
+	limit int,
+) ([]map[string]interface{}, error) {
+	pageSize, _ := d.Get("page_size").(int)
+
+	concurrency := 1
+	if f.hasClientFilters(d) {
+		concurrency = FilterPageConcurrency
+	}
+
+	var result []map[string]interface{}
+
+	for page, pages := 1, 1; page <= pages && len(result) < limit; {
+		// The page count is unknown until the first page has been fetched
+		count := 1
+		if page > 1 {
+			count = concurrency
+			if remaining := pages - page + 1; remaining < count {
+				count = remaining
+			}
+		}
+
+		batch, pageCount, err := listFlattenedPages(ctx, client, listFunc, flattenFunc, filter, pageSize, page, count)
+		if err != nil {
+			return nil, err
+		}
+
+		// Filtering reads from the ResourceData, so it is done here rather than by each fetcher
+		for _, items := range batch {
+			filtered, err := f.filterItems(d, items)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, filtered...)
+		}
+
+		page += count
+		pages = pageCount
+	}
+
+	return result, nil
+}
+
+func (f FilterConfig) filterItems(d *schema.ResourceData, items []interface{}) ([]map[string]interface{}, error) {
+	result, err := f.FilterResults(d, items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter returned data: %s", err)
+	}
+
+	return result, nil
+}
+
+// hasClientFilters returns whether any of the filters of a data source are handled on the client.
+func (f FilterConfig) hasClientFilters(d *schema.ResourceData) bool {
+	filters := d.Get("filter").([]interface{})
+
+	for _, group := range getFilterGroups(d) {
+		filters = append(filters, group.(map[string]interface{})["filter"].([]interface{})...)
+	}
+
+	for _, filter := range filters {
+		if !f.isAPIFilter(filter.(map[string]interface{})) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// listFlattenedPages concurrently lists and flattens count pages starting at the given page,
+// returning the items of each page in order along with the total number of pages.
+func listFlattenedPages(
+	ctx context.Context,
+	client *linodego.Client,
+	listFunc FilterListFunc,
+	flattenFunc FilterFlattenFunc,
+	filter string,
+	pageSize, page, count int,
+) ([][]interface{}, int, error) {
+	result := make([][]interface{}, count)
+	pages := make([]int, count)
+	errs := make([]error, count)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			opts := &linodego.ListOptions{
+				PageOptions: &linodego.PageOptions{Page: page + i},
+				PageSize:    pageSize,
+				Filter:      filter,
+			}
+
+			result[i], errs[i] = listFlattenedItems(ctx, client, listFunc, flattenFunc, opts)
+			pages[i] = opts.Pages
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return result, pages[count-1], nil
+}
+
+func listFlattenedItems(
+	ctx context.Context,
+	client *linodego.Client,
+	listFunc FilterListFunc,
+	flattenFunc FilterFlattenFunc,
+	opts *linodego.ListOptions,
+) ([]interface{}, error) {
+	items, err := listFunc(ctx, client, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list linode items: %s", err)
+	}
+
+	result := make([]interface{}, len(items))
+	for i, item := range items {
+		result[i] = flattenFunc(item)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,138 @@
+package helper_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/linode/linodego"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+)
+
+// testPagedList returns a list function serving 10 pages of 25 items, where every
+// tenth item is tagged, along with the pages it has been asked for.
+func testPagedList() (helper.FilterListFunc, func() []int) {
+	var mu sync.Mutex
+	var requested []int
+
+	listFunc := func(
+		ctx context.Context, client *linodego.Client, options *linodego.ListOptions) ([]interface{}, error) {
+		page := 0
+		if options.PageOptions != nil {
+			page = options.Page
+		}
+
+		mu.Lock()
+		requested = append(requested, page)
+		mu.Unlock()
+
+		first, last := 1, 250
+		if page > 0 {
+			first, last = (page-1)*25+1, page*25
+			options.Pages = 10
+		}
+
+		var result []interface{}
+		for i := first; i <= last; i++ {
+			var tags []string
+			if i%10 == 0 {
+				tags = []string{"tenth"}
+			}
+
+			result = append(result, map[string]interface{}{
+				"label": fmt.Sprintf("item-%d", i), "memory": i, "vendor": "Debian", "tags": tags,
+			})
+		}
+
+		return result, nil
+	}
+
+	return listFunc, func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		sort.Ints(requested)
+		return requested
+	}
+}
+
+func testPagedFlatten(item interface{}) map[string]interface{} {
+	return item.(map[string]interface{})
+}
+
+func TestListFilteredItems(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		raw       map[string]interface{}
+		expected  []int
+		requested []int
+	}{
+		{
+			name:      "no limit",
+			raw:       map[string]interface{}{"filter": []interface{}{testFilter("tags", "exact", "tenth")}},
+			expected:  []int{10, 20, 30, 40, 50, 60, 70, 80, 90, 100, 110, 120, 130, 140, 150, 160, 170, 180, 190, 200, 210, 220, 230, 240, 250},
+			requested: []int{0},
+		},
+		{
+			name:      "limit with API filters",
+			raw:       map[string]interface{}{"limit": 30, "filter": []interface{}{testFilter("memory", "gt", "0")}},
+			expected:  testRange(1, 30),
+			requested: []int{1, 2},
+		},
+		{
+			name:      "limit with client filters",
+			raw:       map[string]interface{}{"limit": 6, "filter": []interface{}{testFilter("tags", "exact", "tenth")}},
+			expected:  []int{10, 20, 30, 40, 50, 60},
+			requested: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name:      "limit beyond the last page",
+			raw:       map[string]interface{}{"limit": 300},
+			expected:  testRange(1, 250),
+			requested: testRange(1, 10),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			listFunc, requested := testPagedList()
+
+			d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+				"filter":       testFilterConfig.FilterSchema(),
+				"filter_group": testFilterConfig.FilterGroupSchema(),
+				"limit":        testFilterConfig.LimitSchema(),
+				"page_size":    testFilterConfig.PageSizeSchema(),
+			}, tc.raw)
+
+			results, err := testFilterConfig.ListFilteredItems(
+				context.Background(), d, nil, "{}", listFunc, testPagedFlatten)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			memory := make([]int, len(results))
+			for i, result := range results {
+				memory[i] = result["memory"].(int)
+			}
+
+			if !reflect.DeepEqual(memory, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, memory)
+			}
+
+			if !reflect.DeepEqual(requested(), tc.requested) {
+				t.Errorf("expected pages %v to be requested, got %v", tc.requested, requested())
+			}
+		})
+	}
+}
+
+func testRange(first, last int) []int {
+	result := make([]int, 0, last-first+1)
+	for i := first; i <= last; i++ {
+		result = append(result, i)
+	}
+
+	return result
+}
@@ -0,0 +1,435 @@
+package helper_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+)
+
+var testFilterConfig = helper.FilterConfig{
+	"label":  {APIFilterable: true, TypeFunc: helper.FilterTypeString},
+	"memory": {APIFilterable: true, TypeFunc: helper.FilterTypeInt},
+	"vendor": {APIFilterable: true, TypeFunc: helper.FilterTypeString},
+	"tags":   {TypeFunc: helper.FilterTypeString},
+
+	"specs.vcpus":              {APIFilterable: true, TypeFunc: helper.FilterTypeInt},
+	"config.interface.purpose": {TypeFunc: helper.FilterTypeString},
+}
+
+var testFilterItems = []interface{}{
+	map[string]interface{}{
+		"label": "a", "memory": 4096, "vendor": "Debian", "tags": []string{"foo"},
+		"specs":  []map[string]int{{"vcpus": 1}},
+		"config": testFilterConfigs("public"),
+	},
+	map[string]interface{}{
+		"label": "b", "memory": 8192, "vendor": "Debian", "tags": []string{"bar"},
+		"specs":  []map[string]int{{"vcpus": 2}},
+		"config": testFilterConfigs("public", "vlan"),
+	},
+	map[string]interface{}{
+		"label": "c", "memory": 8192, "vendor": "Ubuntu", "tags": []string{"foo", "bar"},
+		"specs":  []map[string]int{{"vcpus": 4}},
+		"config": testFilterConfigs(),
+	},
+	map[string]interface{}{
+		"label": "d", "memory": 16384, "vendor": "Alpine", "tags": []string{},
+		"specs":  []map[string]int{{"vcpus": 8}},
+		"config": append(testFilterConfigs("vlan"), testFilterConfigs("public")...),
+	},
+}
+
+func testFilterConfigs(purposes ...string) []map[string]interface{} {
+	interfaces := make([]interface{}, len(purposes))
+	for i, purpose := range purposes {
+		interfaces[i] = map[string]interface{}{"purpose": purpose}
+	}
+
+	return []map[string]interface{}{{"interface": interfaces}}
+}
+
+func testFilterData(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+
+	return schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"filter":       testFilterConfig.FilterSchema(),
+		"filter_group": testFilterConfig.FilterGroupSchema(),
+		"order_by":     testFilterConfig.OrderBySchema(),
+		"order":        testFilterConfig.OrderSchema(),
+	}, raw)
+}
+
+func testFilter(name, matchBy string, values ...interface{}) map[string]interface{} {
+	return map[string]interface{}{"name": name, "match_by": matchBy, "values": values}
+}
+
+func TestFilterResults(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		raw      map[string]interface{}
+		expected []string
+	}{
+		{
+			name: "comparison and negation",
+			raw: map[string]interface{}{
+				"filter": []interface{}{
+					testFilter("memory", "gte", "8192"),
+					testFilter("vendor", "not", "Debian"),
+				},
+			},
+			expected: []string{"c", "d"},
+		},
+		{
+			name: "less than",
+			raw: map[string]interface{}{
+				"filter": []interface{}{testFilter("memory", "lt", "8192")},
+			},
+			expected: []string{"a"},
+		},
+		{
+			name: "not regex on a list",
+			raw: map[string]interface{}{
+				"filter": []interface{}{testFilter("tags", "not_regex", "^f")},
+			},
+			expected: []string{"b", "d"},
+		},
+		{
+			name: "any group",
+			raw: map[string]interface{}{
+				"filter_group": []interface{}{
+					map[string]interface{}{
+						"filter": []interface{}{
+							testFilter("vendor", "exact", "Alpine"),
+							testFilter("memory", "lte", "4096"),
+						},
+					},
+				},
+			},
+			expected: []string{"a", "d"},
+		},
+		{
+			name: "nested field",
+			raw: map[string]interface{}{
+				"filter": []interface{}{testFilter("specs.vcpus", "gt", "1")},
+			},
+			expected: []string{"b", "c", "d"},
+		},
+		{
+			name: "nested list of maps",
+			raw: map[string]interface{}{
+				"filter": []interface{}{testFilter("config.interface.purpose", "exact", "vlan")},
+			},
+			expected: []string{"b", "d"},
+		},
+		{
+			name: "negated nested list of maps",
+			raw: map[string]interface{}{
+				"filter": []interface{}{testFilter("config.interface.purpose", "not", "vlan")},
+			},
+			expected: []string{"a", "c"},
+		},
+		{
+			name: "all group with filters",
+			raw: map[string]interface{}{
+				"filter": []interface{}{testFilter("memory", "gt", "4096")},
+				"filter_group": []interface{}{
+					map[string]interface{}{
+						"match": "all",
+						"filter": []interface{}{
+							testFilter("tags", "exact", "bar"),
+							testFilter("vendor", "not", "Ubuntu"),
+						},
+					},
+				},
+			},
+			expected: []string{"b"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := testFilterData(t, tc.raw)
+
+			results, err := testFilterConfig.FilterResults(d, testFilterItems)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, len(results))
+			for i, result := range results {
+				labels[i] = result["label"].(string)
+			}
+
+			if !reflect.DeepEqual(labels, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, labels)
+			}
+		})
+	}
+}
+
+func TestPrefilterFunc(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		raw      map[string]interface{}
+		expected []string
+	}{
+		{
+			name: "filters on unknown attributes are skipped",
+			raw: map[string]interface{}{
+				"filter": []interface{}{
+					testFilter("tags", "exact", "foo"),
+					testFilter("config.interface.purpose", "exact", "vlan"),
+				},
+			},
+			expected: []string{"a", "c"},
+		},
+		{
+			name: "groups matching any filter on an unknown attribute are skipped",
+			raw: map[string]interface{}{
+				"filter_group": []interface{}{
+					map[string]interface{}{
+						"filter": []interface{}{
+							testFilter("vendor", "exact", "Alpine"),
+							testFilter("config.interface.purpose", "exact", "vlan"),
+						},
+					},
+				},
+			},
+			expected: []string{"a", "b", "c", "d"},
+		},
+		{
+			name: "groups matching all filters",
+			raw: map[string]interface{}{
+				"filter_group": []interface{}{
+					map[string]interface{}{
+						"match": "all",
+						"filter": []interface{}{
+							testFilter("vendor", "exact", "Debian"),
+							testFilter("config.interface.purpose", "exact", "vlan"),
+						},
+					},
+					map[string]interface{}{
+						"filter": []interface{}{
+							testFilter("memory", "gte", "8192"),
+							testFilter("label", "exact", "a"),
+						},
+					},
+				},
+			},
+			expected: []string{"a", "b"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			prefilter := testFilterConfig.PrefilterFunc(testFilterData(t, tc.raw), "config")
+
+			var labels []string
+			for _, item := range testFilterItems {
+				// The configs are not known yet
+				item := item.(map[string]interface{})
+				partial := make(map[string]interface{}, len(item))
+				for k, v := range item {
+					if k != "config" {
+						partial[k] = v
+					}
+				}
+
+				match, err := prefilter(partial)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if match {
+					labels = append(labels, partial["label"].(string))
+				}
+			}
+
+			if !reflect.DeepEqual(labels, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, labels)
+			}
+		})
+	}
+}
+
+func TestConstructFilterString(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		raw      map[string]interface{}
+		expected string
+	}{
+		{
+			name: "comparison and negation",
+			raw: map[string]interface{}{
+				"filter": []interface{}{
+					testFilter("memory", "gte", "8192"),
+					testFilter("vendor", "not", "Debian", "Ubuntu"),
+					testFilter("tags", "exact", "foo"),
+				},
+			},
+			expected: `{"+and":[{"+or":[{"memory":{"+gte":8192}}]},` +
+				`{"+and":[{"vendor":{"+neq":"Debian"}},{"vendor":{"+neq":"Ubuntu"}}]}]}`,
+		},
+		{
+			name: "any group",
+			raw: map[string]interface{}{
+				"filter_group": []interface{}{
+					map[string]interface{}{
+						"filter": []interface{}{
+							testFilter("vendor", "exact", "Alpine"),
+							testFilter("memory", "lte", "4096"),
+						},
+					},
+				},
+			},
+			expected: `{"+and":[{"+or":[{"+or":[{"vendor":"Alpine"}]},{"+or":[{"memory":{"+lte":4096}}]}]}]}`,
+		},
+		{
+			name: "nested field",
+			raw: map[string]interface{}{
+				"filter": []interface{}{
+					testFilter("label", "exact", "a"),
+					testFilter("specs.vcpus", "exact", "1"),
+				},
+			},
+			expected: `{"+and":[{"+or":[{"label":"a"}]}]}`,
+		},
+		{
+			name:     "order without filters",
+			raw:      map[string]interface{}{"order_by": "memory", "order": "desc"},
+			expected: `{"+order_by":"memory","+order":"desc"}`,
+		},
+		{
+			name: "client-side order",
+			raw: map[string]interface{}{
+				"order_by": "tags",
+				"filter":   []interface{}{testFilter("label", "exact", "a")},
+			},
+			expected: `{"+and":[{"+or":[{"label":"a"}]}]}`,
+		},
+		{
+			name: "any group with a client-side filter",
+			raw: map[string]interface{}{
+				"filter_group": []interface{}{
+					map[string]interface{}{
+						"filter": []interface{}{
+							testFilter("vendor", "exact", "Alpine"),
+							testFilter("tags", "exact", "foo"),
+						},
+					},
+				},
+			},
+			expected: `{}`,
+		},
+		{
+			name: "all group with a client-side filter",
+			raw: map[string]interface{}{
+				"filter_group": []interface{}{
+					map[string]interface{}{
+						"match": "all",
+						"filter": []interface{}{
+							testFilter("vendor", "exact", "Alpine"),
+							testFilter("label", "regex", "^a"),
+						},
+					},
+				},
+			},
+			expected: `{"+and":[{"+and":[{"+or":[{"vendor":"Alpine"}]}]}]}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := testFilterData(t, tc.raw)
+
+			filter, err := testFilterConfig.ConstructFilterString(d)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var expected, actual interface{}
+			if err := json.Unmarshal([]byte(tc.expected), &expected); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(filter), &actual); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(expected, actual) {
+				t.Fatalf("expected %s, got %s", tc.expected, filter)
+			}
+		})
+	}
+}
+
+func TestFilterConfigValidateDiagFunc(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		apiOnly bool
+		valid   bool
+	}{
+		{name: "memory", valid: true},
+		{name: "specs.vcpus", valid: true},
+		{name: "specs.vcpus", apiOnly: true, valid: false},
+		{name: "specs.memory", valid: false},
+		{name: "tags", apiOnly: true, valid: false},
+	} {
+		diags := testFilterConfig.ValidateDiagFunc(tc.apiOnly)(tc.name, nil)
+		if diags.HasError() == tc.valid {
+			t.Errorf("expected %q (api only: %v) to be valid: %v, got %v", tc.name, tc.apiOnly, tc.valid, diags)
+		}
+	}
+}
+
+func TestOrderResults(t *testing.T) {
+	items := []map[string]interface{}{
+		{"label": "a", "count": 10, "enabled": true, "created": "2021-01-02T00:00:00Z"},
+		{"label": "b", "count": 9, "enabled": false, "created": "2021-01-01T12:00:00+01:00"},
+		{"label": "c", "count": 10, "enabled": false, "created": "2021-01-03T00:00:00Z"},
+		{"label": "d", "specs": []map[string]int{{"vcpus": 1}}},
+	}
+
+	for _, tc := range []struct {
+		orderBy  string
+		order    string
+		expected []string
+	}{
+		{orderBy: "count", order: "asc", expected: []string{"b", "a", "c", "d"}},
+		{orderBy: "count", order: "desc", expected: []string{"a", "c", "b", "d"}},
+		{orderBy: "enabled", order: "asc", expected: []string{"b", "c", "a", "d"}},
+		{orderBy: "created", order: "asc", expected: []string{"b", "a", "c", "d"}},
+		{orderBy: "label", order: "desc", expected: []string{"d", "c", "b", "a"}},
+		{orderBy: "specs.vcpus", order: "asc", expected: []string{"d", "a", "b", "c"}},
+	} {
+		t.Run(tc.orderBy+" "+tc.order, func(t *testing.T) {
+			d := testFilterData(t, map[string]interface{}{"order_by": tc.orderBy, "order": tc.order})
+
+			results := append([]map[string]interface{}{}, items...)
+			testFilterConfig.OrderResults(d, results)
+
+			labels := make([]string, len(results))
+			for i, result := range results {
+				labels[i] = result["label"].(string)
+			}
+
+			if !reflect.DeepEqual(labels, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, labels)
+			}
+		})
+	}
+}
+
+func TestFilterLatest(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"latest": {Type: schema.TypeBool, Optional: true},
+	}, map[string]interface{}{"latest": true})
+
+	items := []map[string]interface{}{
+		{"label": "a", "created": "2021-01-01T00:00:00Z"},
+		{"label": "b", "created": "invalid"},
+		{"label": "c", "created": "2021-01-02T00:00:00Z"},
+		{"label": "d", "created": "2021-01-02T00:00:00Z"},
+	}
+
+	results := testFilterConfig.FilterLatest(d, items)
+	if len(results) != 1 || results[0]["label"] != "c" {
+		t.Fatalf("expected only c to be returned, got %v", results)
+	}
+}
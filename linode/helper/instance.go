@@ -31,7 +31,7 @@ func RebootInstance(ctx context.Context, d *schema.ResourceData, entityID int,
 	if err != nil {
 		return diag.Errorf("Error rebooting Instance [%d]: %s", instance.ID, err)
 	}
-	_, err = client.WaitForEventFinished(ctx, entityID, linodego.EntityLinode,
+	_, err = meta.(*ProviderMeta).Events.WaitForEventFinished(ctx, entityID, linodego.EntityLinode,
 		linodego.ActionLinodeReboot, *instance.Created, getDeadlineSeconds(ctx, d))
 	if err != nil {
 		return diag.Errorf("Error waiting for Instance [%d] to finish rebooting: %s", instance.ID, err)
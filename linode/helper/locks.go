@@ -0,0 +1,46 @@
+package helper
+
+import (
+	"fmt"
+	"sync"
+)
+
+// entityLock is a mutex for a single entity, shared by every resource that holds or waits for it.
+type entityLock struct {
+	sync.Mutex
+	refs int
+}
+
+var (
+	entityLocksMu sync.Mutex
+	entityLocks   = make(map[string]*entityLock)
+)
+
+// LockEntity serializes writes to an entity such as a Linode that several resources modify,
+// blocking until no other resource holds the lock and returning a function that releases it.
+func LockEntity(entityType string, id interface{}) func() {
+	key := fmt.Sprintf("%s/%v", entityType, id)
+
+	entityLocksMu.Lock()
+	lock, ok := entityLocks[key]
+	if !ok {
+		lock = &entityLock{}
+		entityLocks[key] = lock
+	}
+	lock.refs++
+	entityLocksMu.Unlock()
+
+	lock.Lock()
+
+	return func() {
+		lock.Unlock()
+
+		entityLocksMu.Lock()
+		defer entityLocksMu.Unlock()
+
+		lock.refs--
+		if lock.refs == 0 {
+			delete(entityLocks, key)
+		}
+	}
+}
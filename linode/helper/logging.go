@@ -0,0 +1,295 @@
+package helper
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// redactedValue replaces sensitive values in logs.
+const redactedValue = "[REDACTED]"
+
+// sensitiveLogFields are the JSON fields whose values are redacted from logged request and response bodies.
+// The fields marked Sensitive in the provider's schemas are added by RegisterSensitiveLogFields.
+var (
+	sensitiveLogFields = map[string]bool{
+		"root_pass":        true,
+		"password":         true,
+		"secret_key":       true,
+		"ssl_key":          true,
+		"token":            true,
+		"kubeconfig":       true,
+		"private_key":      true,
+		"stackscript_data": true,
+		"authorized_keys":  true,
+		"authorized_users": true,
+	}
+	sensitiveLogFieldsMu sync.RWMutex
+)
+
+// sensitiveLogHeaders are the headers whose values are redacted from logs.
+var sensitiveLogHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+type resourceTypeKey struct{}
+
+// WithResourceType returns a context that tags the API requests made with it with the given resource type.
+func WithResourceType(ctx context.Context, resourceType string) context.Context {
+	return context.WithValue(ctx, resourceTypeKey{}, resourceType)
+}
+
+// ResourceTypeFromContext returns the resource type the context was tagged with, if any.
+func ResourceTypeFromContext(ctx context.Context) string {
+	resourceType, _ := ctx.Value(resourceTypeKey{}).(string)
+	return resourceType
+}
+
+// TagResourceContexts wraps the functions of the given resources or data sources
+// so the API requests they make are logged with their type.
+func TagResourceContexts(resources map[string]*schema.Resource) {
+	for name, r := range resources {
+		name := name
+
+		tag := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+		) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+			if f == nil {
+				return nil
+			}
+
+			return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				return f(WithResourceType(ctx, name), d, meta)
+			}
+		}
+
+		r.CreateContext = tag(r.CreateContext)
+		r.ReadContext = tag(r.ReadContext)
+		r.UpdateContext = tag(r.UpdateContext)
+		r.DeleteContext = tag(r.DeleteContext)
+
+		if r.Importer != nil && r.Importer.StateContext != nil {
+			stateContext := r.Importer.StateContext
+			r.Importer.StateContext = func(
+				ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				return stateContext(WithResourceType(ctx, name), d, meta)
+			}
+		}
+	}
+}
+
+// RegisterSensitiveLogFields redacts the fields marked Sensitive in the schemas of the given resources
+// or data sources from logged bodies, as the API names its fields the same way.
+func RegisterSensitiveLogFields(resources map[string]*schema.Resource) {
+	sensitiveLogFieldsMu.Lock()
+	defer sensitiveLogFieldsMu.Unlock()
+
+	for _, r := range resources {
+		registerSensitiveSchemaFields(r.Schema)
+	}
+}
+
+func registerSensitiveSchemaFields(fields map[string]*schema.Schema) {
+	for name, s := range fields {
+		if s.Sensitive {
+			sensitiveLogFields[name] = true
+		}
+
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			registerSensitiveSchemaFields(elem.Schema)
+		}
+	}
+}
+
+// LoggingTransport is an http.RoundTripper that logs API requests and responses at the DEBUG level.
+// Sensitive headers and JSON fields are redacted, and each request is logged with a correlation ID,
+// the type of the resource that made it and the request ID returned by the API.
+type LoggingTransport struct {
+	name      string
+	transport http.RoundTripper
+}
+
+// NewLoggingTransport returns a LoggingTransport wrapping the given transport.
+func NewLoggingTransport(name string, transport http.RoundTripper) *LoggingTransport {
+	return &LoggingTransport{name: name, transport: transport}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !logging.IsDebugOrHigher() {
+		return t.transport.RoundTrip(req)
+	}
+
+	fields := map[string]interface{}{
+		"correlation_id": newCorrelationID(),
+		"method":         req.Method,
+		"url":            req.URL.String(),
+	}
+	if resourceType := ResourceTypeFromContext(req.Context()); resourceType != "" {
+		fields["resource_type"] = resourceType
+	}
+
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] %s API Request: %s\n%s%s",
+		t.name, formatLogFields(fields), formatLogHeaders(req.Header), RedactLogBody(body))
+
+	start := time.Now()
+
+	resp, err := t.transport.RoundTrip(req)
+
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		log.Printf("[DEBUG] %s API Response: %s", t.name, formatLogFields(fields))
+
+		return resp, err
+	}
+
+	fields["status"] = resp.StatusCode
+	if requestID := resp.Header.Get("X-Request-Id"); requestID != "" {
+		fields["request_id"] = requestID
+	}
+
+	body, err = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	log.Printf("[DEBUG] %s API Response: %s\n%s%s",
+		t.name, formatLogFields(fields), formatLogHeaders(resp.Header), RedactLogBody(body))
+
+	return resp, nil
+}
+
+// RedactLogBody returns a body to be logged with the values of sensitive JSON fields redacted.
+// Bodies that are not JSON are replaced by their length, as they can not be redacted.
+func RedactLogBody(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Sprintf("[%d bytes]", len(body))
+	}
+
+	sensitiveLogFieldsMu.RLock()
+	value = redactLogValue(value)
+	sensitiveLogFieldsMu.RUnlock()
+
+	result, err := json.MarshalIndent(value, "", " ")
+	if err != nil {
+		return fmt.Sprintf("[%d bytes]", len(body))
+	}
+
+	return string(result)
+}
+
+func redactLogValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			if sensitiveLogFields[k] && v != nil {
+				value[k] = redactedValue
+				continue
+			}
+
+			value[k] = redactLogValue(v)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = redactLogValue(v)
+		}
+	}
+
+	return value
+}
+
+// readRequestBody returns the body of a request, restoring it so that it can still be sent.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+
+		return ioutil.ReadAll(body)
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return body, nil
+}
+
+// formatLogFields formats the fields of a log entry as sorted key=value pairs.
+func formatLogFields(fields map[string]interface{}) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = fmt.Sprintf("%s=%q", k, fmt.Sprint(fields[k]))
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+// formatLogHeaders formats headers to be logged with the values of sensitive headers redacted.
+func formatLogHeaders(header http.Header) string {
+	header = header.Clone()
+	for _, name := range sensitiveLogHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, redactedValue)
+		}
+	}
+
+	var buf bytes.Buffer
+	header.Write(&buf) // nolint:errcheck
+
+	return buf.String()
+}
+
+func newCorrelationID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+
+	return hex.EncodeToString(b)
+}
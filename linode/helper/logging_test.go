@@ -0,0 +1,175 @@
+package helper_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+)
+
+func TestRedactLogBody(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		body     string
+		expected []string
+		redacted []string
+	}{
+		{
+			name:     "nested fields",
+			body:     `{"label":"test","root_pass":"hunter2","configs":[{"kubeconfig":"a3ViZQ=="}],"ssl_key":null}`,
+			expected: []string{`"label": "test"`, `"root_pass": "[REDACTED]"`, `"kubeconfig": "[REDACTED]"`, `"ssl_key": null`},
+			redacted: []string{"hunter2", "a3ViZQ=="},
+		},
+		{
+			name:     "list",
+			body:     `{"data":[{"access_key":"ACCESS","secret_key":"SECRET"}]}`,
+			expected: []string{`"access_key": "ACCESS"`, `"secret_key": "[REDACTED]"`},
+			redacted: []string{"SECRET"},
+		},
+		{
+			name: "deployment",
+			body: `{"stackscript_data":{"db_pass":"hunter2"},"authorized_keys":["ssh-rsa KEY"],` +
+				`"authorized_users":["admin"]}`,
+			expected: []string{`"stackscript_data": "[REDACTED]"`, `"authorized_keys": "[REDACTED]"`},
+			redacted: []string{"hunter2", "KEY", "admin"},
+		},
+		{
+			name:     "not JSON",
+			body:     "token=secret",
+			expected: []string{"[12 bytes]"},
+			redacted: []string{"secret"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result := helper.RedactLogBody([]byte(tc.body))
+
+			for _, s := range tc.expected {
+				if !strings.Contains(result, s) {
+					t.Errorf("expected %q in %s", s, result)
+				}
+			}
+
+			for _, s := range tc.redacted {
+				if strings.Contains(result, s) {
+					t.Errorf("expected %q to be redacted from %s", s, result)
+				}
+			}
+		})
+	}
+}
+
+func TestLoggingTransport(t *testing.T) {
+	t.Setenv("TF_LOG", "DEBUG")
+
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The request body must still be sent after it is logged
+		if body, _ := ioutil.ReadAll(r.Body); string(body) != `{"root_pass":"hunter2"}` {
+			t.Errorf("unexpected body %q", body)
+		}
+
+		w.Header().Set("X-Request-Id", "abc123")
+		w.Write([]byte(`{"id":1,"secret_key":"SECRET"}`)) // nolint:errcheck
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: helper.NewLoggingTransport("Linode", http.DefaultTransport)}
+
+	ctx := helper.WithResourceType(context.Background(), "linode_instance")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, strings.NewReader(`{"root_pass":"hunter2"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer TOKEN")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	// The response body must still be readable after it is logged
+	if body, _ := ioutil.ReadAll(resp.Body); string(body) != `{"id":1,"secret_key":"SECRET"}` {
+		t.Errorf("unexpected response body %q", body)
+	}
+
+	logs := output.String()
+
+	for _, s := range []string{`resource_type="linode_instance"`, `request_id="abc123"`, `status="200"`, "correlation_id="} {
+		if !strings.Contains(logs, s) {
+			t.Errorf("expected %q in the logs:\n%s", s, logs)
+		}
+	}
+
+	for _, s := range []string{"hunter2", "SECRET", "TOKEN"} {
+		if strings.Contains(logs, s) {
+			t.Errorf("expected %q to be redacted from the logs:\n%s", s, logs)
+		}
+	}
+}
+
+func TestTagResourceContexts(t *testing.T) {
+	var resourceType string
+
+	resources := map[string]*schema.Resource{
+		"linode_instance": {
+			ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				resourceType = helper.ResourceTypeFromContext(ctx)
+				return nil
+			},
+		},
+	}
+
+	helper.TagResourceContexts(resources)
+
+	r := resources["linode_instance"]
+	if r.CreateContext != nil {
+		t.Error("expected unset functions to stay unset")
+	}
+
+	r.ReadContext(context.Background(), nil, nil)
+
+	if resourceType != "linode_instance" {
+		t.Errorf("expected the context to be tagged with linode_instance, got %q", resourceType)
+	}
+}
+
+func TestRegisterSensitiveLogFields(t *testing.T) {
+	helper.RegisterSensitiveLogFields(map[string]*schema.Resource{
+		"linode_test": {
+			Schema: map[string]*schema.Schema{
+				"label": {Type: schema.TypeString},
+				"credentials": {
+					Type: schema.TypeList,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"client_secret": {Type: schema.TypeString, Sensitive: true},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	result := helper.RedactLogBody([]byte(`{"label":"test","credentials":[{"client_secret":"SECRET"}]}`))
+
+	if !strings.Contains(result, `"label": "test"`) {
+		t.Errorf("expected the label not to be redacted from %s", result)
+	}
+
+	if strings.Contains(result, "SECRET") {
+		t.Errorf("expected the nested sensitive field to be redacted from %s", result)
+	}
+}
@@ -1,33 +1,271 @@
 package helper
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
 	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/linode/linodego"
 )
 
 const (
-	LinodeObjectsEndpoint = "https://%s.linodeobjects.com"
+	// DefaultObjEndpoint is the default Object Storage endpoint template.
+	DefaultObjEndpoint = "https://{cluster}.linodeobjects.com"
+
+	// objEndpointClusterVar is replaced by the cluster in Object Storage endpoint templates.
+	objEndpointClusterVar = "{cluster}"
+
+	// tempObjectStorageKeyPrefix is the label prefix of the temporary keys created for obj_use_temp_keys.
+	tempObjectStorageKeyPrefix = "tf-temp-"
+
+	// tempKeyPropagationTimeout is how long requests rejected because a new temporary key is not yet known
+	// to the cluster are retried.
+	tempKeyPropagationTimeout = 30 * time.Second
+
+	// staleTempKeyAge is the age after which a temporary key is assumed to have been left behind
+	// by a provider that did not get to revoke it, rather than being used by another run.
+	staleTempKeyAge = time.Hour
 )
 
-// S3ConnFromResourceData builds an S3 client from the linode_object_storage_object
-// resource's access_key, secret_key, and cluster fields.
-func S3ConnFromResourceData(d *schema.ResourceData) *s3.S3 {
+// objectStorageClientKey identifies the S3 clients of a cluster and access key.
+type objectStorageClientKey struct {
+	cluster   string
+	accessKey string
+	secretKey string
+}
+
+// ObjectStorageClients builds the S3 clients of the Object Storage resources from a single session
+// configured by the provider, caching a client per cluster and access key.
+type ObjectStorageClients struct {
+	session  *session.Session
+	endpoint string
+
+	mu      sync.Mutex
+	clients map[objectStorageClientKey]*s3.S3
+}
+
+// NewObjectStorageClients returns the S3 client factory for the Object Storage options of the config.
+func NewObjectStorageClients(config *Config) (*ObjectStorageClients, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: config.ObjInsecureSkipVerify, // nolint:gosec
+	}
+
+	opts := session.Options{
+		Config: aws.Config{
+			// This region is hardcoded strictly for preflight validation purposes.
+			Region:           aws.String("us-east-1"),
+			S3ForcePathStyle: aws.Bool(config.ObjUsePathStyle),
+			HTTPClient:       &http.Client{Transport: transport},
+		},
+	}
+
+	// The bundle takes precedence over the AWS_CA_BUNDLE environment variable
+	if config.ObjCABundle != "" {
+		caBundle, err := ioutil.ReadFile(config.ObjCABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Object Storage CA bundle: %s", err)
+		}
+
+		opts.CustomCABundle = bytes.NewReader(caBundle)
+	}
+
+	sess, err := session.NewSessionWithOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Object Storage session: %s", err)
+	}
+
+	endpoint := config.ObjEndpoint
+	if endpoint == "" {
+		endpoint = DefaultObjEndpoint
+	}
+
+	return &ObjectStorageClients{
+		session:  sess,
+		endpoint: endpoint,
+		clients:  make(map[objectStorageClientKey]*s3.S3),
+	}, nil
+}
+
+// Endpoint returns the Object Storage endpoint of the given cluster.
+func (c *ObjectStorageClients) Endpoint(cluster string) string {
+	return ObjEndpoint(c.endpoint, cluster)
+}
+
+// ObjEndpoint returns the Object Storage endpoint of a cluster from an endpoint template.
+func ObjEndpoint(template, cluster string) string {
+	return strings.ReplaceAll(template, objEndpointClusterVar, cluster)
+}
+
+// Client returns the cached S3 client of a cluster using the given access key.
+func (c *ObjectStorageClients) Client(cluster, accessKey, secretKey string) *s3.S3 {
+	key := objectStorageClientKey{cluster: cluster, accessKey: accessKey, secretKey: secretKey}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.clients[key]; ok {
+		return client
+	}
+
+	client := s3.New(c.session, &aws.Config{
+		Credentials: credentials.NewStaticCredentials(accessKey, secretKey, ""),
+		Endpoint:    aws.String(c.Endpoint(cluster)),
+	})
+	c.clients[key] = client
+
+	return client
+}
+
+// TempKeyClient returns a new S3 client of a cluster using a temporary key, retrying the requests rejected
+// while the key propagates to the cluster. Clients of temporary keys are not cached, as the keys are revoked
+// once the operation using them is done.
+func (c *ObjectStorageClients) TempKeyClient(cluster string, key *linodego.ObjectStorageKey) *s3.S3 {
+	config := request.WithRetryer(&aws.Config{
+		Credentials:             credentials.NewStaticCredentials(key.AccessKey, key.SecretKey, ""),
+		Endpoint:                aws.String(c.Endpoint(cluster)),
+		EnforceShouldRetryCheck: aws.Bool(true),
+	}, tempKeyRetryer{
+		DefaultRetryer: client.DefaultRetryer{
+			NumMaxRetries: 10,
+			MinRetryDelay: 250 * time.Millisecond,
+			MaxRetryDelay: 5 * time.Second,
+		},
+		created: time.Now(),
+	})
+
+	return s3.New(c.session, config)
+}
+
+// tempKeyRetryer retries the requests rejected while a new temporary key propagates to the cluster.
+type tempKeyRetryer struct {
+	client.DefaultRetryer
+	created time.Time
+}
+
+func (r tempKeyRetryer) ShouldRetry(req *request.Request) bool {
+	if time.Since(r.created) < tempKeyPropagationTimeout && isUnknownAccessKeyError(req) {
+		return true
+	}
+
+	return r.DefaultRetryer.ShouldRetry(req)
+}
+
+// isUnknownAccessKeyError returns whether a request was rejected because its access key is unknown.
+// HEAD responses have no body to tell why they were forbidden.
+func isUnknownAccessKeyError(req *request.Request) bool {
+	if aerr, ok := req.Error.(awserr.Error); ok && aerr.Code() == "InvalidAccessKeyId" {
+		return true
+	}
+
+	return req.HTTPRequest.Method == http.MethodHead &&
+		req.HTTPResponse != nil && req.HTTPResponse.StatusCode == http.StatusForbidden
+}
+
+// S3ConnFromResourceData returns an S3 client for a bucket of the cluster of an Object Storage resource.
+// The access_key and secret_key of the resource are used if set, then the obj_access_key and obj_secret_key
+// of the provider. Otherwise, a temporary key limited to the bucket is created if obj_use_temp_keys is set.
+// The returned function must be called once the client is no longer used, revoking any temporary key.
+func S3ConnFromResourceData(
+	ctx context.Context, d *schema.ResourceData, meta interface{}, bucket string) (*s3.S3, func(), error) {
+	providerMeta := meta.(*ProviderMeta)
+	config := providerMeta.Config
+	cluster := d.Get("cluster").(string)
+
 	accessKey := d.Get("access_key").(string)
 	secretKey := d.Get("secret_key").(string)
-	cluster := d.Get("cluster").(string)
 
-	sess := session.New(&aws.Config{
-		// This region is hardcoded strictly for preflight validation purposes.
-		Region:      aws.String("us-east-1"),
-		Credentials: credentials.NewStaticCredentials(accessKey, secretKey, ""),
-		Endpoint:    aws.String(fmt.Sprintf(LinodeObjectsEndpoint, cluster)),
+	if accessKey == "" || secretKey == "" {
+		accessKey, secretKey = config.ObjAccessKey, config.ObjSecretKey
+	}
+
+	if accessKey != "" && secretKey != "" {
+		return providerMeta.ObjectStorage.Client(cluster, accessKey, secretKey), func() {}, nil
+	}
+
+	if !config.ObjUseTempKeys {
+		return nil, nil, fmt.Errorf("access_key and secret_key, the provider's obj_access_key and obj_secret_key, " +
+			"or the provider's obj_use_temp_keys are required")
+	}
+
+	key, err := createTempObjectStorageKey(ctx, &providerMeta.Client, cluster, bucket)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	release := func() {
+		// The key is revoked even if the operation that used it was canceled
+		revokeTempObjectStorageKey(context.Background(), &providerMeta.Client, key.ID)
+	}
+
+	return providerMeta.ObjectStorage.TempKeyClient(cluster, key), release, nil
+}
+
+// createTempObjectStorageKey creates an Object Storage key limited to reading and writing a single bucket.
+func createTempObjectStorageKey(
+	ctx context.Context, client *linodego.Client, cluster, bucket string) (*linodego.ObjectStorageKey, error) {
+	key, err := client.CreateObjectStorageKey(ctx, linodego.ObjectStorageKeyCreateOptions{
+		Label: fmt.Sprintf("%s%d", tempObjectStorageKeyPrefix, time.Now().UnixNano()),
+		BucketAccess: &[]linodego.ObjectStorageKeyBucketAccess{
+			{Cluster: cluster, BucketName: bucket, Permissions: "read_write"},
+		},
 	})
-	return s3.New(sess)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary Object Storage Key for Bucket (%s): %s", bucket, err)
+	}
+
+	log.Printf("[DEBUG] created temporary Object Storage Key %d for Bucket (%s)", key.ID, bucket)
+
+	return key, nil
+}
+
+func revokeTempObjectStorageKey(ctx context.Context, client *linodego.Client, id int) {
+	if err := client.DeleteObjectStorageKey(ctx, id); err != nil {
+		log.Printf("[WARN] failed to revoke temporary Object Storage Key %d: %s", id, err)
+		return
+	}
+
+	log.Printf("[DEBUG] revoked temporary Object Storage Key %d", id)
+}
+
+// RevokeStaleTempObjectStorageKeys revokes the temporary keys left behind by providers that were stopped
+// before they could revoke them. Keys created within staleTempKeyAge are kept, as another run may be using them.
+func RevokeStaleTempObjectStorageKeys(ctx context.Context, client *linodego.Client) error {
+	keys, err := client.ListObjectStorageKeys(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list Object Storage Keys: %s", err)
+	}
+
+	for _, key := range keys {
+		if !strings.HasPrefix(key.Label, tempObjectStorageKeyPrefix) {
+			continue
+		}
+
+		created, err := strconv.ParseInt(strings.TrimPrefix(key.Label, tempObjectStorageKeyPrefix), 10, 64)
+		if err != nil || time.Since(time.Unix(0, created)) < staleTempKeyAge {
+			continue
+		}
+
+		revokeTempObjectStorageKey(ctx, client, key.ID)
+	}
+
+	return nil
 }
 
 func BuildObjectStorageObjectID(d *schema.ResourceData) string {
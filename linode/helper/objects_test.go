@@ -0,0 +1,303 @@
+package helper_test
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/linode/linodego"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+)
+
+// testObjectStorageServer returns a TLS server recording the paths of the requests sent to it,
+// and the path of a CA bundle trusting it.
+func testObjectStorageServer(t *testing.T, paths *[]string) (*httptest.Server, string) {
+	t.Helper()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+
+	caBundle := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caBundle, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return server, caBundle
+}
+
+func TestObjectStorageClients(t *testing.T) {
+	var paths []string
+	server, caBundle := testObjectStorageServer(t, &paths)
+
+	clients, err := helper.NewObjectStorageClients(&helper.Config{
+		ObjEndpoint:     server.URL + "/{cluster}",
+		ObjUsePathStyle: true,
+		ObjCABundle:     caBundle,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if endpoint := clients.Endpoint("us-east-1"); endpoint != server.URL+"/us-east-1" {
+		t.Errorf("expected the cluster to be templated into the endpoint, got %s", endpoint)
+	}
+
+	client := clients.Client("us-east-1", "access", "secret")
+	if clients.Client("us-east-1", "access", "secret") != client {
+		t.Error("expected the client to be cached")
+	}
+	if clients.Client("us-east-1", "other", "secret") == client {
+		t.Error("expected a client per access key")
+	}
+
+	if _, err := client.HeadBucket(&s3.HeadBucketInput{Bucket: aws.String("test")}); err != nil {
+		t.Fatalf("expected the CA bundle to be trusted: %s", err)
+	}
+
+	if len(paths) != 1 || paths[0] != "/us-east-1/test" {
+		t.Errorf("expected a path-style request to the cluster endpoint, got %v", paths)
+	}
+}
+
+func TestObjectStorageClientsTLS(t *testing.T) {
+	var paths []string
+	server, _ := testObjectStorageServer(t, &paths)
+
+	for _, tc := range []struct {
+		name    string
+		config  helper.Config
+		trusted bool
+	}{
+		{name: "untrusted", config: helper.Config{}},
+		{name: "insecure skip verify", config: helper.Config{ObjInsecureSkipVerify: true}, trusted: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config.ObjEndpoint = server.URL
+			tc.config.ObjUsePathStyle = true
+
+			clients, err := helper.NewObjectStorageClients(&tc.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = clients.Client("us-east-1", "access", "secret").HeadBucket(
+				&s3.HeadBucketInput{Bucket: aws.String("test")})
+			if trusted := err == nil; trusted != tc.trusted {
+				t.Errorf("expected trusted to be %t, got error %v", tc.trusted, err)
+			}
+		})
+	}
+
+	_, err := helper.NewObjectStorageClients(&helper.Config{ObjCABundle: filepath.Join(t.TempDir(), "missing.pem")})
+	if err == nil || !strings.Contains(err.Error(), "CA bundle") {
+		t.Errorf("expected an error reading the CA bundle, got %v", err)
+	}
+}
+
+// testTempKeyAPI returns a Linode API client creating, listing and revoking temporary keys,
+// recording the buckets of the created keys and the paths of the revoked keys.
+func testTempKeyAPI(t *testing.T, keys []linodego.ObjectStorageKey, created, revoked *[]string) *linodego.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v4/object-storage/keys":
+			var opts linodego.ObjectStorageKeyCreateOptions
+			if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+				t.Error(err)
+			}
+			*created = append(*created, (*opts.BucketAccess)[0].BucketName)
+
+			json.NewEncoder(w).Encode(linodego.ObjectStorageKey{ // nolint:errcheck
+				ID: 123, Label: opts.Label, AccessKey: "TEMPACCESS", SecretKey: "TEMPSECRET", Limited: true,
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/object-storage/keys":
+			json.NewEncoder(w).Encode(map[string]interface{}{ // nolint:errcheck
+				"data": keys, "page": 1, "pages": 1, "results": len(keys),
+			})
+		case r.Method == http.MethodDelete:
+			*revoked = append(*revoked, r.URL.Path)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := linodego.NewClient(http.DefaultClient)
+	client.SetBaseURL(server.URL)
+
+	return &client
+}
+
+func TestS3ConnFromResourceData(t *testing.T) {
+	var created, revoked []string
+	client := testTempKeyAPI(t, nil, &created, &revoked)
+
+	resourceSchema := map[string]*schema.Schema{
+		"cluster":    {Type: schema.TypeString, Optional: true},
+		"access_key": {Type: schema.TypeString, Optional: true},
+		"secret_key": {Type: schema.TypeString, Optional: true},
+	}
+
+	for _, tc := range []struct {
+		name      string
+		accessKey string
+		secretKey string
+		config    helper.Config
+		expected  string
+		temp      bool
+	}{
+		{
+			name:      "resource keys",
+			accessKey: "RESOURCE",
+			secretKey: "secret",
+			config:    helper.Config{ObjAccessKey: "PROVIDER", ObjSecretKey: "secret", ObjUseTempKeys: true},
+			expected:  "RESOURCE",
+		},
+		{
+			name:     "provider keys",
+			config:   helper.Config{ObjAccessKey: "PROVIDER", ObjSecretKey: "secret", ObjUseTempKeys: true},
+			expected: "PROVIDER",
+		},
+		{
+			name:     "temporary keys",
+			config:   helper.Config{ObjUseTempKeys: true},
+			expected: "TEMPACCESS",
+			temp:     true,
+		},
+		{
+			name: "no keys",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			created, revoked = nil, nil
+
+			d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+				"cluster":    "us-east-1",
+				"access_key": tc.accessKey,
+				"secret_key": tc.secretKey,
+			})
+
+			clients, err := helper.NewObjectStorageClients(&tc.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			meta := &helper.ProviderMeta{Client: *client, Config: &tc.config, ObjectStorage: clients}
+
+			conn, release, err := helper.S3ConnFromResourceData(context.Background(), d, meta, "test-bucket")
+			if tc.expected == "" {
+				if err == nil {
+					t.Fatal("expected an error without keys")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			creds, err := conn.Config.Credentials.Get()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if creds.AccessKeyID != tc.expected {
+				t.Errorf("expected access key %s, got %s", tc.expected, creds.AccessKeyID)
+			}
+
+			if tc.temp && (len(created) != 1 || created[0] != "test-bucket") {
+				t.Errorf("expected a temporary key limited to the bucket, got %v", created)
+			}
+
+			release()
+
+			if tc.temp {
+				if len(revoked) != 1 || revoked[0] != "/v4/object-storage/keys/123" {
+					t.Errorf("expected the temporary key to be revoked, got %v", revoked)
+				}
+			} else if len(created) != 0 || len(revoked) != 0 {
+				t.Errorf("expected no temporary keys, created %v and revoked %v", created, revoked)
+			}
+		})
+	}
+}
+
+func TestRevokeStaleTempObjectStorageKeys(t *testing.T) {
+	label := func(created time.Time) string {
+		return fmt.Sprintf("tf-temp-%d", created.UnixNano())
+	}
+
+	var created, revoked []string
+	client := testTempKeyAPI(t, []linodego.ObjectStorageKey{
+		{ID: 1, Label: label(time.Now().Add(-2 * time.Hour))},
+		{ID: 2, Label: label(time.Now().Add(-time.Minute))},
+		{ID: 3, Label: "backups"},
+		{ID: 4, Label: "tf-temp-backups"},
+	}, &created, &revoked)
+
+	if err := helper.RevokeStaleTempObjectStorageKeys(context.Background(), client); err != nil {
+		t.Fatal(err)
+	}
+
+	// Keys that may still be in use by another run are kept
+	if len(revoked) != 1 || revoked[0] != "/v4/object-storage/keys/1" {
+		t.Errorf("expected only the stale temporary key to be revoked, got %v", revoked)
+	}
+}
+
+func TestTempKeyClientPropagation(t *testing.T) {
+	// The cluster rejects the key until it has propagated
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		if len(requests) > 2 {
+			return
+		}
+
+		w.WriteHeader(http.StatusForbidden)
+		if r.Method != http.MethodHead {
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` + // nolint:errcheck
+				`<Error><Code>InvalidAccessKeyId</Code></Error>`))
+		}
+	}))
+	defer server.Close()
+
+	clients, err := helper.NewObjectStorageClients(&helper.Config{
+		ObjEndpoint:     server.URL + "/{cluster}",
+		ObjUsePathStyle: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn := clients.TempKeyClient("us-east-1",
+		&linodego.ObjectStorageKey{AccessKey: "TEMPACCESS", SecretKey: "TEMPSECRET"})
+
+	if _, err := conn.HeadBucket(&s3.HeadBucketInput{Bucket: aws.String("test-bucket")}); err != nil {
+		t.Fatalf("expected the forbidden HEAD request to be retried: %s", err)
+	}
+
+	if _, err := conn.GetBucketVersioning(
+		&s3.GetBucketVersioningInput{Bucket: aws.String("test-bucket")}); err != nil {
+		t.Fatalf("expected the InvalidAccessKeyId error to be retried: %s", err)
+	}
+
+	if len(requests) != 4 {
+		t.Errorf("expected 4 requests, got %v", requests)
+	}
+}
@@ -0,0 +1,266 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultMinRetryDelay is the default minimum delay before retrying a rejected request.
+	DefaultMinRetryDelay = time.Second
+
+	// DefaultMaxRetryDelay is the default maximum delay before retrying a rejected request.
+	DefaultMaxRetryDelay = 30 * time.Second
+
+	// DefaultMaxRetries is the default number of times a rejected request is retried.
+	DefaultMaxRetries = 5
+)
+
+// RateLimitTransport is an http.RoundTripper shared by every request made by a provider.
+// It limits the rate and concurrency of requests, pauses all requests when the API reports
+// that the rate limit has been reached, and retries requests rejected because an entity
+// was busy or the API failed.
+type RateLimitTransport struct {
+	Transport http.RoundTripper
+
+	MinRetryDelay time.Duration
+	MaxRetryDelay time.Duration
+	MaxRetries    int
+
+	limiter   *rate.Limiter
+	semaphore chan struct{}
+
+	mu           sync.Mutex
+	blockedUntil time.Time
+}
+
+// NewRateLimitTransport returns a RateLimitTransport wrapping the given transport.
+// A requestsPerSecond or maxConcurrent of 0 leaves the rate or concurrency of requests unlimited.
+// The burst defaults to the number of requests per second.
+func NewRateLimitTransport(
+	transport http.RoundTripper, requestsPerSecond float64, burst, maxConcurrent int) *RateLimitTransport {
+	t := &RateLimitTransport{
+		Transport:     transport,
+		MinRetryDelay: DefaultMinRetryDelay,
+		MaxRetryDelay: DefaultMaxRetryDelay,
+		MaxRetries:    DefaultMaxRetries,
+	}
+
+	if requestsPerSecond > 0 {
+		if burst < 1 {
+			burst = int(math.Max(1, math.Ceil(requestsPerSecond)))
+		}
+
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+
+	if maxConcurrent > 0 {
+		t.semaphore = make(chan struct{}, maxConcurrent)
+	}
+
+	return t
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	attemptReq := req
+
+	for attempt := 0; ; attempt++ {
+		if err := t.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.send(attemptReq)
+		if err != nil {
+			return nil, err
+		}
+
+		t.observeRateLimit(resp)
+
+		delay, retry := t.retryDelay(req, resp, attempt)
+		if !retry {
+			return resp, nil
+		}
+
+		nextReq, err := rewindRequest(req)
+		if err != nil {
+			return resp, nil
+		}
+
+		log.Printf("[INFO] Received status %d for %s %s - retrying in %s",
+			resp.StatusCode, req.Method, req.URL.Path, delay)
+
+		io.Copy(ioutil.Discard, resp.Body) // nolint:errcheck
+		resp.Body.Close()
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+
+		attemptReq = nextReq
+	}
+}
+
+// wait blocks until the request may be sent under the rate limit.
+func (t *RateLimitTransport) wait(ctx context.Context) error {
+	t.mu.Lock()
+	blockedFor := time.Until(t.blockedUntil)
+	t.mu.Unlock()
+
+	if blockedFor > 0 {
+		log.Printf("[DEBUG] Linode API rate limit reached, waiting %s", blockedFor)
+
+		if err := sleepContext(ctx, blockedFor); err != nil {
+			return err
+		}
+	}
+
+	if t.limiter == nil {
+		return nil
+	}
+
+	return t.limiter.Wait(ctx)
+}
+
+// send sends the request once a concurrent request slot is free.
+func (t *RateLimitTransport) send(req *http.Request) (*http.Response, error) {
+	if t.semaphore != nil {
+		select {
+		case t.semaphore <- struct{}{}:
+			defer func() { <-t.semaphore }()
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	return t.Transport.RoundTrip(req)
+}
+
+// observeRateLimit pauses all requests if the response shows the rate limit has been reached.
+func (t *RateLimitTransport) observeRateLimit(resp *http.Response) {
+	var until time.Time
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if delay, ok := retryAfter(resp); ok {
+			until = time.Now().Add(delay)
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			if resetTime := time.Unix(reset, 0); resetTime.After(until) {
+				until = resetTime
+			}
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if until.After(t.blockedUntil) {
+		t.blockedUntil = until
+	}
+}
+
+// retryDelay returns how long to wait before retrying the request, if it should be retried.
+func (t *RateLimitTransport) retryDelay(req *http.Request, resp *http.Response, attempt int) (time.Duration, bool) {
+	if attempt >= t.MaxRetries {
+		return 0, false
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode == http.StatusConflict:
+	case resp.StatusCode >= 500 && resp.Header.Get("X-Maintenance-Mode") == "":
+		// A request that changed something may have done so before failing
+		if !isIdempotent(req.Method) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	if delay, ok := retryAfter(resp); ok {
+		return delay, true
+	}
+
+	return t.backoff(attempt), true
+}
+
+// backoff returns a jittered exponential delay for the given attempt.
+func (t *RateLimitTransport) backoff(attempt int) time.Duration {
+	delay := t.MinRetryDelay << uint(attempt)
+	if delay <= 0 || delay > t.MaxRetryDelay {
+		delay = t.MaxRetryDelay
+	}
+
+	if delay < 2 {
+		return delay
+	}
+
+	// nolint:gosec
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)))
+}
+
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// rewindRequest returns a copy of the request with a fresh body that can be sent again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	result := req.Clone(req.Context())
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return result, nil
+	}
+
+	if req.GetBody == nil {
+		return nil, errors.New("request body cannot be rewound")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	result.Body = body
+
+	return result, nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
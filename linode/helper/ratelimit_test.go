@@ -0,0 +1,234 @@
+package helper_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/linode/terraform-provider-linode/linode/helper"
+)
+
+// testRateLimitClient returns a client sending requests to the given handler through a RateLimitTransport.
+func testRateLimitClient(
+	t *testing.T, handler http.HandlerFunc, rps float64, burst, concurrent int) *http.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	transport := helper.NewRateLimitTransport(http.DefaultTransport, rps, burst, concurrent)
+	transport.MinRetryDelay = 10 * time.Millisecond
+	transport.MaxRetryDelay = 20 * time.Millisecond
+
+	return &http.Client{Transport: testBaseURLTransport{server.URL, transport}}
+}
+
+// testBaseURLTransport sends every request to the test server.
+type testBaseURLTransport struct {
+	url       string
+	transport http.RoundTripper
+}
+
+func (t testBaseURLTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	url, err := req.URL.Parse(t.url + req.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.URL = url
+
+	return t.transport.RoundTrip(req)
+}
+
+func testRateLimitRequest(t *testing.T, client *http.Client, method, body string) int {
+	t.Helper()
+
+	req, err := http.NewRequest(method, "http://linode.test/v4/linode/instances", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode
+}
+
+func TestRateLimitTransportRate(t *testing.T) {
+	client := testRateLimitClient(t, func(w http.ResponseWriter, r *http.Request) {}, 20, 1, 0)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		testRateLimitRequest(t, client, http.MethodGet, "")
+	}
+
+	// The first request is sent at once and the rest at 20 per second
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Fatalf("expected 5 requests to take at least 200ms, took %s", elapsed)
+	}
+}
+
+func TestRateLimitTransportConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	client := testRateLimitClient(t, func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}, 0, 0, 2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			testRateLimitRequest(t, client, http.MethodGet, "")
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 2 {
+		t.Fatalf("expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
+
+func TestRateLimitTransportRetry(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		method   string
+		statuses []int
+		expected int
+		requests int
+	}{
+		{name: "conflict", method: http.MethodPost, statuses: []int{409, 409, 200}, expected: 200, requests: 3},
+		{name: "idempotent server error", method: http.MethodPut, statuses: []int{502, 200}, expected: 200, requests: 2},
+		{name: "non-idempotent server error", method: http.MethodPost, statuses: []int{502, 200}, expected: 502, requests: 1},
+		{name: "client error", method: http.MethodGet, statuses: []int{400, 200}, expected: 400, requests: 1},
+		{name: "too many retries", method: http.MethodGet, statuses: []int{409, 409, 409, 409, 409, 409, 409}, expected: 409, requests: 6},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int32
+
+			client := testRateLimitClient(t, func(w http.ResponseWriter, r *http.Request) {
+				i := atomic.AddInt32(&requests, 1) - 1
+
+				// Every attempt must send the full body
+				if body, _ := ioutil.ReadAll(r.Body); string(body) != `{"label":"test"}` {
+					t.Errorf("unexpected body %q", body)
+				}
+
+				w.WriteHeader(tc.statuses[i])
+			}, 0, 0, 0)
+
+			status := testRateLimitRequest(t, client, tc.method, `{"label":"test"}`)
+			if status != tc.expected {
+				t.Errorf("expected status %d, got %d", tc.expected, status)
+			}
+
+			if int(requests) != tc.requests {
+				t.Errorf("expected %d requests, got %d", tc.requests, requests)
+			}
+		})
+	}
+}
+
+func TestRateLimitTransportRetryAfter(t *testing.T) {
+	var requests int32
+	var times [3]time.Time
+
+	client := testRateLimitClient(t, func(w http.ResponseWriter, r *http.Request) {
+		i := atomic.AddInt32(&requests, 1) - 1
+		times[i] = time.Now()
+
+		if i == 0 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}, 0, 0, 0)
+
+	if status := testRateLimitRequest(t, client, http.MethodGet, ""); status != http.StatusOK {
+		t.Fatalf("expected the request to be retried, got status %d", status)
+	}
+
+	// Every other request waits for the limit to reset as well
+	testRateLimitRequest(t, client, http.MethodGet, "")
+
+	if delay := times[1].Sub(times[0]); delay < 900*time.Millisecond {
+		t.Errorf("expected the retry to wait for the Retry-After delay, waited %s", delay)
+	}
+
+	if requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+}
+
+func TestRateLimitTransportRateLimitReset(t *testing.T) {
+	var requests int32
+	var times [2]time.Time
+
+	reset := time.Now().Add(2 * time.Second).Truncate(time.Second)
+
+	client := testRateLimitClient(t, func(w http.ResponseWriter, r *http.Request) {
+		i := atomic.AddInt32(&requests, 1) - 1
+		times[i] = time.Now()
+
+		if i == 0 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset.Unix()))
+		}
+	}, 0, 0, 0)
+
+	testRateLimitRequest(t, client, http.MethodGet, "")
+	testRateLimitRequest(t, client, http.MethodGet, "")
+
+	if times[1].Before(reset) {
+		t.Errorf("expected the second request to wait until %s, sent at %s", reset, times[1])
+	}
+}
+
+func TestLockEntity(t *testing.T) {
+	var held, maxHeld int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// Only the locks of the same Linode exclude each other
+			defer helper.LockEntity("linode", i%2)()
+			if i%2 == 1 {
+				return
+			}
+
+			if current := atomic.AddInt32(&held, 1); current > atomic.LoadInt32(&maxHeld) {
+				atomic.StoreInt32(&maxHeld, current)
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&held, -1)
+		}(i)
+	}
+	wg.Wait()
+
+	if maxHeld != 1 {
+		t.Fatalf("expected the lock to be held once at a time, got %d", maxHeld)
+	}
+}
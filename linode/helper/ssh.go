@@ -0,0 +1,92 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SSHPollInterval is the interval between SSH connection attempts in WaitForSSH.
+var SSHPollInterval = 5 * time.Second
+
+// WaitForSSH blocks until an SSH handshake with the server at the given address succeeds or the timeout
+// elapses, and returns the host key presented by the server. If fingerprint is not empty, the host key
+// must match it as either a SHA256 or a legacy MD5 fingerprint.
+func WaitForSSH(ctx context.Context, address, fingerprint string, timeout time.Duration) (ssh.PublicKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(SSHPollInterval)
+	defer ticker.Stop()
+
+	for {
+		hostKey, err := getSSHHostKey(ctx, address)
+		if err == nil {
+			if fingerprint != "" && !SSHFingerprintMatches(hostKey, fingerprint) {
+				return nil, fmt.Errorf("host key of %s (%s) does not match the expected fingerprint %s",
+					address, ssh.FingerprintSHA256(hostKey), fingerprint)
+			}
+
+			return hostKey, nil
+		}
+
+		log.Printf("[DEBUG] waiting for SSH on %s: %s", address, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for SSH on %s: %s", address, err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// SSHFingerprintMatches returns whether the given SHA256 or legacy MD5 fingerprint matches the key.
+func SSHFingerprintMatches(key ssh.PublicKey, fingerprint string) bool {
+	return fingerprint == ssh.FingerprintSHA256(key) || fingerprint == ssh.FingerprintLegacyMD5(key)
+}
+
+// getSSHHostKey performs an SSH handshake with the server at the given address and returns its host key.
+// No credentials are offered, so an authentication failure following the key exchange is not an error.
+func getSSHHostKey(ctx context.Context, address string) (ssh.PublicKey, error) {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	var hostKey ssh.PublicKey
+
+	config := &ssh.ClientConfig{
+		User: "root",
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			hostKey = key
+			return nil
+		},
+	}
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, address, config)
+	if hostKey == nil {
+		if err == nil {
+			err = fmt.Errorf("no host key was presented")
+		}
+		return nil, err
+	}
+
+	if err == nil {
+		ssh.NewClient(sshConn, chans, reqs).Close()
+	}
+
+	return hostKey, nil
+}
@@ -0,0 +1,95 @@
+package helper_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+	"golang.org/x/crypto/ssh"
+)
+
+// startSSHServer starts a local SSH server stand-in that completes handshakes without authentication.
+func startSSHServer(t *testing.T) (string, ssh.PublicKey) {
+	t.Helper()
+
+	_, privateKey, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				if sshConn, _, _, err := ssh.NewServerConn(conn, config); err == nil {
+					sshConn.Close()
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String(), signer.PublicKey()
+}
+
+func TestWaitForSSH(t *testing.T) {
+	address, hostKey := startSSHServer(t)
+
+	key, err := helper.WaitForSSH(context.Background(), address, "", 10*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if ssh.FingerprintSHA256(key) != ssh.FingerprintSHA256(hostKey) {
+		t.Fatalf("expected host key %s, got %s", ssh.FingerprintSHA256(hostKey), ssh.FingerprintSHA256(key))
+	}
+}
+
+func TestWaitForSSH_fingerprint(t *testing.T) {
+	address, hostKey := startSSHServer(t)
+
+	for _, fingerprint := range []string{ssh.FingerprintSHA256(hostKey), ssh.FingerprintLegacyMD5(hostKey)} {
+		if _, err := helper.WaitForSSH(context.Background(), address, fingerprint, 10*time.Second); err != nil {
+			t.Fatalf("unexpected error for fingerprint %s: %s", fingerprint, err)
+		}
+	}
+
+	if _, err := helper.WaitForSSH(
+		context.Background(), address, "SHA256:bWlzbWF0Y2hlZA", 10*time.Second); err == nil {
+		t.Fatal("expected an error for a mismatched fingerprint")
+	}
+}
+
+func TestWaitForSSH_timeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	if _, err := helper.WaitForSSH(context.Background(), address, "", time.Second); err == nil {
+		t.Fatal("expected an error waiting for a closed port")
+	}
+}
@@ -0,0 +1,142 @@
+package helper
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// tagNameSeparator separates the name of a tag from its value (e.g. `env:prod`).
+const tagNameSeparator = ":"
+
+// TagsAllSchema should be referenced in the schema of any resource that supports
+// the provider-level `default_tags` block.
+func TagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+		Computed: true,
+		Description: "All tags applied to this object, including any tags inherited from the provider " +
+			"`default_tags` block.",
+	}
+}
+
+// TagName returns the name of the given tag. Tags in the format `name:value` are identified
+// by their name; all other tags are identified by the full tag.
+func TagName(tag string) string {
+	return strings.SplitN(tag, tagNameSeparator, 2)[0]
+}
+
+// MergeTags merges the given default tags into the given resource tags.
+// Resource tags take precedence over default tags with the same name.
+func MergeTags(defaultTags, tags []string) []string {
+	names := make(map[string]struct{}, len(tags))
+	seen := make(map[string]struct{}, len(tags)+len(defaultTags))
+	result := make([]string, 0, len(tags)+len(defaultTags))
+
+	for _, tag := range tags {
+		names[TagName(tag)] = struct{}{}
+
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+
+		seen[tag] = struct{}{}
+		result = append(result, tag)
+	}
+
+	for _, tag := range defaultTags {
+		if _, ok := names[TagName(tag)]; ok {
+			continue
+		}
+
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+
+		seen[tag] = struct{}{}
+		result = append(result, tag)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// ExpandTags returns the tags of the given resource merged with the provider default tags.
+func ExpandTags(d *schema.ResourceData, meta interface{}) []string {
+	return MergeTags(defaultTags(meta), ExpandStringSet(d.Get("tags").(*schema.Set)))
+}
+
+// SetTags sets the `tags` and `tags_all` attributes from the tags returned by the API.
+// Tags inherited from the provider default tags are only reflected in `tags_all`.
+func SetTags(d *schema.ResourceData, meta interface{}, tags []string) {
+	configured := make(map[string]struct{})
+	for _, tag := range ExpandStringSet(d.Get("tags").(*schema.Set)) {
+		configured[tag] = struct{}{}
+	}
+
+	defaults := make(map[string]struct{})
+	for _, tag := range defaultTags(meta) {
+		defaults[tag] = struct{}{}
+	}
+
+	resourceTags := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		_, isConfigured := configured[tag]
+		_, isDefault := defaults[tag]
+
+		if isDefault && !isConfigured {
+			continue
+		}
+
+		resourceTags = append(resourceTags, tag)
+	}
+
+	d.Set("tags", resourceTags)
+	d.Set("tags_all", tags)
+}
+
+// CustomizeDiffTagsAll computes the planned `tags_all` value for a resource so that
+// changes to the provider default tags and drift in provider-added tags show up in the plan.
+func CustomizeDiffTagsAll(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	merged := MergeTags(defaultTags(meta), ExpandStringSet(d.Get("tags").(*schema.Set)))
+
+	current := ExpandStringSet(d.Get("tags_all").(*schema.Set))
+	sort.Strings(current)
+
+	if d.Id() != "" && stringSlicesEqual(current, merged) {
+		return nil
+	}
+
+	return d.SetNew("tags_all", merged)
+}
+
+func defaultTags(meta interface{}) []string {
+	providerMeta, ok := meta.(*ProviderMeta)
+	if !ok || providerMeta.Config == nil {
+		return nil
+	}
+
+	return providerMeta.Config.DefaultTags
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,53 @@
+package helper_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/linode/terraform-provider-linode/linode/helper"
+)
+
+func TestMergeTags(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		defaultTags []string
+		tags        []string
+		expected    []string
+	}{
+		{
+			name:     "no default tags",
+			tags:     []string{"foo", "bar"},
+			expected: []string{"bar", "foo"},
+		},
+		{
+			name:        "no resource tags",
+			defaultTags: []string{"owner:platform", "env:prod"},
+			expected:    []string{"env:prod", "owner:platform"},
+		},
+		{
+			name:        "merge disjoint tags",
+			defaultTags: []string{"owner:platform", "cost-center"},
+			tags:        []string{"app"},
+			expected:    []string{"app", "cost-center", "owner:platform"},
+		},
+		{
+			name:        "resource tag wins over default tag with the same name",
+			defaultTags: []string{"env:prod", "owner:platform"},
+			tags:        []string{"env:staging"},
+			expected:    []string{"env:staging", "owner:platform"},
+		},
+		{
+			name:        "duplicate tags are removed",
+			defaultTags: []string{"tf_test", "owner:platform"},
+			tags:        []string{"tf_test", "tf_test"},
+			expected:    []string{"owner:platform", "tf_test"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result := helper.MergeTags(tc.defaultTags, tc.tags)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("expected %v; got %v", tc.expected, result)
+			}
+		})
+	}
+}
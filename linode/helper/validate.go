@@ -0,0 +1,20 @@
+package helper
+
+import (
+	"fmt"
+	"time"
+)
+
+// ValidateDuration validates that the given value is a duration string accepted by time.ParseDuration.
+func ValidateDuration(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, err := time.ParseDuration(v); err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a duration: %s", k, err)}
+	}
+
+	return nil, nil
+}
@@ -73,6 +73,14 @@ func TestAccDataSourceImages_basic(t *testing.T) {
 				),
 			},
 
+			{
+				Config: tmpl.DataLimit(t, imageName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "images.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "images.0.label", imageName),
+				),
+			},
+
 			{
 				Config: tmpl.DataSubstring(t, imageName),
 				Check: resource.ComposeTestCheckFunc(
@@ -32,9 +32,12 @@ var dataSourceSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Default:     false,
 	},
-	"order_by": filterConfig.OrderBySchema(),
-	"order":    filterConfig.OrderSchema(),
-	"filter":   filterConfig.FilterSchema(),
+	"order_by":     filterConfig.OrderBySchema(),
+	"order":        filterConfig.OrderSchema(),
+	"filter":       filterConfig.FilterSchema(),
+	"filter_group": filterConfig.FilterGroupSchema(),
+	"limit":        filterConfig.LimitSchema(),
+	"page_size":    filterConfig.PageSizeSchema(),
 	"images": {
 		Type:        schema.TypeList,
 		Description: "The returned list of Images.",
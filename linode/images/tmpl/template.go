@@ -30,6 +30,11 @@ func DataOrder(t *testing.T, image string) string {
 		"images_data_order", TemplateData{Image: image})
 }
 
+func DataLimit(t *testing.T, image string) string {
+	return acceptance.ExecuteTemplate(t,
+		"images_data_limit", TemplateData{Image: image})
+}
+
 func DataSubstring(t *testing.T, image string) string {
 	return acceptance.ExecuteTemplate(t,
 		"images_data_substring", TemplateData{Image: image})
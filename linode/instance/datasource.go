@@ -2,6 +2,8 @@ package instance
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -25,6 +27,41 @@ var filterConfig = helper.FilterConfig{
 	},
 	"type":             {TypeFunc: helper.FilterTypeString},
 	"watchdog_enabled": {TypeFunc: helper.FilterTypeBool},
+
+	"backups.enabled": {TypeFunc: helper.FilterTypeBool},
+	"specs.disk":      {TypeFunc: helper.FilterTypeInt},
+	"specs.memory":    {TypeFunc: helper.FilterTypeInt},
+	"specs.transfer":  {TypeFunc: helper.FilterTypeInt},
+	"specs.vcpus":     {TypeFunc: helper.FilterTypeInt},
+
+	// Configs are only listed for filtering when one of these is used
+	"config.kernel":          {TypeFunc: helper.FilterTypeString},
+	"config.label":           {TypeFunc: helper.FilterTypeString},
+	"config.interface.label": {TypeFunc: helper.FilterTypeString},
+	"config.interface.purpose": {
+		TypeFunc: func(value string) (interface{}, error) {
+			return linodego.ConfigInterfacePurpose(value), nil
+		},
+	},
+}
+
+// filterableInstance is an instance along with any of its configs that are needed for filtering.
+type filterableInstance struct {
+	instance      linodego.Instance
+	configs       []linodego.InstanceConfig
+	configsListed bool
+}
+
+func flattenFilterableInstance(data interface{}) map[string]interface{} {
+	filterable := data.(filterableInstance)
+
+	result := flattenInstanceSimple(&filterable.instance)
+
+	if filterable.configsListed {
+		result["config"] = flattenInstanceConfigs(filterable.configs, nil)
+	}
+
+	return result
 }
 
 func dataSourceInstance() *schema.Resource {
@@ -37,9 +74,12 @@ func DataSource() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: readDataSource,
 		Schema: map[string]*schema.Schema{
-			"filter":   filterConfig.FilterSchema(),
-			"order_by": filterConfig.OrderBySchema(),
-			"order":    filterConfig.OrderSchema(),
+			"filter":       filterConfig.FilterSchema(),
+			"filter_group": filterConfig.FilterGroupSchema(),
+			"limit":        filterConfig.LimitSchema(),
+			"page_size":    filterConfig.PageSizeSchema(),
+			"order_by":     filterConfig.OrderBySchema(),
+			"order":        filterConfig.OrderSchema(),
 			"instances": {
 				Type:        schema.TypeList,
 				Description: "The returned list of Instances.",
@@ -63,31 +103,61 @@ func readDataSource(ctx context.Context, d *schema.ResourceData, meta interface{
 		return diag.Errorf("failed to construct filter: %s", err)
 	}
 
-	instances, err := client.ListInstances(ctx, &linodego.ListOptions{
-		Filter: filter,
-	})
-	if err != nil {
-		return diag.Errorf("failed to get instances: %s", err)
-	}
-
-	instanceIDMap := make(map[int]linodego.Instance, len(instances))
+	var instanceIDMapMutex sync.Mutex
+	instanceIDMap := make(map[int]linodego.Instance)
 
-	// Create a list of filterable instance maps
-	flattenedInstances := make([]interface{}, len(instances))
-	for i, instance := range instances {
-		instanceIDMap[instance.ID] = instance
+	// Configs are listed for each instance, so only for the instances matching every other filter
+	listConfigs := filterConfig.FiltersOn(d, "config") || filterConfig.OrdersOn(d, "config")
+	prefilter := filterConfig.PrefilterFunc(d, "config")
 
-		instanceMap, err := flattenInstanceSimple(&instance)
+	// Pages may be listed concurrently
+	listInstances := func(
+		ctx context.Context, client *linodego.Client, options *linodego.ListOptions) ([]interface{}, error) {
+		instances, err := client.ListInstances(ctx, options)
 		if err != nil {
-			return diag.Errorf("failed to translate instance to filterable map: %s", err)
+			return nil, err
+		}
+
+		result := make([]interface{}, 0, len(instances))
+
+		for _, instance := range instances {
+			filterable := filterableInstance{instance: instance}
+
+			if listConfigs {
+				match, err := prefilter(flattenFilterableInstance(filterable))
+				if err != nil {
+					return nil, err
+				}
+
+				if !match {
+					continue
+				}
+
+				filterable.configs, err = client.ListInstanceConfigs(ctx, instance.ID, nil)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get the configs for instance %d: %s", instance.ID, err)
+				}
+
+				filterable.configsListed = true
+			}
+
+			result = append(result, filterable)
 		}
 
-		flattenedInstances[i] = instanceMap
+		instanceIDMapMutex.Lock()
+		defer instanceIDMapMutex.Unlock()
+
+		for _, instance := range instances {
+			instanceIDMap[instance.ID] = instance
+		}
+
+		return result, nil
 	}
 
-	instancesFiltered, err := filterConfig.FilterResults(d, flattenedInstances)
+	instancesFiltered, err := filterConfig.ListFilteredItems(
+		ctx, d, &client, filter, listInstances, flattenFilterableInstance)
 	if err != nil {
-		return diag.Errorf("failed to filter returned instances: %s", err)
+		return diag.Errorf("failed to get instances: %s", err)
 	}
 
 	// Fully populate returned instances
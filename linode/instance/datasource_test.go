@@ -74,6 +74,12 @@ func TestAccDataSourceInstances_multipleInstances(t *testing.T) {
 					resource.TestCheckResourceAttr(resName, "instances.#", "3"),
 				),
 			},
+			{
+				Config: tmpl.DataMultipleNested(t, instanceName, tagName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "instances.#", "3"),
+				),
+			},
 			{
 				Config: tmpl.DataClientFilter(t, instanceName, tagName),
 				Check: resource.ComposeTestCheckFunc(
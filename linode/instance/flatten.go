@@ -199,7 +199,7 @@ func flattenInstanceSpecs(instance linodego.Instance) []map[string]int {
 	}}
 }
 
-func flattenInstanceSimple(instance *linodego.Instance) (map[string]interface{}, error) {
+func flattenInstanceSimple(instance *linodego.Instance) map[string]interface{} {
 	result := make(map[string]interface{})
 
 	var ips []string
@@ -222,5 +222,5 @@ func flattenInstanceSimple(instance *linodego.Instance) (map[string]interface{},
 	result["specs"] = flattenInstanceSpecs(*instance)
 	result["alerts"] = flattenInstanceAlerts(*instance)
 
-	return result, nil
+	return result
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/linode/linodego"
 	"github.com/linode/terraform-provider-linode/linode/helper"
 	"golang.org/x/crypto/sha3"
+	"golang.org/x/crypto/ssh"
 )
 
 var (
@@ -24,8 +25,52 @@ var (
 	boolTrue  = true
 )
 
+// Event actions for cross-datacenter migrations, which are not defined by linodego.
+const (
+	actionLinodeMigrateDatacenter       linodego.EventAction = "linode_migrate_datacenter"
+	actionLinodeMigrateDatacenterCreate linodego.EventAction = "linode_migrate_datacenter_create"
+)
+
+const (
+	rebootPolicyNever    = "never"
+	rebootPolicyOnChange = "on_change"
+	rebootPolicyAlways   = "always"
+)
+
+// rebootRequiredKeys are the arguments that can require a reboot of the instance to take effect.
+var rebootRequiredKeys = []string{"private_ip", "disk", "config", "interface", "swap_size", "type"}
+
+// rebuildKeys are the arguments that can only be applied to an existing instance through a rebuild.
+var rebuildKeys = []string{
+	"image", "stackscript_id", "stackscript_data", "authorized_keys", "authorized_users", "root_pass",
+}
+
 type diskSpec map[string]interface{}
 
+// importInstanceChildID parses an import ID in the form of linode_id,child_id for resources that
+// belong to an instance.
+func importInstanceChildID(d *schema.ResourceData) error {
+	s := strings.Split(d.Id(), ",")
+	if len(s) != 2 {
+		return fmt.Errorf("expected an ID in the form of linode_id,id; got %s", d.Id())
+	}
+
+	linodeID, err := strconv.Atoi(s[0])
+	if err != nil {
+		return fmt.Errorf("invalid linode ID: %s", err)
+	}
+
+	// Validate that this is an ID by making sure it can be converted into an int
+	if _, err := strconv.Atoi(s[1]); err != nil {
+		return fmt.Errorf("invalid ID: %s", err)
+	}
+
+	d.SetId(s[1])
+	d.Set("linode_id", linodeID)
+
+	return nil
+}
+
 // getDeadlineSeconds gets the seconds remaining until deadline is met.
 func getDeadlineSeconds(ctx context.Context, d *schema.ResourceData) int {
 	duration := d.Timeout(schema.TimeoutUpdate)
@@ -225,7 +270,7 @@ func updateInstanceConfigs(
 			}
 
 			if configUpdateOpts.Devices != nil {
-				detacher := makeVolumeDetacherIgnoreAttached(client, d)
+				detacher := makeVolumeDetacherIgnoreAttached(client, d, instance.ID)
 
 				if detachErr := detachConfigVolumes(ctx, *configUpdateOpts.Devices, detacher); detachErr != nil {
 					return rebootInstance, updatedConfigMap, updatedConfigs, detachErr
@@ -357,20 +402,15 @@ func makeVolumeDetacher(client linodego.Client, d *schema.ResourceData) volumeDe
 	}
 }
 
-func makeVolumeDetacherIgnoreAttached(client linodego.Client, d *schema.ResourceData) volumeDetacher {
+func makeVolumeDetacherIgnoreAttached(client linodego.Client, d *schema.ResourceData, instanceID int) volumeDetacher {
 	return func(ctx context.Context, volumeID int, reason string) error {
-		id, err := strconv.Atoi(d.Id())
-		if err != nil {
-			return err
-		}
-
 		vol, err := client.GetVolume(ctx, volumeID)
 		if err != nil {
 			return err
 		}
 
-		if vol.LinodeID != nil && *vol.LinodeID == id {
-			log.Printf("[INFO] Volume %d is already attached to Linode %d, ignoring ...", volumeID, id)
+		if vol.LinodeID != nil && *vol.LinodeID == instanceID {
+			log.Printf("[INFO] Volume %d is already attached to Linode %d, ignoring ...", volumeID, instanceID)
 			return nil
 		}
 
@@ -380,11 +420,13 @@ func makeVolumeDetacherIgnoreAttached(client linodego.Client, d *schema.Resource
 
 func createInstanceDisk(
 	ctx context.Context,
-	client linodego.Client,
+	meta *helper.ProviderMeta,
 	instance linodego.Instance,
 	disk diskSpec,
 	d *schema.ResourceData,
 ) (*linodego.InstanceDisk, error) {
+	client := meta.Client
+
 	diskOpts := linodego.InstanceDiskCreateOptions{
 		Label:      disk["label"].(string),
 		Filesystem: disk["filesystem"].(string),
@@ -437,7 +479,7 @@ func createInstanceDisk(
 		return nil, fmt.Errorf("Error creating Linode instance %d disk: %s", instance.ID, err)
 	}
 
-	_, err = client.WaitForEventFinished(ctx, instance.ID, linodego.EntityLinode,
+	_, err = meta.Events.WaitForEventFinished(ctx, instance.ID, linodego.EntityLinode,
 		linodego.ActionDiskCreate, *instanceDisk.Created, getDeadlineSeconds(ctx, d))
 	if err != nil {
 		return nil, fmt.Errorf("Error waiting for Linode instance %d disk: %s", instanceDisk.ID, err)
@@ -537,7 +579,8 @@ func getInstanceDiskSpecDiffs(
 // This function will also warn when there are disks attached to an instance which are not managed by
 // terraform.
 func updateInstanceDisks(
-	ctx context.Context, client linodego.Client, d *schema.ResourceData, instance linodego.Instance) (bool, error) {
+	ctx context.Context, meta *helper.ProviderMeta, d *schema.ResourceData, instance linodego.Instance) (bool, error) {
+	client := meta.Client
 	oldDisk, newDisk := getInstanceDiskSpecChange(d)
 	added, removed, existing := getInstanceDiskSpecDiffs(oldDisk, newDisk)
 	disks, err := getInstanceDisks(ctx, client, instance.ID)
@@ -566,7 +609,7 @@ func updateInstanceDisks(
 		if err := client.DeleteInstanceDisk(ctx, instance.ID, disk.ID); err != nil {
 			return hasChanges, err
 		}
-		_, err = client.WaitForEventFinished(ctx, instance.ID, linodego.EntityLinode,
+		_, err = meta.Events.WaitForEventFinished(ctx, instance.ID, linodego.EntityLinode,
 			linodego.ActionDiskDelete, *instance.Created, getDeadlineSeconds(ctx, d))
 		if err != nil {
 			return hasChanges, fmt.Errorf(
@@ -581,7 +624,7 @@ func updateInstanceDisks(
 		// The only non-destructive change supported is resize.
 		// Label renames are not supported because this TF provider relies on the label as an identifier.
 		if spec["size"].(int) != existingDisk.Size {
-			if err := changeInstanceDiskSize(ctx, &client, instance, existingDisk, spec["size"].(int), d); err != nil {
+			if err := changeInstanceDiskSize(ctx, meta, instance, existingDisk, spec["size"].(int), d); err != nil {
 				return hasChanges, err
 			}
 			hasChanges = true
@@ -594,7 +637,7 @@ func updateInstanceDisks(
 
 	// create disks staged for creation
 	for _, spec := range added {
-		if _, err := createInstanceDisk(ctx, client, instance, spec, d); err != nil {
+		if _, err := createInstanceDisk(ctx, meta, instance, spec, d); err != nil {
 			return hasChanges, err
 		}
 	}
@@ -655,16 +698,360 @@ func ensureInstanceOffline(
 	return client.WaitForInstanceStatus(ctx, instanceID, linodego.InstanceOffline, timeout)
 }
 
+// getDeclaredBooted returns the declared booted state of the instance or nil if it is not declared.
+func getDeclaredBooted(d *schema.ResourceData) *bool {
+	booted := d.GetRawConfig().GetAttr("booted")
+	if booted.IsNull() || !booted.IsKnown() {
+		return nil
+	}
+
+	result := booted.True()
+	return &result
+}
+
+// isInstanceBooted returns whether the given instance is powered on or in the process of powering on.
+func isInstanceBooted(instance *linodego.Instance) bool {
+	switch instance.Status {
+	case linodego.InstanceRunning, linodego.InstanceBooting, linodego.InstanceRebooting:
+		return true
+	}
+
+	return false
+}
+
+// waitForLatestInstanceEvent waits for the latest event of the given action on an instance to finish.
+func waitForLatestInstanceEvent(
+	ctx context.Context, meta *helper.ProviderMeta, instanceID int, action linodego.EventAction, timeout int) error {
+	event, err := helper.GetLatestEvent(ctx, &meta.Client, instanceID, linodego.EntityLinode, action)
+	if err != nil {
+		return fmt.Errorf("failed to get latest %s event for instance %d: %s", action, instanceID, err)
+	}
+
+	if event == nil {
+		return fmt.Errorf("no %s event was found for instance %d", action, instanceID)
+	}
+
+	if _, err := meta.Events.WaitForEventFinished(
+		ctx, instanceID, linodego.EntityLinode, action, *event.Created, timeout); err != nil {
+		return fmt.Errorf("failed to wait for instance %d %s event: %s", instanceID, action, err)
+	}
+
+	return nil
+}
+
+// bootInstance boots the given instance with the given config and waits for it to be running.
+func bootInstance(ctx context.Context, meta *helper.ProviderMeta, instanceID, configID, timeout int) error {
+	client := meta.Client
+
+	if err := client.BootInstance(ctx, instanceID, configID); err != nil {
+		return fmt.Errorf("Error booting Linode instance %d: %s", instanceID, err)
+	}
+
+	if err := waitForLatestInstanceEvent(ctx, meta, instanceID, linodego.ActionLinodeBoot, timeout); err != nil {
+		return err
+	}
+
+	if _, err := client.WaitForInstanceStatus(ctx, instanceID, linodego.InstanceRunning, timeout); err != nil {
+		return fmt.Errorf("Timed-out waiting for Linode instance %d to boot: %s", instanceID, err)
+	}
+
+	return nil
+}
+
+// shutdownInstance shuts down the given instance and waits for it to be offline.
+func shutdownInstance(ctx context.Context, meta *helper.ProviderMeta, instanceID, timeout int) error {
+	client := meta.Client
+
+	if err := client.ShutdownInstance(ctx, instanceID); err != nil {
+		return fmt.Errorf("Error shutting down Linode instance %d: %s", instanceID, err)
+	}
+
+	if err := waitForLatestInstanceEvent(ctx, meta, instanceID, linodego.ActionLinodeShutdown, timeout); err != nil {
+		return err
+	}
+
+	if _, err := client.WaitForInstanceStatus(ctx, instanceID, linodego.InstanceOffline, timeout); err != nil {
+		return fmt.Errorf("Timed-out waiting for Linode instance %d to shut down: %s", instanceID, err)
+	}
+
+	return nil
+}
+
+// cloneInstance clones the instance declared in the clone_from block into a new instance.
+func cloneInstance(
+	ctx context.Context, d *schema.ResourceData, client *linodego.Client, createOpts linodego.InstanceCreateOptions,
+) (*linodego.Instance, error) {
+	sourceID := d.Get("clone_from.0.linode_id").(int)
+
+	cloneOpts := linodego.InstanceCloneOptions{
+		Region:         createOpts.Region,
+		Type:           createOpts.Type,
+		Label:          createOpts.Label,
+		Group:          createOpts.Group,
+		BackupsEnabled: createOpts.BackupsEnabled,
+		Disks:          helper.ExpandIntList(d.Get("clone_from.0.disks").([]interface{})),
+		Configs:        helper.ExpandIntList(d.Get("clone_from.0.configs").([]interface{})),
+	}
+
+	log.Printf("[INFO] Cloning Linode instance %d\n", sourceID)
+
+	instance, err := client.CloneInstance(ctx, sourceID, cloneOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone Linode instance %d: %s", sourceID, err)
+	}
+
+	return instance, nil
+}
+
+// waitForInstanceClone waits for a cloned instance to finish provisioning and for all of its disks to be ready.
+func waitForInstanceClone(ctx context.Context, d *schema.ResourceData, client *linodego.Client, instanceID int) error {
+	timeout := getDeadlineSeconds(ctx, d)
+
+	if _, err := client.WaitForInstanceStatus(ctx, instanceID, linodego.InstanceOffline, timeout); err != nil {
+		return fmt.Errorf("Error waiting for Linode instance %d to finish cloning: %s", instanceID, err)
+	}
+
+	disks, err := client.ListInstanceDisks(ctx, instanceID, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting the disks for the Linode instance %d: %s", instanceID, err)
+	}
+
+	for _, disk := range disks {
+		if _, err := client.WaitForInstanceDiskStatus(
+			ctx, instanceID, disk.ID, linodego.DiskReady, getDeadlineSeconds(ctx, d)); err != nil {
+			return fmt.Errorf("Error waiting for Linode instance %d disk %d to finish cloning: %s",
+				instanceID, disk.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// migrateInstance migrates the given instance to another region and waits for the migration to finish.
+func migrateInstance(
+	ctx context.Context, d *schema.ResourceData, meta *helper.ProviderMeta, instance *linodego.Instance, region string,
+) (*linodego.Instance, error) {
+	client := meta.Client
+
+	// linodego does not support cross-datacenter migrations, so the request is built directly
+	endpoint, err := client.Instances.Endpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	wasBooted := isInstanceBooted(instance)
+
+	log.Printf("[INFO] Instance [%d] will be migrated from %s to %s\n", instance.ID, instance.Region, region)
+
+	migrateStart := time.Now()
+
+	resp, err := client.R(ctx).
+		SetBody(map[string]string{"region": region}).
+		Post(fmt.Sprintf("%s/%d/migrate", endpoint, instance.ID))
+	if err == nil && resp.IsError() {
+		err = linodego.NewError(resp)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error migrating Linode instance %d to region %s: %s", instance.ID, region, err)
+	}
+
+	timeout := getDeadlineSeconds(ctx, d)
+
+	// The migration event is created once the migration is scheduled, so poll on events newer
+	// than the creation event as is done for resizes. Creation events of earlier migrations are ignored.
+	// The creation event is matched against the local time, which may differ from the API's.
+	createEvent, err := waitForMigrationScheduled(ctx, &client, instance.ID, migrateStart.Add(-time.Minute),
+		time.Duration(meta.Config.EventPollMilliseconds)*time.Millisecond, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("no migration was scheduled for instance %d to region %s: %s", instance.ID, region, err)
+	}
+
+	if _, err := meta.Events.WaitForEventFinished(ctx, instance.ID, linodego.EntityLinode,
+		actionLinodeMigrateDatacenter, *createEvent.Created, timeout); err != nil {
+		return nil, fmt.Errorf(
+			"Error waiting for Instance %d to finish migrating to region %s; the migration may still be in "+
+				"progress or may have failed and should be checked in the Linode Cloud Manager: %s",
+			instance.ID, region, err)
+	}
+
+	// The instance is returned to its previous power state after the migration
+	targetStatus := linodego.InstanceOffline
+	if wasBooted {
+		targetStatus = linodego.InstanceRunning
+	}
+
+	migrated, err := client.WaitForInstanceStatus(ctx, instance.ID, targetStatus, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("Timed-out waiting for Linode instance %d to reach status %s after migrating: %s",
+			instance.ID, targetStatus, err)
+	}
+
+	if migrated.Region != region {
+		return nil, fmt.Errorf("Instance %d is in region %s after migrating; expected %s",
+			instance.ID, migrated.Region, region)
+	}
+
+	return migrated, nil
+}
+
+// rebuildInstance redeploys the declared image to the given instance and waits for the rebuild to finish.
+// The instance is left offline so that remaining changes can be applied before it is booted.
+func rebuildInstance(
+	ctx context.Context, d *schema.ResourceData, meta *helper.ProviderMeta, instanceID int) (*linodego.Instance, error) {
+	client := meta.Client
+
+	rebuildOpts := linodego.InstanceRebuildOptions{
+		Image:         d.Get("image").(string),
+		RootPass:      d.Get("root_pass").(string),
+		StackScriptID: d.Get("stackscript_id").(int),
+		Booted:        &boolFalse,
+	}
+
+	for _, key := range d.Get("authorized_keys").([]interface{}) {
+		rebuildOpts.AuthorizedKeys = append(rebuildOpts.AuthorizedKeys, key.(string))
+	}
+	for _, user := range d.Get("authorized_users").([]interface{}) {
+		rebuildOpts.AuthorizedUsers = append(rebuildOpts.AuthorizedUsers, user.(string))
+	}
+
+	if rebuildOpts.RootPass == "" {
+		var err error
+		if rebuildOpts.RootPass, err = createRandomRootPassword(); err != nil {
+			return nil, err
+		}
+	}
+
+	if stackscriptData, ok := d.Get("stackscript_data").(map[string]interface{}); ok && len(stackscriptData) > 0 {
+		rebuildOpts.StackScriptData = make(map[string]string, len(stackscriptData))
+		for name, value := range stackscriptData {
+			rebuildOpts.StackScriptData[name] = value.(string)
+		}
+	}
+
+	log.Printf("[INFO] Instance [%d] will be rebuilt from image %s\n", instanceID, rebuildOpts.Image)
+
+	if _, err := client.RebuildInstance(ctx, instanceID, rebuildOpts); err != nil {
+		return nil, fmt.Errorf("Error rebuilding Linode instance %d: %s", instanceID, err)
+	}
+
+	timeout := getDeadlineSeconds(ctx, d)
+
+	if err := waitForLatestInstanceEvent(ctx, meta, instanceID, linodego.ActionLinodeRebuild, timeout); err != nil {
+		return nil, err
+	}
+
+	instance, err := client.WaitForInstanceStatus(ctx, instanceID, linodego.InstanceOffline, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("Timed-out waiting for Linode instance %d to finish rebuilding: %s", instanceID, err)
+	}
+
+	return instance, nil
+}
+
+// getInstanceConfigs returns the configs of the given instance.
+func getInstanceConfigs(
+	ctx context.Context, client *linodego.Client, instanceID int) ([]*linodego.InstanceConfig, error) {
+	configs, err := client.ListInstanceConfigs(ctx, instanceID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching the configs for Instance %d: %s", instanceID, err)
+	}
+
+	result := make([]*linodego.InstanceConfig, len(configs))
+	for i := range configs {
+		result[i] = &configs[i]
+	}
+
+	return result, nil
+}
+
+// isPendingRebootApplied returns whether the instance was booted or rebooted after the last change
+// that requires a reboot to take effect.
+func isPendingRebootApplied(
+	ctx context.Context, client *linodego.Client, instanceID int, configs []linodego.InstanceConfig) (bool, error) {
+	var lastChange time.Time
+	for _, config := range configs {
+		if config.Updated != nil && config.Updated.After(lastChange) {
+			lastChange = *config.Updated
+		}
+	}
+
+	latestEventTime := func(actions ...linodego.EventAction) (time.Time, error) {
+		var result time.Time
+
+		for _, action := range actions {
+			event, err := helper.GetLatestEvent(ctx, client, instanceID, linodego.EntityLinode, action)
+			if err != nil {
+				return result, fmt.Errorf("failed to get latest %s event for instance %d: %s", action, instanceID, err)
+			}
+
+			if event != nil && event.Created != nil && event.Created.After(result) {
+				result = *event.Created
+			}
+		}
+
+		return result, nil
+	}
+
+	lastChangeEvent, err := latestEventTime(linodego.ActionLinodeAddIP, linodego.ActionLinodeConfigCreate,
+		linodego.ActionLinodeConfigUpdate, linodego.ActionDiskCreate, linodego.ActionDiskDelete)
+	if err != nil {
+		return false, err
+	}
+
+	if lastChangeEvent.After(lastChange) {
+		lastChange = lastChangeEvent
+	}
+
+	lastBoot, err := latestEventTime(linodego.ActionLinodeBoot, linodego.ActionLinodeReboot)
+	if err != nil {
+		return false, err
+	}
+
+	return lastBoot.After(lastChange), nil
+}
+
+// validateNoShutdownRequired returns an error if the planned changes to an instance require it to be shut down.
+func validateNoShutdownRequired(d *schema.ResourceDiff) error {
+	const message = "changing %s requires the Linode to be shut down, which is not allowed by " +
+		"reboot_policy \"never\""
+
+	if d.HasChange("type") {
+		return fmt.Errorf(message, "type")
+	}
+
+	if d.HasChange("swap_size") {
+		return fmt.Errorf(message, "swap_size")
+	}
+
+	oldDisks, newDisks := d.GetChange("disk")
+	oldSizes := make(map[string]int)
+	for _, disk := range oldDisks.([]interface{}) {
+		disk := disk.(map[string]interface{})
+		oldSizes[disk["label"].(string)] = disk["size"].(int)
+	}
+
+	for _, disk := range newDisks.([]interface{}) {
+		disk := disk.(map[string]interface{})
+		if size, ok := oldSizes[disk["label"].(string)]; ok && size != disk["size"].(int) {
+			return fmt.Errorf(message, fmt.Sprintf("the size of disk %q", disk["label"]))
+		}
+	}
+
+	return nil
+}
+
 // changeInstanceType resizes the Linode Instance.
 func changeInstanceType(
 	ctx context.Context,
-	client *linodego.Client,
+	meta *helper.ProviderMeta,
 	instanceID int,
 	targetType string,
 	diskResize bool,
 	d *schema.ResourceData,
 ) (*linodego.Instance, error) {
-	instance, err := ensureInstanceOffline(ctx, client, instanceID, getDeadlineSeconds(ctx, d))
+	client := meta.Client
+
+	instance, err := ensureInstanceOffline(ctx, &client, instanceID, getDeadlineSeconds(ctx, d))
 	if err != nil {
 		return nil, err
 	}
@@ -681,13 +1068,13 @@ func changeInstanceType(
 	// This is necessary as linode_resize events are scheduled to be created long-after the initial
 	// resize request. In order to ensure we're polling on the correct event, we need use the
 	// creation date of the pre-resize event.
-	resizeCreateEvent, err := helper.GetLatestEvent(ctx, client, instance.ID, linodego.EntityLinode,
+	resizeCreateEvent, err := helper.GetLatestEvent(ctx, &client, instance.ID, linodego.EntityLinode,
 		linodego.ActionLinodeResizeCreate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get instance resize_create event %d: %s", instance.ID, err)
 	}
 
-	_, err = client.WaitForEventFinished(ctx, instance.ID, linodego.EntityLinode, linodego.ActionLinodeResize,
+	_, err = meta.Events.WaitForEventFinished(ctx, instance.ID, linodego.EntityLinode, linodego.ActionLinodeResize,
 		*resizeCreateEvent.Created, getDeadlineSeconds(ctx, d))
 	if err != nil {
 		return nil, fmt.Errorf("Error waiting for instance %d to finish resizing: %s", instance.ID, err)
@@ -703,6 +1090,39 @@ func changeInstanceType(
 	return instance, nil
 }
 
+// waitForMigrationScheduled polls for the creation event of a migration of an instance created after minStart,
+// which may only be created some time after the migration is requested.
+func waitForMigrationScheduled(ctx context.Context, client *linodego.Client, instanceID int, minStart time.Time,
+	pollInterval time.Duration, timeoutSeconds int) (*linodego.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+
+	if pollInterval <= 0 {
+		pollInterval = helper.DefaultEventPollInterval
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		event, err := helper.GetLatestEvent(ctx, client, instanceID, linodego.EntityLinode,
+			actionLinodeMigrateDatacenterCreate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get instance %d migration event: %s", instanceID, err)
+		}
+
+		if event != nil && event.Created != nil && !event.Created.Before(minStart) {
+			return event, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for the migration event: %s", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
 // returns the amount of disk space used by the new plan and old plan.
 func getDiskSizeChange(oldDisk interface{}, newDisk interface{}) (int, int) {
 	tfDisksOldInterface := oldDisk.([]interface{})
@@ -723,12 +1143,14 @@ func getDiskSizeChange(oldDisk interface{}, newDisk interface{}) (int, int) {
 
 func changeInstanceDiskSize(
 	ctx context.Context,
-	client *linodego.Client,
+	meta *helper.ProviderMeta,
 	instance linodego.Instance,
 	disk linodego.InstanceDisk,
 	targetSize int,
 	d *schema.ResourceData,
 ) error {
+	client := meta.Client
+
 	if instance.Specs.Disk < targetSize {
 		return fmt.Errorf("Error resizing disk %d: size exceeds disk size for Instance %d", disk.ID, instance.ID)
 	}
@@ -759,7 +1181,7 @@ func changeInstanceDiskSize(
 	}
 
 	// Wait for the disk resize operation to complete, and boot instance.
-	_, err := client.WaitForEventFinished(ctx, instance.ID, linodego.EntityLinode, linodego.ActionDiskResize,
+	_, err := meta.Events.WaitForEventFinished(ctx, instance.ID, linodego.EntityLinode, linodego.ActionDiskResize,
 		*disk.Updated, getDeadlineSeconds(ctx, d))
 	if err != nil {
 		return fmt.Errorf("Error waiting for resize of Instance %d Disk %d: %s", instance.ID, disk.ID, err)
@@ -844,7 +1266,7 @@ func getInstanceTypeChange(
 func applyInstanceDiskSpec(
 	ctx context.Context,
 	d *schema.ResourceData,
-	client *linodego.Client,
+	meta *helper.ProviderMeta,
 	instance *linodego.Instance,
 	typ *linodego.LinodeType,
 ) (bool, error) {
@@ -852,7 +1274,7 @@ func applyInstanceDiskSpec(
 		return false, err
 	}
 
-	return updateInstanceDisks(ctx, *client, d, *instance)
+	return updateInstanceDisks(ctx, meta, d, *instance)
 }
 
 // assertDiskConfigFitsInstanceType asserts that the cumulative disk space used by a given disk config fits a given
@@ -875,7 +1297,7 @@ func assertDiskConfigFitsInstanceType(d *schema.ResourceData, typ *linodego.Lino
 func applyInstanceTypeChange(
 	ctx context.Context,
 	d *schema.ResourceData,
-	client *linodego.Client,
+	meta *helper.ProviderMeta,
 	instance *linodego.Instance,
 	typ *linodego.LinodeType,
 ) (*linodego.Instance, error) {
@@ -893,7 +1315,7 @@ func applyInstanceTypeChange(
 		return nil, err
 	}
 
-	return changeInstanceType(ctx, client, instance.ID, typ.ID, resizeDisk, d)
+	return changeInstanceType(ctx, meta, instance.ID, typ.ID, resizeDisk, d)
 }
 
 // detachConfigVolumes detaches any volumes associated with an InstanceConfig.Devices struct.
@@ -946,3 +1368,24 @@ func detachConfigVolumes(
 
 	return nil
 }
+
+// waitForInstanceSSH waits until the SSH server of an instance is reachable according to its wait_for_ssh
+// block and stores the observed host key in ssh_host_key.
+func waitForInstanceSSH(ctx context.Context, d *schema.ResourceData, address string) error {
+	spec := d.Get("wait_for_ssh.0").(map[string]interface{})
+
+	timeout, err := time.ParseDuration(spec["timeout"].(string))
+	if err != nil {
+		return fmt.Errorf("Error parsing wait_for_ssh timeout: %s", err)
+	}
+
+	hostKey, err := helper.WaitForSSH(ctx, net.JoinHostPort(address, strconv.Itoa(spec["port"].(int))),
+		spec["host_key_fingerprint"].(string), timeout)
+	if err != nil {
+		return err
+	}
+
+	d.Set("ssh_host_key", strings.TrimSpace(string(ssh.MarshalAuthorizedKey(hostKey))))
+
+	return nil
+}
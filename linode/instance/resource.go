@@ -26,6 +26,7 @@ func Resource() *schema.Resource {
 		CreateContext: createResource,
 		UpdateContext: updateResource,
 		DeleteContext: deleteResource,
+		CustomizeDiff: diffResource,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -87,11 +88,12 @@ func readResource(ctx context.Context, d *schema.ResourceData, meta interface{})
 
 	d.Set("label", instance.Label)
 	d.Set("status", instance.Status)
+	d.Set("booted", isInstanceBooted(instance))
 	d.Set("type", instance.Type)
 	d.Set("region", instance.Region)
 	d.Set("watchdog_enabled", instance.WatchdogEnabled)
 	d.Set("group", instance.Group)
-	d.Set("tags", instance.Tags)
+	helper.SetTags(d, meta, instance.Tags)
 
 	flatSpecs := flattenInstanceSpecs(*instance)
 	flatAlerts := flattenInstanceAlerts(*instance)
@@ -123,6 +125,18 @@ func readResource(ctx context.Context, d *schema.ResourceData, meta interface{})
 
 	configs := flattenInstanceConfigs(instanceConfigs, diskLabelIDMap)
 
+	// A pending reboot is applied once the instance is booted after its configs last changed
+	if d.Get("pending_reboot").(bool) {
+		applied, err := isPendingRebootApplied(ctx, &client, instance.ID, instanceConfigs)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if applied {
+			d.Set("pending_reboot", false)
+		}
+	}
+
 	d.Set("config", configs)
 	if len(instanceConfigs) == 1 {
 		defaultConfig := instanceConfigs[0]
@@ -146,6 +160,8 @@ func readResource(ctx context.Context, d *schema.ResourceData, meta interface{})
 func createResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*helper.ProviderMeta).Client
 
+	_, cloneOk := d.GetOk("clone_from")
+
 	bootConfig := 0
 	createOpts := linodego.InstanceCreateOptions{
 		Region:         d.Get("region").(string),
@@ -154,12 +170,7 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		Group:          d.Get("group").(string),
 		BackupsEnabled: d.Get("backups_enabled").(bool),
 		PrivateIP:      d.Get("private_ip").(bool),
-	}
-
-	if tagsRaw, tagsOk := d.GetOk("tags"); tagsOk {
-		for _, tag := range tagsRaw.(*schema.Set).List() {
-			createOpts.Tags = append(createOpts.Tags, tag.(string))
-		}
+		Tags:           helper.ExpandTags(d, meta),
 	}
 
 	if interfaces, interfacesOk := d.GetOk("interface"); interfacesOk {
@@ -174,9 +185,10 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 
 	_, disksOk := d.GetOk("disk")
 	_, configsOk := d.GetOk("config")
+	booted := getDeclaredBooted(d)
 
 	// If we don't have disks and we don't have configs, use the single API call approach
-	if !disksOk && !configsOk {
+	if !disksOk && !configsOk && !cloneOk {
 		for _, key := range d.Get("authorized_keys").([]interface{}) {
 			createOpts.AuthorizedKeys = append(createOpts.AuthorizedKeys, key.(string))
 		}
@@ -194,6 +206,16 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		createOpts.Image = d.Get("image").(string)
 		createOpts.Booted = &boolTrue
 		createOpts.BackupID = d.Get("backup_id").(int)
+
+		if booted != nil {
+			if *booted && createOpts.Image == "" && createOpts.BackupID == 0 {
+				return diag.Errorf("Error creating a Linode Instance: booted requires an image, a backup_id, " +
+					"or explicit disks and configs")
+			}
+
+			createOpts.Booted = booted
+		}
+
 		if swapSize := d.Get("swap_size").(int); swapSize > 0 {
 			createOpts.SwapSize = &swapSize
 		}
@@ -214,13 +236,26 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		createOpts.Booted = &boolFalse // necessary to prepare disks and configs
 	}
 
-	instance, err := client.CreateInstance(ctx, createOpts)
+	var instance *linodego.Instance
+	var err error
+
+	if cloneOk {
+		instance, err = cloneInstance(ctx, d, &client, createOpts)
+	} else {
+		instance, err = client.CreateInstance(ctx, createOpts)
+	}
 	if err != nil {
 		return diag.Errorf("Error creating a Linode Instance: %s", err)
 	}
 
 	d.SetId(fmt.Sprintf("%d", instance.ID))
 
+	if cloneOk {
+		if err := waitForInstanceClone(ctx, d, &client, instance.ID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	var ips []string
 	for _, ip := range instance.IPv4 {
 		ips = append(ips, ip.String())
@@ -240,6 +275,12 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 	updateOpts := linodego.InstanceUpdateOptions{}
 	doUpdate := false
 
+	// Tags can not be set when cloning an instance
+	if cloneOk && len(createOpts.Tags) > 0 {
+		doUpdate = true
+		updateOpts.Tags = &createOpts.Tags
+	}
+
 	watchdogEnabled := d.Get("watchdog_enabled").(bool)
 	if !watchdogEnabled {
 		doUpdate = true
@@ -272,8 +313,8 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 	var configIDLabelMap map[string]int
 
 	if disksOk {
-		_, err = client.WaitForEventFinished(ctx, instance.ID, linodego.EntityLinode, linodego.ActionLinodeCreate,
-			*instance.Created, getDeadlineSeconds(ctx, d))
+		_, err = meta.(*helper.ProviderMeta).Events.WaitForEventFinished(ctx, instance.ID, linodego.EntityLinode,
+			linodego.ActionLinodeCreate, *instance.Created, getDeadlineSeconds(ctx, d))
 		if err != nil {
 			return diag.Errorf("Error waiting for Instance to finish creating: %s", err)
 		}
@@ -284,7 +325,7 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		for _, diskSpec := range diskSpecs {
 			diskSpec := diskSpec.(map[string]interface{})
 
-			instanceDisk, err := createInstanceDisk(ctx, client, *instance, diskSpec, d)
+			instanceDisk, err := createInstanceDisk(ctx, meta.(*helper.ProviderMeta), *instance, diskSpec, d)
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -312,14 +353,27 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 	}
 
 	targetStatus := linodego.InstanceRunning
+	canBoot := disksOk && configsOk
+
+	if cloneOk {
+		configs, err := client.ListInstanceConfigs(ctx, instance.ID, nil)
+		if err != nil {
+			return diag.Errorf("Error getting the configs for Linode instance %d: %s", instance.ID, err)
+		}
+		canBoot = len(configs) > 0
+
+		if booted != nil && *booted && !canBoot {
+			return diag.Errorf("Error booting Linode instance %d: the cloned instance has no configs", instance.ID)
+		}
+	}
 
 	if createOpts.Booted == nil || !*createOpts.Booted {
-		if disksOk && configsOk {
+		if canBoot && (booted == nil || *booted) {
 			if err = client.BootInstance(ctx, instance.ID, bootConfig); err != nil {
 				return diag.Errorf("Error booting Linode instance %d: %s", instance.ID, err)
 			}
 
-			if _, err = client.WaitForEventFinished(
+			if _, err = meta.(*helper.ProviderMeta).Events.WaitForEventFinished(
 				ctx, instance.ID, linodego.EntityLinode, linodego.ActionLinodeBoot,
 				*instance.Created, getDeadlineSeconds(ctx, d),
 			); err != nil {
@@ -331,7 +385,7 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 	}
 
 	// If the instance has implicit disks and config with no specified image it will not boot.
-	if !(disksOk && configsOk) && len(createOpts.Image) < 1 {
+	if !canBoot && len(createOpts.Image) < 1 {
 		targetStatus = linodego.InstanceOffline
 	}
 
@@ -341,6 +395,22 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		}
 	}
 
+	if _, ok := d.GetOk("wait_for_ssh"); ok {
+		address := d.Get("ip_address").(string)
+
+		switch {
+		case targetStatus != linodego.InstanceRunning:
+			log.Printf("[WARN] Not waiting for SSH on Linode instance %d because it is not booted", instance.ID)
+		case address == "":
+			return diag.Errorf("Error waiting for SSH on Linode instance %d: the instance has no public IPv4 address",
+				instance.ID)
+		default:
+			if err := waitForInstanceSSH(ctx, d, address); err != nil {
+				return diag.Errorf("Error waiting for SSH on Linode instance %d: %s", instance.ID, err)
+			}
+		}
+	}
+
 	return readResource(ctx, d, meta)
 }
 
@@ -358,14 +428,14 @@ func findDiskByFS(disks []linodego.InstanceDisk, fs linodego.DiskFilesystem) *li
 //
 // returns bool describing whether the linode needs to be restarted.
 func adjustSwapSizeIfNeeded(
-	ctx context.Context, d *schema.ResourceData, client *linodego.Client, instance *linodego.Instance) (bool, error) {
+	ctx context.Context, d *schema.ResourceData, meta *helper.ProviderMeta, instance *linodego.Instance) (bool, error) {
 	if !d.HasChange("swap_size") {
 		return false, nil
 	}
 
 	// If the swap_size attribute is set, there are two default disks attached to the instance (the main disk of type ext4
 	// and a swap disk), as custom disk configuration via "disk" nested attributes conflicts with the swap_size.
-	bootDisk, swapDisk, err := getInstanceDefaultDisks(ctx, instance.ID, client)
+	bootDisk, swapDisk, err := getInstanceDefaultDisks(ctx, instance.ID, &meta.Client)
 	if err != nil {
 		return false, err
 	}
@@ -395,7 +465,7 @@ func adjustSwapSizeIfNeeded(
 	}
 
 	for _, resizeOp := range toResize {
-		if err := changeInstanceDiskSize(ctx, client, *instance, *resizeOp.disk, resizeOp.size, d); err != nil {
+		if err := changeInstanceDiskSize(ctx, meta, *instance, *resizeOp.disk, resizeOp.size, d); err != nil {
 			return true, err
 		}
 	}
@@ -409,6 +479,9 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		return diag.Errorf("Error parsing Linode Instance ID %s as int: %s", d.Id(), err)
 	}
 
+	// Migrations, resizes and reboots must not interleave with the disks and configs of the instance
+	defer helper.LockEntity("linode", int(id))()
+
 	instance, err := client.GetInstance(ctx, int(id))
 	if err != nil {
 		return diag.Errorf("Error fetching data about the current linode: %s", err)
@@ -425,11 +498,8 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		updateOpts.Group = d.Get("group").(string)
 		simpleUpdate = true
 	}
-	if d.HasChange("tags") {
-		var tags []string
-		for _, tag := range d.Get("tags").(*schema.Set).List() {
-			tags = append(tags, tag.(string))
-		}
+	if d.HasChanges("tags", "tags_all") {
+		tags := helper.ExpandTags(d, meta)
 		updateOpts.Tags = &tags
 		simpleUpdate = true
 	}
@@ -469,6 +539,21 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		}
 	}
 
+	if d.HasChange("region") {
+		if instance, err = migrateInstance(ctx, d, meta.(*helper.ProviderMeta), instance, d.Get("region").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// A rebuild replaces all disks and configs, so the disk and config specs in state no longer apply
+	rebuilt := d.Get("rebuild_on_change").(bool) && d.HasChanges(rebuildKeys...)
+
+	if rebuilt {
+		if instance, err = rebuildInstance(ctx, d, meta.(*helper.ProviderMeta), instance.ID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	rebootInstance := false
 
 	if d.HasChange("private_ip") {
@@ -493,13 +578,15 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 
 	if upsized {
 		// The linode was upsized; apply before disk changes to allocate more disk
-		if instance, err = applyInstanceTypeChange(ctx, d, &client, instance, newSpec); err != nil {
+		if instance, err = applyInstanceTypeChange(ctx, d, meta.(*helper.ProviderMeta), instance, newSpec); err != nil {
 			return diag.Errorf("failed to change instance type: %s", err)
 		}
 		rebootInstance = true
 	}
 
-	if didChange, err := applyInstanceDiskSpec(ctx, d, &client, instance, newSpec); err == nil && didChange {
+	if rebuilt {
+		log.Printf("[INFO] Instance [%d] was rebuilt; skipping disk spec changes\n", instance.ID)
+	} else if didChange, err := applyInstanceDiskSpec(ctx, d, meta.(*helper.ProviderMeta), instance, newSpec); err == nil && didChange {
 		rebootInstance = true
 	} else if err != nil && newSpec.Disk < oldSpec.Disk && !d.HasChange("disk") {
 		// Linode was downsized but the pre-existing disk config does not fit new instance spec
@@ -511,32 +598,55 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 
 	if oldSpec.ID != newSpec.ID && !upsized {
 		// linode was downsized or changed to a type with the same disk allocation
-		if instance, err = applyInstanceTypeChange(ctx, d, &client, instance, newSpec); err != nil {
+		if instance, err = applyInstanceTypeChange(ctx, d, meta.(*helper.ProviderMeta), instance, newSpec); err != nil {
 			return diag.Errorf("failed to change instance type: %s", err)
 		}
 	}
 
-	if didChange, err := adjustSwapSizeIfNeeded(ctx, d, &client, instance); err != nil {
+	if didChange, err := adjustSwapSizeIfNeeded(ctx, d, meta.(*helper.ProviderMeta), instance); err != nil {
 		return diag.FromErr(err)
 	} else if didChange {
 		rebootInstance = true
 	}
 
-	diskIDLabelMap, err := getInstanceDiskLabelIDMap(ctx, client, d, instance.ID)
-	if err != nil {
-		return diag.Errorf("failed to get disk label to ID mappings")
-	}
+	var diskIDLabelMap, updatedConfigMap map[string]int
+	var updatedConfigs []*linodego.InstanceConfig
 
 	bootConfig := 0
 	bootConfigLabel := d.Get("boot_config_label").(string)
 
-	tfConfigsOld, tfConfigsNew := d.GetChange("config")
-	didChangeConfig, updatedConfigMap, updatedConfigs, err := updateInstanceConfigs(
-		ctx, client, d, *instance, tfConfigsOld, tfConfigsNew, diskIDLabelMap, bootConfigLabel)
-	if err != nil {
-		return diag.FromErr(err)
+	if !rebuilt {
+		if diskIDLabelMap, err = getInstanceDiskLabelIDMap(ctx, client, d, instance.ID); err != nil {
+			return diag.Errorf("failed to get disk label to ID mappings")
+		}
+	}
+
+	// Configs that are not declared may be implicit or managed by linode_instance_config resources,
+	// so they are only looked up to determine the boot config.
+	if rebuilt || d.GetRawConfig().GetAttr("config").LengthInt() == 0 {
+		if updatedConfigs, err = getInstanceConfigs(ctx, &client, instance.ID); err != nil {
+			return diag.FromErr(err)
+		}
+
+		updatedConfigMap = make(map[string]int, len(updatedConfigs))
+		for _, config := range updatedConfigs {
+			updatedConfigMap[config.Label] = config.ID
+		}
+	} else {
+		tfConfigsOld, tfConfigsNew := d.GetChange("config")
+		var didChangeConfig bool
+		didChangeConfig, updatedConfigMap, updatedConfigs, err = updateInstanceConfigs(
+			ctx, client, d, *instance, tfConfigsOld, tfConfigsNew, diskIDLabelMap, bootConfigLabel)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		rebootInstance = rebootInstance || didChangeConfig
+	}
+
+	// Configs created by a rebuild are not known to state; boot from the first one
+	if rebuilt {
+		bootConfigLabel = ""
 	}
-	rebootInstance = rebootInstance || didChangeConfig
 
 	if bootConfigLabel != "" {
 		if foundConfig, found := updatedConfigMap[bootConfigLabel]; found {
@@ -548,9 +658,8 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		bootConfig = updatedConfigs[0].ID
 	}
 
-	if d.HasChange("interface") {
-		interfaces := d.Get("interface").([]interface{})
-
+	// Interfaces must be reapplied to the config created by a rebuild
+	if interfaces := d.Get("interface").([]interface{}); d.HasChange("interface") || (rebuilt && len(interfaces) > 0) {
 		expandedInterfaces := make([]linodego.InstanceConfigInterface, len(interfaces))
 
 		for i, ni := range interfaces {
@@ -565,6 +674,32 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		rebootInstance = true
 	}
 
+	// Changes that require a reboot are left pending when the reboot policy does not allow rebooting
+	oldPendingReboot, _ := d.GetChange("pending_reboot")
+	pendingReboot := false
+
+	switch d.Get("reboot_policy").(string) {
+	case rebootPolicyNever:
+		pendingReboot = rebootInstance || oldPendingReboot.(bool)
+		rebootInstance = false
+	case rebootPolicyAlways:
+		rebootInstance = rebootInstance || instance.Status == linodego.InstanceRunning
+	default:
+		rebootInstance = rebootInstance || oldPendingReboot.(bool)
+	}
+
+	booted := getDeclaredBooted(d)
+
+	// An instance that should be kept offline must not be booted by a reboot
+	if booted != nil && !*booted {
+		rebootInstance = false
+	}
+
+	// A rebuilt instance is left offline until all changes are applied and is booted by default
+	if rebuilt && booted == nil {
+		booted = &boolTrue
+	}
+
 	if rebootInstance && len(diskIDLabelMap) > 0 && len(updatedConfigMap) > 0 && bootConfig > 0 {
 		err = client.RebootInstance(ctx, instance.ID, bootConfig)
 
@@ -573,8 +708,8 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		if err != nil {
 			return diag.Errorf("Error rebooting Instance %d: %s", instance.ID, err)
 		}
-		_, err = client.WaitForEventFinished(ctx, id, linodego.EntityLinode, linodego.ActionLinodeReboot,
-			*instance.Created, getDeadlineSeconds(ctx, d))
+		_, err = meta.(*helper.ProviderMeta).Events.WaitForEventFinished(ctx, int(id), linodego.EntityLinode,
+			linodego.ActionLinodeReboot, *instance.Created, getDeadlineSeconds(ctx, d))
 		if err != nil {
 			return diag.Errorf("Error waiting for Instance %d to finish rebooting: %s", instance.ID, err)
 		}
@@ -585,9 +720,82 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		}
 	}
 
+	if booted != nil {
+		if instance, err = client.GetInstance(ctx, instance.ID); err != nil {
+			return diag.Errorf("Error fetching data about the current linode: %s", err)
+		}
+
+		if *booted && !isInstanceBooted(instance) {
+			if err := bootInstance(ctx, meta.(*helper.ProviderMeta), instance.ID, bootConfig, getDeadlineSeconds(ctx, d)); err != nil {
+				return diag.FromErr(err)
+			}
+		} else if !*booted && isInstanceBooted(instance) {
+			if err := shutdownInstance(ctx, meta.(*helper.ProviderMeta), instance.ID, getDeadlineSeconds(ctx, d)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	d.Set("pending_reboot", pendingReboot)
+
 	return readResource(ctx, d, meta)
 }
 
+func diffResource(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := helper.CustomizeDiffTagsAll(ctx, d, meta); err != nil {
+		return err
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange("region") {
+		if !d.Get("migrate_on_region_change").(bool) {
+			if err := d.ForceNew("region"); err != nil {
+				return err
+			}
+		} else {
+			// A migrated instance is assigned new addresses in the target region
+			for _, key := range []string{"ip_address", "private_ip_address", "ipv4", "ipv6"} {
+				if err := d.SetNewComputed(key); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if d.Get("reboot_policy").(string) == rebootPolicyNever {
+		if err := validateNoShutdownRequired(d); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range append([]string{"reboot_policy"}, rebootRequiredKeys...) {
+		if d.HasChange(key) {
+			if err := d.SetNewComputed("pending_reboot"); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	// Deployment changes can only be applied in-place through a rebuild of an image-based instance
+	rebuild := d.Get("rebuild_on_change").(bool) && d.Get("image").(string) != ""
+
+	for _, key := range rebuildKeys {
+		if !d.HasChange(key) || rebuild {
+			continue
+		}
+
+		if err := d.ForceNew(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func deleteResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*helper.ProviderMeta).Client
 	id, err := strconv.ParseInt(d.Id(), 10, 64)
@@ -602,8 +810,8 @@ func deleteResource(ctx context.Context, d *schema.ResourceData, meta interface{
 
 	if !meta.(*helper.ProviderMeta).Config.SkipInstanceDeletePoll {
 		// Wait for full deletion to assure volumes are detached
-		if _, err = client.WaitForEventFinished(ctx, int(id), linodego.EntityLinode, linodego.ActionLinodeDelete,
-			minDelete, getDeadlineSeconds(ctx, d)); err != nil {
+		if _, err = meta.(*helper.ProviderMeta).Events.WaitForEventFinished(ctx, int(id), linodego.EntityLinode,
+			linodego.ActionLinodeDelete, minDelete, getDeadlineSeconds(ctx, d)); err != nil {
 			return diag.Errorf("failed to wait for instance %d to be deleted: %s", id, err)
 		}
 	}
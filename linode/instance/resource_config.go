@@ -0,0 +1,247 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/linode/linodego"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+)
+
+func ConfigResource() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceConfigSchema(),
+		ReadContext:   readConfigResource,
+		CreateContext: createConfigResource,
+		UpdateContext: updateConfigResource,
+		DeleteContext: deleteConfigResource,
+		Importer: &schema.ResourceImporter{
+			StateContext: importConfigResource,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(LinodeInstanceCreateTimeout),
+			Update: schema.DefaultTimeout(LinodeInstanceUpdateTimeout),
+			Delete: schema.DefaultTimeout(LinodeInstanceDeleteTimeout),
+		},
+	}
+}
+
+func importConfigResource(
+	ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := importInstanceChildID(d); err != nil {
+		return nil, fmt.Errorf("invalid linode_instance_config ID: %s", err)
+	}
+
+	if diags := readConfigResource(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("unable to import %v as linode_instance_config: %s", d.Id(), diags[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func readConfigResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*helper.ProviderMeta).Client
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("Error parsing Linode Instance Config ID %s as int: %s", d.Id(), err)
+	}
+	linodeID := d.Get("linode_id").(int)
+
+	config, err := client.GetInstanceConfig(ctx, linodeID, id)
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			log.Printf("[WARN] removing Linode Instance Config ID %q from state because it no longer exists", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error finding the specified Linode Instance Config: %s", err)
+	}
+
+	instanceDisks, err := client.ListInstanceDisks(ctx, linodeID, nil)
+	if err != nil {
+		return diag.Errorf("Error getting the disks for the Linode instance %d: %s", linodeID, err)
+	}
+
+	diskLabelIDMap := make(map[int]string, len(instanceDisks))
+	for _, disk := range instanceDisks {
+		diskLabelIDMap[disk.ID] = disk.Label
+	}
+
+	for key, value := range flattenInstanceConfigs([]linodego.InstanceConfig{*config}, diskLabelIDMap)[0] {
+		d.Set(key, value)
+	}
+
+	instance, err := client.GetInstance(ctx, linodeID)
+	if err != nil {
+		return diag.Errorf("Error fetching data about Linode instance %d: %s", linodeID, err)
+	}
+
+	// The API does not expose which config an instance was booted into
+	if !isInstanceBooted(instance) {
+		d.Set("booted", false)
+	}
+
+	return nil
+}
+
+func createConfigResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*helper.ProviderMeta).Client
+	linodeID := d.Get("linode_id").(int)
+
+	defer helper.LockEntity("linode", linodeID)()
+
+	diskIDLabelMap, err := getInstanceDiskIDMap(ctx, client, linodeID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	configIDMap, err := createInstanceConfigsFromSet(ctx, client, linodeID,
+		[]interface{}{configSpecFromResourceData(d)}, diskIDLabelMap, makeVolumeDetacher(client, d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for configID := range configIDMap {
+		d.SetId(strconv.Itoa(configID))
+	}
+
+	if err := applyConfigBooted(ctx, d, meta, true); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return readConfigResource(ctx, d, meta)
+}
+
+func updateConfigResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*helper.ProviderMeta).Client
+	linodeID := d.Get("linode_id").(int)
+
+	defer helper.LockEntity("linode", linodeID)()
+
+	instance, err := client.GetInstance(ctx, linodeID)
+	if err != nil {
+		return diag.Errorf("Error fetching data about Linode instance %d: %s", linodeID, err)
+	}
+
+	diskIDLabelMap, err := getInstanceDiskIDMap(ctx, client, linodeID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	oldLabel, _ := d.GetChange("label")
+	oldInterfaces, _ := d.GetChange("interface")
+	oldSpec := map[string]interface{}{
+		"label":     oldLabel,
+		"interface": oldInterfaces,
+	}
+	label := d.Get("label").(string)
+
+	_, updatedConfigMap, _, err := updateInstanceConfigs(ctx, client, d, *instance,
+		[]interface{}{oldSpec}, []interface{}{configSpecFromResourceData(d)}, diskIDLabelMap, label)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Relabeled configs are recreated by updateInstanceConfigs
+	if configID, ok := updatedConfigMap[label]; ok {
+		d.SetId(strconv.Itoa(configID))
+	}
+
+	changed := false
+	for key := range instanceConfigSchema() {
+		if d.HasChange(key) {
+			changed = true
+			break
+		}
+	}
+
+	if err := applyConfigBooted(ctx, d, meta, changed || d.HasChange("booted")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return readConfigResource(ctx, d, meta)
+}
+
+func deleteConfigResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*helper.ProviderMeta).Client
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("Error parsing Linode Instance Config ID %s as int: %s", d.Id(), err)
+	}
+	linodeID := d.Get("linode_id").(int)
+
+	defer helper.LockEntity("linode", linodeID)()
+
+	if err := client.DeleteInstanceConfig(ctx, linodeID, id); err != nil {
+		if lerr, ok := err.(*linodego.Error); !ok || lerr.Code != 404 {
+			return diag.Errorf("Error deleting Linode Instance Config %d: %s", id, err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// configSpecFromResourceData returns a config spec in the form of a linode_instance config block.
+func configSpecFromResourceData(d *schema.ResourceData) map[string]interface{} {
+	spec := make(map[string]interface{})
+	for key := range instanceConfigSchema() {
+		spec[key] = d.Get(key)
+	}
+
+	return spec
+}
+
+// getInstanceDiskIDMap returns a map of all disk labels on an instance to their corresponding IDs.
+func getInstanceDiskIDMap(ctx context.Context, client linodego.Client, instanceID int) (map[string]int, error) {
+	disks, err := getInstanceDisks(ctx, client, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	labelIDMap := make(map[string]int, len(disks))
+	for label, disk := range disks {
+		labelIDMap[label] = disk.ID
+	}
+
+	return labelIDMap, nil
+}
+
+// applyConfigBooted boots, reboots or shuts down the instance of a linode_instance_config according
+// to its declared booted state.
+func applyConfigBooted(ctx context.Context, d *schema.ResourceData, meta interface{}, changed bool) error {
+	booted := getDeclaredBooted(d)
+	if booted == nil || !changed {
+		return nil
+	}
+
+	client := meta.(*helper.ProviderMeta).Client
+	linodeID := d.Get("linode_id").(int)
+
+	configID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing Linode Instance Config ID %s as int: %s", d.Id(), err)
+	}
+
+	instance, err := client.GetInstance(ctx, linodeID)
+	if err != nil {
+		return fmt.Errorf("Error fetching data about Linode instance %d: %s", linodeID, err)
+	}
+
+	switch {
+	case *booted && instance.Status == linodego.InstanceRunning:
+		if diags := helper.RebootInstance(ctx, d, linodeID, meta, configID); diags.HasError() {
+			return fmt.Errorf("%s", diags[0].Summary)
+		}
+	case *booted:
+		return bootInstance(ctx, meta.(*helper.ProviderMeta), linodeID, configID, getDeadlineSeconds(ctx, d))
+	case isInstanceBooted(instance):
+		return shutdownInstance(ctx, meta.(*helper.ProviderMeta), linodeID, getDeadlineSeconds(ctx, d))
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package instance_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/linode/linodego"
+	"github.com/linode/terraform-provider-linode/linode/acceptance"
+	"github.com/linode/terraform-provider-linode/linode/instance/tmpl"
+)
+
+func TestAccResourceInstanceConfig_basic(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_instance_config.foobar"
+	var instance linodego.Instance
+	instanceName := acctest.RandomWithPrefix("tf_test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.PreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.ConfigStandalone(t, instanceName, "initial"),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CheckInstanceExists("linode_instance.foobar", &instance),
+					resource.TestCheckResourceAttr(resName, "label", instanceName),
+					resource.TestCheckResourceAttr(resName, "kernel", "linode/latest-64bit"),
+					resource.TestCheckResourceAttr(resName, "comments", "initial"),
+					resource.TestCheckResourceAttr(resName, "booted", "true"),
+					resource.TestCheckResourceAttrPair(resName, "devices.0.sda.0.disk_id", "linode_instance_disk.boot", "id"),
+					resource.TestCheckResourceAttrPair(resName, "devices.0.sdb.0.disk_id", "linode_instance_disk.swap", "id"),
+					resource.TestCheckResourceAttr("linode_instance.foobar", "status", "running"),
+				),
+			},
+			{
+				Config: tmpl.ConfigStandalone(t, instanceName, "updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "comments", "updated"),
+					resource.TestCheckResourceAttr(resName, "booted", "true"),
+				),
+			},
+			{
+				ResourceName:            resName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       importInstanceChildID(resName),
+				ImportStateVerifyIgnore: []string{"booted"},
+			},
+		},
+	})
+}
@@ -0,0 +1,222 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/linode/linodego"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+)
+
+func DiskResource() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceDiskSchema,
+		ReadContext:   readDiskResource,
+		CreateContext: createDiskResource,
+		UpdateContext: updateDiskResource,
+		DeleteContext: deleteDiskResource,
+		Importer: &schema.ResourceImporter{
+			StateContext: importDiskResource,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(LinodeInstanceCreateTimeout),
+			Update: schema.DefaultTimeout(LinodeInstanceUpdateTimeout),
+			Delete: schema.DefaultTimeout(LinodeInstanceDeleteTimeout),
+		},
+	}
+}
+
+func importDiskResource(
+	ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := importInstanceChildID(d); err != nil {
+		return nil, fmt.Errorf("invalid linode_instance_disk ID: %s", err)
+	}
+
+	if diags := readDiskResource(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("unable to import %v as linode_instance_disk: %s", d.Id(), diags[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func readDiskResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*helper.ProviderMeta).Client
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("Error parsing Linode Instance Disk ID %s as int: %s", d.Id(), err)
+	}
+	linodeID := d.Get("linode_id").(int)
+
+	disk, err := client.GetInstanceDisk(ctx, linodeID, id)
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			log.Printf("[WARN] removing Linode Instance Disk ID %q from state because it no longer exists", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error finding the specified Linode Instance Disk: %s", err)
+	}
+
+	d.Set("label", disk.Label)
+	d.Set("size", disk.Size)
+	d.Set("filesystem", string(disk.Filesystem))
+	d.Set("status", string(disk.Status))
+
+	if disk.Created != nil {
+		d.Set("created", disk.Created.Format(time.RFC3339))
+	}
+
+	if disk.Updated != nil {
+		d.Set("updated", disk.Updated.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func createDiskResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*helper.ProviderMeta).Client
+	linodeID := d.Get("linode_id").(int)
+
+	defer helper.LockEntity("linode", linodeID)()
+
+	instance, err := client.GetInstance(ctx, linodeID)
+	if err != nil {
+		return diag.Errorf("Error fetching data about Linode instance %d: %s", linodeID, err)
+	}
+
+	spec := diskSpec{
+		"label":      d.Get("label").(string),
+		"size":       d.Get("size").(int),
+		"filesystem": d.Get("filesystem").(string),
+	}
+
+	// createInstanceDisk only deploys images when an image is present in the spec
+	if image, ok := d.GetOk("image"); ok {
+		spec["image"] = image
+		spec["root_pass"] = d.Get("root_pass")
+		spec["authorized_keys"] = d.Get("authorized_keys")
+		spec["authorized_users"] = d.Get("authorized_users")
+		spec["stackscript_id"] = d.Get("stackscript_id")
+		spec["stackscript_data"] = d.Get("stackscript_data")
+	}
+
+	disk, err := createInstanceDisk(ctx, meta.(*helper.ProviderMeta), *instance, spec, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(disk.ID))
+
+	if _, err := client.WaitForInstanceDiskStatus(
+		ctx, linodeID, disk.ID, linodego.DiskReady, getDeadlineSeconds(ctx, d)); err != nil {
+		return diag.Errorf("Error waiting for Linode instance %d disk %d to be ready: %s", linodeID, disk.ID, err)
+	}
+
+	return readDiskResource(ctx, d, meta)
+}
+
+func updateDiskResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*helper.ProviderMeta).Client
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("Error parsing Linode Instance Disk ID %s as int: %s", d.Id(), err)
+	}
+	linodeID := d.Get("linode_id").(int)
+
+	defer helper.LockEntity("linode", linodeID)()
+
+	if d.HasChange("label") {
+		if _, err := client.UpdateInstanceDisk(ctx, linodeID, id, linodego.InstanceDiskUpdateOptions{
+			Label: d.Get("label").(string),
+		}); err != nil {
+			return diag.Errorf("Error updating Linode Instance Disk %d: %s", id, err)
+		}
+	}
+
+	if d.HasChange("size") {
+		instance, err := client.GetInstance(ctx, linodeID)
+		if err != nil {
+			return diag.Errorf("Error fetching data about Linode instance %d: %s", linodeID, err)
+		}
+
+		disk, err := client.GetInstanceDisk(ctx, linodeID, id)
+		if err != nil {
+			return diag.Errorf("Error finding the specified Linode Instance Disk: %s", err)
+		}
+
+		// Disks can only be resized while the instance is offline, so the instance is booted again afterwards
+		wasBooted := isInstanceBooted(instance)
+
+		if err := changeInstanceDiskSize(ctx, meta.(*helper.ProviderMeta), *instance, *disk, d.Get("size").(int), d); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if wasBooted {
+			if err := bootInstance(ctx, meta.(*helper.ProviderMeta), linodeID, 0, getDeadlineSeconds(ctx, d)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return readDiskResource(ctx, d, meta)
+}
+
+func deleteDiskResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*helper.ProviderMeta).Client
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("Error parsing Linode Instance Disk ID %s as int: %s", d.Id(), err)
+	}
+	linodeID := d.Get("linode_id").(int)
+
+	// Other disks and configs of the instance must not boot it while it is offline
+	defer helper.LockEntity("linode", linodeID)()
+
+	instance, err := client.GetInstance(ctx, linodeID)
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			return nil
+		}
+		return diag.Errorf("Error fetching data about Linode instance %d: %s", linodeID, err)
+	}
+
+	// Disks can only be deleted while the instance is offline, so the instance is booted again afterwards
+	wasBooted := isInstanceBooted(instance)
+
+	if wasBooted {
+		if err := shutdownInstance(ctx, meta.(*helper.ProviderMeta), linodeID, getDeadlineSeconds(ctx, d)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// The deletion event is matched against the local time, which may differ from the API's
+	deleteStart := time.Now().Add(-time.Minute)
+
+	if err := client.DeleteInstanceDisk(ctx, linodeID, id); err != nil {
+		return diag.Errorf("Error deleting Linode Instance Disk %d: %s", id, err)
+	}
+
+	if _, err := meta.(*helper.ProviderMeta).Events.WaitForFilteredEventFinished(ctx, helper.EventFilter{
+		EntityID:          linodeID,
+		EntityType:        linodego.EntityLinode,
+		SecondaryEntityID: id,
+		Actions:           []linodego.EventAction{linodego.ActionDiskDelete},
+		MinStart:          deleteStart,
+	}, getDeadlineSeconds(ctx, d)); err != nil {
+		return diag.Errorf("Error waiting for Linode Instance %d Disk %d to finish deleting: %s", linodeID, id, err)
+	}
+
+	if wasBooted {
+		if err := bootInstance(ctx, meta.(*helper.ProviderMeta), linodeID, 0, getDeadlineSeconds(ctx, d)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
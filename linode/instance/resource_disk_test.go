@@ -0,0 +1,65 @@
+package instance_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/linode/linodego"
+	"github.com/linode/terraform-provider-linode/linode/acceptance"
+	"github.com/linode/terraform-provider-linode/linode/instance/tmpl"
+)
+
+func TestAccResourceInstanceDisk_basic(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_instance_disk.foobar"
+	var instance linodego.Instance
+	instanceName := acctest.RandomWithPrefix("tf_test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.PreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.DiskStandalone(t, instanceName, 2048),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CheckInstanceExists("linode_instance.foobar", &instance),
+					resource.TestCheckResourceAttr(resName, "label", "boot"),
+					resource.TestCheckResourceAttr(resName, "size", "2048"),
+					resource.TestCheckResourceAttr(resName, "filesystem", "ext4"),
+					resource.TestCheckResourceAttr(resName, "status", "ready"),
+					resource.TestCheckResourceAttrPair(resName, "linode_id", "linode_instance.foobar", "id"),
+				),
+			},
+			{
+				Config: tmpl.DiskStandalone(t, instanceName, 4096),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "size", "4096"),
+				),
+			},
+			{
+				ResourceName:            resName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       importInstanceChildID(resName),
+				ImportStateVerifyIgnore: []string{"image", "root_pass"},
+			},
+		},
+	})
+}
+
+// importInstanceChildID returns the import ID of a resource that belongs to an instance.
+func importInstanceChildID(resName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resName)
+		}
+
+		return fmt.Sprintf("%s,%s", rs.Primary.Attributes["linode_id"], rs.Primary.ID), nil
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/linode/terraform-provider-linode/linode/acceptance"
 	"github.com/linode/terraform-provider-linode/linode/helper"
 	"github.com/linode/terraform-provider-linode/linode/instance/tmpl"
+	"github.com/linode/terraform-provider-linode/linode/mockapi"
 )
 
 func init() {
@@ -110,6 +111,214 @@ func TestAccResourceInstance_watchdogDisabled(t *testing.T) {
 	})
 }
 
+func TestAccResourceInstance_booted(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_instance.foobar"
+	var instance linodego.Instance
+	instanceName := acctest.RandomWithPrefix("tf_test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.PreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.Booted(t, instanceName, false),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CheckInstanceExists(resName, &instance),
+					resource.TestCheckResourceAttr(resName, "booted", "false"),
+					resource.TestCheckResourceAttr(resName, "status", "offline"),
+				),
+			},
+			{
+				Config: tmpl.Booted(t, instanceName, true),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CheckInstanceExists(resName, &instance),
+					resource.TestCheckResourceAttr(resName, "booted", "true"),
+					resource.TestCheckResourceAttr(resName, "status", "running"),
+				),
+			},
+			{
+				Config: tmpl.Booted(t, instanceName, false),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CheckInstanceExists(resName, &instance),
+					resource.TestCheckResourceAttr(resName, "booted", "false"),
+					resource.TestCheckResourceAttr(resName, "status", "offline"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceInstance_waitForSSH(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_instance.foobar"
+	var instance linodego.Instance
+	instanceName := acctest.RandomWithPrefix("tf_test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.PreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.WaitForSSH(t, instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CheckInstanceExists(resName, &instance),
+					resource.TestCheckResourceAttr(resName, "status", "running"),
+					resource.TestCheckResourceAttr(resName, "wait_for_ssh.0.port", "22"),
+					resource.TestMatchResourceAttr(resName, "ssh_host_key", regexp.MustCompile(`^ssh-`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceInstance_rebuildOnChange(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_instance.foobar"
+	var instance linodego.Instance
+	instanceName := acctest.RandomWithPrefix("tf_test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.PreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.Rebuild(t, instanceName, acceptance.TestImagePrevious),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CheckInstanceExists(resName, &instance),
+					resource.TestCheckResourceAttr(resName, "image", acceptance.TestImagePrevious),
+					resource.TestCheckResourceAttr(resName, "rebuild_on_change", "true"),
+				),
+			},
+			{
+				Config: tmpl.Rebuild(t, instanceName, acceptance.TestImageLatest),
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						rs := s.RootModule().Resources[resName]
+						if rs.Primary.ID != strconv.Itoa(instance.ID) {
+							return fmt.Errorf("expected instance %d to be rebuilt in-place; got %s", instance.ID, rs.Primary.ID)
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttr(resName, "image", acceptance.TestImageLatest),
+					resource.TestCheckResourceAttr(resName, "status", "running"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceInstance_migrateOnRegionChange(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_instance.foobar"
+	var instance linodego.Instance
+	instanceName := acctest.RandomWithPrefix("tf_test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.PreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.Migrate(t, instanceName, "us-east"),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CheckInstanceExists(resName, &instance),
+					resource.TestCheckResourceAttr(resName, "region", "us-east"),
+				),
+			},
+			{
+				Config: tmpl.Migrate(t, instanceName, "us-central"),
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						rs := s.RootModule().Resources[resName]
+						if rs.Primary.ID != strconv.Itoa(instance.ID) {
+							return fmt.Errorf("expected instance %d to be migrated in-place; got %s", instance.ID, rs.Primary.ID)
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttr(resName, "region", "us-central"),
+					resource.TestCheckResourceAttr(resName, "status", "running"),
+					resource.TestCheckResourceAttrSet(resName, "ip_address"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceInstance_cloneFrom(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_instance.foobar"
+	var instance linodego.Instance
+	instanceName := acctest.RandomWithPrefix("tf_test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.PreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.Clone(t, instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CheckInstanceExists(resName, &instance),
+					resource.TestCheckResourceAttr(resName, "label", instanceName),
+					resource.TestCheckResourceAttr(resName, "status", "running"),
+					resource.TestCheckResourceAttrPair(resName, "disk.#", "linode_instance.source", "disk.#"),
+					resource.TestCheckResourceAttrPair(resName, "config.#", "linode_instance.source", "config.#"),
+					resource.TestCheckResourceAttrPair(resName, "disk.0.size", "linode_instance.source", "disk.0.size"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceInstance_rebootPolicy(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_instance.foobar"
+	var instance linodego.Instance
+	instanceName := acctest.RandomWithPrefix("tf_test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.PreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.RebootPolicy(t, instanceName, "never", false),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CheckInstanceExists(resName, &instance),
+					resource.TestCheckResourceAttr(resName, "reboot_policy", "never"),
+					resource.TestCheckResourceAttr(resName, "pending_reboot", "false"),
+				),
+			},
+			{
+				Config: tmpl.RebootPolicy(t, instanceName, "never", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "private_ip", "true"),
+					resource.TestCheckResourceAttr(resName, "pending_reboot", "true"),
+					resource.TestCheckResourceAttr(resName, "status", "running"),
+				),
+			},
+			{
+				Config: tmpl.RebootPolicy(t, instanceName, "on_change", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "reboot_policy", "on_change"),
+					resource.TestCheckResourceAttr(resName, "pending_reboot", "false"),
+					resource.TestCheckResourceAttr(resName, "status", "running"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceInstance_authorizedUsers(t *testing.T) {
 	t.Parallel()
 
@@ -1936,3 +2145,35 @@ func checkComputeInstanceDisk(instance *linodego.Instance, label string, size in
 		return fmt.Errorf("Disk not found: %s", label)
 	}
 }
+
+func TestUnitResourceInstance_basic(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+
+	resName := "linode_instance.foobar"
+	instanceName := acctest.RandomWithPrefix("tf_test")
+	publicKeyMaterial, _, err := acctest.RandSSHKeyPair("linode@ssh-acceptance-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { mockapi.PreCheck(t) },
+		ProviderFactories: mockapi.ProviderFactories,
+		CheckDestroy:      server.CheckDestroy("linode_instance", "linode/instances"),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + tmpl.Basic(t, instanceName, publicKeyMaterial),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "label", instanceName),
+					resource.TestCheckResourceAttr(resName, "type", "g6-nanode-1"),
+					resource.TestCheckResourceAttr(resName, "image", acceptance.TestImageLatest),
+					resource.TestCheckResourceAttr(resName, "region", "us-east"),
+					resource.TestCheckResourceAttr(resName, "status", "running"),
+					resource.TestCheckResourceAttr(resName, "swap_size", "256"),
+					resource.TestCheckResourceAttrSet(resName, "ip_address"),
+				),
+			},
+		},
+	})
+}
@@ -3,6 +3,7 @@ package instance
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/linode/terraform-provider-linode/linode/helper"
 )
 
 const deviceDescription = "Device can be either a Disk or Volume identified by disk_id or " +
@@ -61,6 +62,179 @@ func resourceConfigInterface() *schema.Resource {
 	}
 }
 
+// instanceConfigSchema returns the schema of an Instance Config, shared by the config block of
+// linode_instance and the linode_instance_config resource.
+func instanceConfigSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"label": {
+			Type:         schema.TypeString,
+			Description:  "The Config's label for display purposes.  Also used by `boot_config_label`.",
+			Required:     true,
+			ValidateFunc: validation.StringLenBetween(1, 48),
+		},
+		"helpers": {
+			Type:        schema.TypeList,
+			Description: "Helpers enabled when booting to this Linode Config.",
+			MaxItems:    1,
+			Optional:    true,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"updatedb_disabled": {
+						Type:        schema.TypeBool,
+						Description: "Disables updatedb cron job to avoid disk thrashing.",
+						Optional:    true,
+						Default:     true,
+					},
+					"distro": {
+						Type:        schema.TypeBool,
+						Description: "Controls the behavior of the Linode Config's Distribution Helper setting.",
+						Optional:    true,
+						Default:     true,
+					},
+					"modules_dep": {
+						Type:        schema.TypeBool,
+						Description: "Creates a modules dependency file for the Kernel you run.",
+						Optional:    true,
+						Default:     true,
+					},
+					"network": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Description: "Controls the behavior of the Linode Config's Network Helper setting, " +
+							"used to automatically configure additional IP addresses assigned to this instance.",
+						Default: true,
+					},
+					"devtmpfs_automount": {
+						Type:        schema.TypeBool,
+						Description: "Populates the /dev directory early during boot without udev. Defaults to false.",
+						Optional:    true,
+						Default:     false,
+					},
+				},
+			},
+		},
+		"devices": {
+			Type: schema.TypeList,
+			Description: "Device sda-sdh can be either a Disk or Volume identified by disk_label or volume_id. " +
+				"Only one type per slot allowed.",
+			MaxItems: 1,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"sda": {
+						Type:        schema.TypeList,
+						Description: deviceDescription,
+						MaxItems:    1,
+						Computed:    true,
+						Optional:    true,
+						Elem:        resourceDeviceDisk(),
+					},
+					"sdb": {
+						Type:        schema.TypeList,
+						Description: deviceDescription,
+						MaxItems:    1,
+						Optional:    true,
+						Computed:    true,
+						Elem:        resourceDeviceDisk(),
+					},
+					"sdc": {
+						Type:        schema.TypeList,
+						Description: deviceDescription,
+						MaxItems:    1,
+						Optional:    true,
+						Computed:    true,
+						Elem:        resourceDeviceDisk(),
+					},
+					"sdd": {
+						Type:        schema.TypeList,
+						Description: deviceDescription,
+						MaxItems:    1,
+						Optional:    true,
+						Computed:    true,
+						Elem:        resourceDeviceDisk(),
+					},
+					"sde": {
+						Type:        schema.TypeList,
+						Description: deviceDescription,
+						MaxItems:    1,
+						Optional:    true,
+						Computed:    true,
+						Elem:        resourceDeviceDisk(),
+					},
+					"sdf": {
+						Type:        schema.TypeList,
+						Description: deviceDescription,
+						MaxItems:    1,
+						Optional:    true,
+						Computed:    true,
+						Elem:        resourceDeviceDisk(),
+					},
+					"sdg": {
+						Type:        schema.TypeList,
+						Description: deviceDescription,
+						MaxItems:    1,
+						Optional:    true,
+						Computed:    true,
+						Elem:        resourceDeviceDisk(),
+					},
+					"sdh": {
+						Type:        schema.TypeList,
+						Description: deviceDescription,
+						MaxItems:    1,
+						Optional:    true,
+						Computed:    true,
+						Elem:        resourceDeviceDisk(),
+					},
+				},
+			},
+		},
+		"interface": {
+			Type:        schema.TypeList,
+			Description: "An array of Network Interfaces for this Linode’s Configuration Profile.",
+			Optional:    true,
+			Elem:        resourceConfigInterface(),
+		},
+		"kernel": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Description: "A Kernel ID to boot a Linode with. Default is based on image choice. " +
+				"(examples: linode/latest-64bit, linode/grub2, linode/direct-disk)",
+		},
+		"run_level": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Defines the state of your Linode after booting. Defaults to default.",
+			Default:      "default",
+			ValidateFunc: validation.StringInSlice([]string{"default", "single", "binbash"}, false),
+		},
+		"virt_mode": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Controls the virtualization mode. Defaults to paravirt.",
+			Default:      "paravirt",
+			ValidateFunc: validation.StringInSlice([]string{"paravirt", "fullvirt"}, false),
+		},
+		"root_device": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The root device to boot. The corresponding disk must be attached.",
+		},
+		"comments": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Optional field for arbitrary User comments on this Config.",
+		},
+		"memory_limit": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Defaults to the total RAM of the Linode",
+		},
+	}
+}
+
 var resourceSchema = map[string]*schema.Schema{
 	"image": {
 		Type: schema.TypeString,
@@ -68,7 +242,6 @@ var resourceSchema = map[string]*schema.Schema{
 			"while your Images start with private/. See /images for more information on the Images available " +
 			"for you to use.",
 		Optional:      true,
-		ForceNew:      true,
 		ConflictsWith: []string{"disk", "config", "backup_id"},
 	},
 	"backup_id": {
@@ -86,7 +259,6 @@ var resourceSchema = map[string]*schema.Schema{
 		Description: "The StackScript to deploy to the newly created Linode. If provided, 'image' must also be " +
 			"provided, and must be an Image that is compatible with this StackScript.",
 		Optional:      true,
-		ForceNew:      true,
 		ConflictsWith: []string{"disk", "config"},
 	},
 	"stackscript_data": {
@@ -95,10 +267,100 @@ var resourceSchema = map[string]*schema.Schema{
 			"being deployed to this Linode. Only accepted if 'stackscript_id' is given. The required values depend " +
 			"on the StackScript being deployed.",
 		Optional:      true,
-		ForceNew:      true,
 		Sensitive:     true,
 		ConflictsWith: []string{"disk", "config"},
 	},
+	"clone_from": {
+		Type:        schema.TypeList,
+		Description: "Clone an existing Linode instance into this instance.",
+		Optional:    true,
+		ForceNew:    true,
+		MaxItems:    1,
+		ConflictsWith: []string{
+			"image", "backup_id", "stackscript_id", "stackscript_data", "root_pass", "authorized_keys",
+			"authorized_users", "swap_size", "disk", "config", "interface",
+		},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"linode_id": {
+					Type:        schema.TypeInt,
+					Description: "The ID of the Linode instance to clone.",
+					Required:    true,
+					ForceNew:    true,
+				},
+				"disks": {
+					Type:        schema.TypeList,
+					Elem:        &schema.Schema{Type: schema.TypeInt},
+					Description: "The IDs of the disks to clone. All disks are cloned if omitted.",
+					Optional:    true,
+					ForceNew:    true,
+				},
+				"configs": {
+					Type:        schema.TypeList,
+					Elem:        &schema.Schema{Type: schema.TypeInt},
+					Description: "The IDs of the configs to clone. All configs are cloned if omitted.",
+					Optional:    true,
+					ForceNew:    true,
+				},
+			},
+		},
+	},
+	"reboot_policy": {
+		Type: schema.TypeString,
+		Description: "Controls when the Linode is rebooted to apply changes to its configs, disks and interfaces. " +
+			"One of never, on_change or always.",
+		Optional: true,
+		Default:  rebootPolicyOnChange,
+		ValidateFunc: validation.StringInSlice(
+			[]string{rebootPolicyNever, rebootPolicyOnChange, rebootPolicyAlways}, false),
+	},
+	"pending_reboot": {
+		Type:        schema.TypeBool,
+		Description: "Whether the Linode has changes that will only take effect after it is rebooted.",
+		Computed:    true,
+	},
+	"wait_for_ssh": {
+		Type:        schema.TypeList,
+		Description: "If set, creating the Linode waits until an SSH handshake with its public IPv4 address succeeds.",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"port": {
+					Type:         schema.TypeInt,
+					Description:  "The port of the SSH server.",
+					Optional:     true,
+					Default:      22,
+					ValidateFunc: validation.IsPortNumber,
+				},
+				"timeout": {
+					Type:         schema.TypeString,
+					Description:  "How long to wait for the SSH server, e.g. 5m.",
+					Optional:     true,
+					Default:      "5m",
+					ValidateFunc: helper.ValidateDuration,
+				},
+				"host_key_fingerprint": {
+					Type: schema.TypeString,
+					Description: "The expected SHA256 or MD5 fingerprint of the SSH host key, " +
+						"e.g. SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8.",
+					Optional: true,
+				},
+			},
+		},
+	},
+	"ssh_host_key": {
+		Type:        schema.TypeString,
+		Description: "The SSH host key observed by wait_for_ssh, in authorized_keys format.",
+		Computed:    true,
+	},
+	"rebuild_on_change": {
+		Type: schema.TypeBool,
+		Description: "If true, changes to image, stackscript_id, stackscript_data, authorized_keys, " +
+			"authorized_users and root_pass will rebuild the Linode in-place rather than recreating it.",
+		Optional: true,
+		Default:  false,
+	},
 	"label": {
 		Type: schema.TypeString,
 		Description: "The Linode's label is for display purposes only. If no label is provided for a Linode, " +
@@ -118,6 +380,7 @@ var resourceSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "An array of tags applied to this object. Tags are for organizational purposes only.",
 	},
+	"tags_all": helper.TagsAllSchema(),
 	"boot_config_label": {
 		Type:        schema.TypeString,
 		Description: "The Label of the Instance Config that should be used to boot the Linode instance.",
@@ -126,12 +389,18 @@ var resourceSchema = map[string]*schema.Schema{
 	},
 	"region": {
 		Type: schema.TypeString,
-		Description: "This is the location where the Linode was deployed. This cannot be changed without " +
-			"opening a support ticket.",
+		Description: "This is the location where the Linode was deployed. Changing the region recreates the Linode " +
+			"unless migrate_on_region_change is set.",
 		Required:     true,
-		ForceNew:     true,
 		InputDefault: "us-east",
 	},
+	"migrate_on_region_change": {
+		Type: schema.TypeBool,
+		Description: "If true, changes to region will migrate the Linode to the new region rather than recreating it. " +
+			"Migrated Linodes are assigned new IP addresses.",
+		Optional: true,
+		Default:  false,
+	},
 	"type": {
 		Type:        schema.TypeString,
 		Description: "The type of instance to be deployed, determining the price and size.",
@@ -151,6 +420,13 @@ var resourceSchema = map[string]*schema.Schema{
 		Description: "The status of the instance, indicating the current readiness state.",
 		Computed:    true,
 	},
+	"booted": {
+		Type: schema.TypeBool,
+		Description: "If true, the Linode will be booted and kept running. If false, the Linode will be shut down " +
+			"and kept offline. If unset, the power state of the Linode is not managed.",
+		Optional: true,
+		Computed: true,
+	},
 	"ip_address": {
 		Type: schema.TypeString,
 		Description: "This Linode's Public IPv4 Address. If there are multiple public IPv4 addresses on this " +
@@ -190,7 +466,6 @@ var resourceSchema = map[string]*schema.Schema{
 		Description: "A list of SSH public keys to deploy for the root user on the newly created Linode. " +
 			"Only accepted if 'image' is provided.",
 		Optional:      true,
-		ForceNew:      true,
 		StateFunc:     sshKeyState,
 		ConflictsWith: []string{"disk", "config"},
 	},
@@ -201,7 +476,6 @@ var resourceSchema = map[string]*schema.Schema{
 			"be appended to the `root` user's `~/.ssh/authorized_keys` file automatically. Only accepted if " +
 			"'image' is provided.",
 		Optional:      true,
-		ForceNew:      true,
 		StateFunc:     sshKeyState,
 		ConflictsWith: []string{"disk", "config"},
 	},
@@ -210,7 +484,6 @@ var resourceSchema = map[string]*schema.Schema{
 		Description:   "The password that will be initialially assigned to the 'root' user account.",
 		Sensitive:     true,
 		Optional:      true,
-		ForceNew:      true,
 		StateFunc:     rootPasswordState,
 		ConflictsWith: []string{"disk", "config"},
 	},
@@ -378,6 +651,7 @@ var resourceSchema = map[string]*schema.Schema{
 	},
 	"config": {
 		Optional:    true,
+		Computed:    true,
 		Description: "Configuration profiles define the VM settings and boot behavior of the Linode Instance.",
 		Type:        schema.TypeList,
 		ConflictsWith: []string{
@@ -388,178 +662,12 @@ var resourceSchema = map[string]*schema.Schema{
 			return hasImage
 		},
 		Elem: &schema.Resource{
-			Schema: map[string]*schema.Schema{
-				"label": {
-					Type:         schema.TypeString,
-					Description:  "The Config's label for display purposes.  Also used by `boot_config_label`.",
-					Required:     true,
-					ValidateFunc: validation.StringLenBetween(1, 48),
-				},
-				"helpers": {
-					Type:        schema.TypeList,
-					Description: "Helpers enabled when booting to this Linode Config.",
-					MaxItems:    1,
-					Optional:    true,
-					Computed:    true,
-					Elem: &schema.Resource{
-						Schema: map[string]*schema.Schema{
-							"updatedb_disabled": {
-								Type:        schema.TypeBool,
-								Description: "Disables updatedb cron job to avoid disk thrashing.",
-								Optional:    true,
-								Default:     true,
-							},
-							"distro": {
-								Type:        schema.TypeBool,
-								Description: "Controls the behavior of the Linode Config's Distribution Helper setting.",
-								Optional:    true,
-								Default:     true,
-							},
-							"modules_dep": {
-								Type:        schema.TypeBool,
-								Description: "Creates a modules dependency file for the Kernel you run.",
-								Optional:    true,
-								Default:     true,
-							},
-							"network": {
-								Type:     schema.TypeBool,
-								Optional: true,
-								Description: "Controls the behavior of the Linode Config's Network Helper setting, " +
-									"used to automatically configure additional IP addresses assigned to this instance.",
-								Default: true,
-							},
-							"devtmpfs_automount": {
-								Type:        schema.TypeBool,
-								Description: "Populates the /dev directory early during boot without udev. Defaults to false.",
-								Optional:    true,
-								Default:     false,
-							},
-						},
-					},
-				},
-				"devices": {
-					Type: schema.TypeList,
-					Description: "Device sda-sdh can be either a Disk or Volume identified by disk_label or volume_id. " +
-						"Only one type per slot allowed.",
-					MaxItems: 1,
-					Optional: true,
-					Computed: true,
-					Elem: &schema.Resource{
-						Schema: map[string]*schema.Schema{
-							"sda": {
-								Type:        schema.TypeList,
-								Description: deviceDescription,
-								MaxItems:    1,
-								Computed:    true,
-								Optional:    true,
-								Elem:        resourceDeviceDisk(),
-							},
-							"sdb": {
-								Type:        schema.TypeList,
-								Description: deviceDescription,
-								MaxItems:    1,
-								Optional:    true,
-								Computed:    true,
-								Elem:        resourceDeviceDisk(),
-							},
-							"sdc": {
-								Type:        schema.TypeList,
-								Description: deviceDescription,
-								MaxItems:    1,
-								Optional:    true,
-								Computed:    true,
-								Elem:        resourceDeviceDisk(),
-							},
-							"sdd": {
-								Type:        schema.TypeList,
-								Description: deviceDescription,
-								MaxItems:    1,
-								Optional:    true,
-								Computed:    true,
-								Elem:        resourceDeviceDisk(),
-							},
-							"sde": {
-								Type:        schema.TypeList,
-								Description: deviceDescription,
-								MaxItems:    1,
-								Optional:    true,
-								Computed:    true,
-								Elem:        resourceDeviceDisk(),
-							},
-							"sdf": {
-								Type:        schema.TypeList,
-								Description: deviceDescription,
-								MaxItems:    1,
-								Optional:    true,
-								Computed:    true,
-								Elem:        resourceDeviceDisk(),
-							},
-							"sdg": {
-								Type:        schema.TypeList,
-								Description: deviceDescription,
-								MaxItems:    1,
-								Optional:    true,
-								Computed:    true,
-								Elem:        resourceDeviceDisk(),
-							},
-							"sdh": {
-								Type:        schema.TypeList,
-								Description: deviceDescription,
-								MaxItems:    1,
-								Optional:    true,
-								Computed:    true,
-								Elem:        resourceDeviceDisk(),
-							},
-						},
-					},
-				},
-				"interface": {
-					Type:        schema.TypeList,
-					Description: "An array of Network Interfaces for this Linode’s Configuration Profile.",
-					Optional:    true,
-					Elem:        resourceConfigInterface(),
-				},
-				"kernel": {
-					Type:     schema.TypeString,
-					Optional: true,
-					Description: "A Kernel ID to boot a Linode with. Default is based on image choice. " +
-						"(examples: linode/latest-64bit, linode/grub2, linode/direct-disk)",
-				},
-				"run_level": {
-					Type:         schema.TypeString,
-					Optional:     true,
-					Description:  "Defines the state of your Linode after booting. Defaults to default.",
-					Default:      "default",
-					ValidateFunc: validation.StringInSlice([]string{"default", "single", "binbash"}, false),
-				},
-				"virt_mode": {
-					Type:         schema.TypeString,
-					Optional:     true,
-					Description:  "Controls the virtualization mode. Defaults to paravirt.",
-					Default:      "paravirt",
-					ValidateFunc: validation.StringInSlice([]string{"paravirt", "fullvirt"}, false),
-				},
-				"root_device": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Computed:    true,
-					Description: "The root device to boot. The corresponding disk must be attached.",
-				},
-				"comments": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Description: "Optional field for arbitrary User comments on this Config.",
-				},
-				"memory_limit": {
-					Type:        schema.TypeInt,
-					Optional:    true,
-					Description: "Defaults to the total RAM of the Linode",
-				},
-			},
+			Schema: instanceConfigSchema(),
 		},
 	},
 	"disk": {
 		Optional: true,
+		Computed: true,
 		ConflictsWith: []string{
 			"image", "root_pass", "authorized_keys", "authorized_users", "swap_size",
 			"backup_id", "stackscript_id", "interface"},
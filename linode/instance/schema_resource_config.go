@@ -0,0 +1,30 @@
+package instance
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceConfigSchema extends the Instance Config schema for use as a standalone resource.
+func resourceConfigSchema() map[string]*schema.Schema {
+	result := instanceConfigSchema()
+
+	// These default to values chosen by the API when not specified
+	result["kernel"].Computed = true
+	result["memory_limit"].Computed = true
+
+	result["linode_id"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Description: "The ID of the Linode to create this Config under.",
+		Required:    true,
+		ForceNew:    true,
+	}
+	result["booted"] = &schema.Schema{
+		Type: schema.TypeBool,
+		Description: "If true, the Linode will be booted into this Config and rebooted when it changes. " +
+			"If false, the Linode will be shut down.",
+		Optional: true,
+		Computed: true,
+	}
+
+	return result
+}
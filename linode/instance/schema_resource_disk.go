@@ -0,0 +1,104 @@
+package instance
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var resourceDiskSchema = map[string]*schema.Schema{
+	"linode_id": {
+		Type:        schema.TypeInt,
+		Description: "The ID of the Linode to create this Disk under.",
+		Required:    true,
+		ForceNew:    true,
+	},
+	"label": {
+		Type:         schema.TypeString,
+		Description:  "The Disk's label for display purposes only.",
+		Required:     true,
+		ValidateFunc: validation.StringLenBetween(1, 48),
+	},
+	"size": {
+		Type:        schema.TypeInt,
+		Description: "The size of the Disk in MB. Resizing the Disk will shut down the Linode.",
+		Required:    true,
+	},
+	"filesystem": {
+		Type:         schema.TypeString,
+		Description:  "The Disk filesystem can be one of: raw, swap, ext3, ext4, initrd (max 32mb)",
+		Optional:     true,
+		Computed:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice([]string{"raw", "swap", "ext3", "ext4", "initrd"}, false),
+	},
+	"image": {
+		Type: schema.TypeString,
+		Description: "An Image ID to deploy the Disk from. Official Linode Images start with linode/, " +
+			"while your Images start with private/.",
+		Optional: true,
+		ForceNew: true,
+	},
+	"authorized_keys": {
+		Type: schema.TypeList,
+		Elem: &schema.Schema{Type: schema.TypeString},
+		Description: "A list of SSH public keys to deploy for the root user on the newly created Disk. " +
+			"Only accepted if 'image' is provided.",
+		Optional:     true,
+		ForceNew:     true,
+		StateFunc:    sshKeyState,
+		RequiredWith: []string{"image"},
+	},
+	"authorized_users": {
+		Type: schema.TypeList,
+		Elem: &schema.Schema{Type: schema.TypeString},
+		Description: "A list of Linode usernames. If the usernames have associated SSH keys, " +
+			"the keys will be appended to the `root` user's `~/.ssh/authorized_keys` file automatically. " +
+			"Only accepted if 'image' is provided.",
+		Optional:     true,
+		ForceNew:     true,
+		StateFunc:    sshKeyState,
+		RequiredWith: []string{"image"},
+	},
+	"stackscript_id": {
+		Type: schema.TypeInt,
+		Description: "The StackScript to deploy to the newly created Disk. If provided, 'image' " +
+			"must also be provided, and must be an Image that is compatible with this StackScript.",
+		Optional:     true,
+		ForceNew:     true,
+		RequiredWith: []string{"image"},
+	},
+	"stackscript_data": {
+		Type: schema.TypeMap,
+		Description: "An object containing responses to any User Defined Fields present in the " +
+			"StackScript being deployed to this Disk. Only accepted if 'stackscript_id' is given.",
+		Optional:     true,
+		ForceNew:     true,
+		Sensitive:    true,
+		RequiredWith: []string{"stackscript_id"},
+	},
+	"root_pass": {
+		Type:         schema.TypeString,
+		Description:  "The password that will be initialially assigned to the 'root' user account.",
+		Sensitive:    true,
+		Optional:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringLenBetween(6, 128),
+		StateFunc:    rootPasswordState,
+		RequiredWith: []string{"image"},
+	},
+	"status": {
+		Type:        schema.TypeString,
+		Description: "A brief description of this Disk's current state.",
+		Computed:    true,
+	},
+	"created": {
+		Type:        schema.TypeString,
+		Description: "When this Disk was created.",
+		Computed:    true,
+	},
+	"updated": {
+		Type:        schema.TypeString,
+		Description: "When this Disk was last updated.",
+		Computed:    true,
+	},
+}
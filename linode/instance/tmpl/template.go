@@ -13,12 +13,21 @@ type TemplateData struct {
 	Image  string
 	Group  string
 	Tag    string
+	Region string
 
 	SwapSize int
 
 	StackScriptName string
 
 	ResizeDisk bool
+
+	Booted bool
+
+	DiskSize int
+	Comments string
+
+	PrivateIP    bool
+	RebootPolicy string
 }
 
 func Basic(t *testing.T, label, pubKey string) string {
@@ -46,6 +55,76 @@ func WatchdogDisabled(t *testing.T, label string) string {
 		})
 }
 
+func Booted(t *testing.T, label string, booted bool) string {
+	return acceptance.ExecuteTemplate(t,
+		"instance_booted", TemplateData{
+			Label:  label,
+			Image:  acceptance.TestImageLatest,
+			Booted: booted,
+		})
+}
+
+func WaitForSSH(t *testing.T, label string) string {
+	return acceptance.ExecuteTemplate(t,
+		"instance_wait_for_ssh", TemplateData{
+			Label: label,
+			Image: acceptance.TestImageLatest,
+		})
+}
+
+func Rebuild(t *testing.T, label, image string) string {
+	return acceptance.ExecuteTemplate(t,
+		"instance_rebuild", TemplateData{
+			Label: label,
+			Image: image,
+		})
+}
+
+func Migrate(t *testing.T, label, region string) string {
+	return acceptance.ExecuteTemplate(t,
+		"instance_migrate", TemplateData{
+			Label:  label,
+			Image:  acceptance.TestImageLatest,
+			Region: region,
+		})
+}
+
+func DiskStandalone(t *testing.T, label string, diskSize int) string {
+	return acceptance.ExecuteTemplate(t,
+		"instance_disk_standalone", TemplateData{
+			Label:    label,
+			Image:    acceptance.TestImageLatest,
+			DiskSize: diskSize,
+		})
+}
+
+func ConfigStandalone(t *testing.T, label, comments string) string {
+	return acceptance.ExecuteTemplate(t,
+		"instance_config_standalone", TemplateData{
+			Label:    label,
+			Image:    acceptance.TestImageLatest,
+			Comments: comments,
+		})
+}
+
+func Clone(t *testing.T, label string) string {
+	return acceptance.ExecuteTemplate(t,
+		"instance_clone", TemplateData{
+			Label: label,
+			Image: acceptance.TestImageLatest,
+		})
+}
+
+func RebootPolicy(t *testing.T, label, rebootPolicy string, privateIP bool) string {
+	return acceptance.ExecuteTemplate(t,
+		"instance_reboot_policy", TemplateData{
+			Label:        label,
+			Image:        acceptance.TestImageLatest,
+			RebootPolicy: rebootPolicy,
+			PrivateIP:    privateIP,
+		})
+}
+
 func WithType(t *testing.T, label, pubKey, typ string) string {
 	return acceptance.ExecuteTemplate(t,
 		"instance_with_type", TemplateData{
@@ -408,6 +487,15 @@ func DataMultipleRegex(t *testing.T, label, tag string) string {
 		})
 }
 
+func DataMultipleNested(t *testing.T, label, tag string) string {
+	return acceptance.ExecuteTemplate(t,
+		"instance_data_multiple_nested", TemplateData{
+			Label: label,
+			Tag:   tag,
+			Image: acceptance.TestImageLatest,
+		})
+}
+
 func DataClientFilter(t *testing.T, label, tag string) string {
 	return acceptance.ExecuteTemplate(t,
 		"instance_data_clientfilter", TemplateData{
@@ -46,6 +46,8 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 	private := d.Get("public").(bool)
 	applyImmediately := d.Get("apply_immediately").(bool)
 
+	defer helper.LockEntity("linode", linodeID)()
+
 	ip, err := client.AddInstanceIPAddress(ctx, linodeID, private)
 	if err != nil {
 		return diag.Errorf("failed to create instance (%d) ip: %s", linodeID, err)
@@ -82,6 +84,8 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 	linodeID := d.Get("linode_id").(int)
 	rdns := d.Get("rdns").(string)
 	if d.HasChange("rdns") {
+		defer helper.LockEntity("linode", linodeID)()
+
 		updateOptions := linodego.IPAddressUpdateOptions{}
 		if rdns != "" {
 			updateOptions.RDNS = &rdns
@@ -101,6 +105,9 @@ func deleteResource(ctx context.Context, d *schema.ResourceData, meta interface{
 
 	address := d.Id()
 	linodeID := d.Get("linode_id").(int)
+
+	defer helper.LockEntity("linode", linodeID)()
+
 	if err := client.DeleteInstanceIPAddress(ctx, linodeID, address); err != nil {
 		return diag.Errorf("failed to delete instance (%d) ip (%s): %s", linodeID, address, err)
 	}
@@ -78,3 +78,44 @@ func TestAccDataSourceInstanceTypes_regex(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDataSourceInstanceTypes_filterGroup(t *testing.T) {
+	t.Parallel()
+
+	resourceName := "data.linode_instance_types.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.PreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.DataFilterGroup(t),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CheckResourceAttrGreaterThan(resourceName, "types.#", 1),
+					acceptance.CheckResourceAttrGreaterThan(resourceName, "types.0.memory", 8191),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceInstanceTypes_order(t *testing.T) {
+	t.Parallel()
+
+	resourceName := "data.linode_instance_types.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.PreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.DataOrder(t),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "types.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "types.0.id", "g6-standard-2"),
+					resource.TestCheckResourceAttr(resourceName, "types.1.id", "g6-standard-1"),
+				),
+			},
+		},
+	})
+}
@@ -15,12 +15,15 @@ var filterConfig = helper.FilterConfig{
 	"network_out": {APIFilterable: true, TypeFunc: helper.FilterTypeInt},
 	"transfer":    {APIFilterable: true, TypeFunc: helper.FilterTypeInt},
 	"vcpus":       {APIFilterable: true, TypeFunc: helper.FilterTypeInt},
+
+	"id": {TypeFunc: helper.FilterTypeString},
 }
 
 var dataSourceSchema = map[string]*schema.Schema{
-	"order_by": filterConfig.OrderBySchema(),
-	"order":    filterConfig.OrderSchema(),
-	"filter":   filterConfig.FilterSchema(),
+	"order_by":     filterConfig.OrderBySchema(),
+	"order":        filterConfig.OrderSchema(),
+	"filter":       filterConfig.FilterSchema(),
+	"filter_group": filterConfig.FilterGroupSchema(),
 	"types": {
 		Type:        schema.TypeList,
 		Description: "The returned list of Types.",
@@ -20,3 +20,13 @@ func DataRegex(t *testing.T) string {
 	return acceptance.ExecuteTemplate(t,
 		"instance_types_data_regex", nil)
 }
+
+func DataFilterGroup(t *testing.T) string {
+	return acceptance.ExecuteTemplate(t,
+		"instance_types_data_filter_group", nil)
+}
+
+func DataOrder(t *testing.T) string {
+	return acceptance.ExecuteTemplate(t,
+		"instance_types_data_order", nil)
+}
@@ -2,7 +2,6 @@ package lke
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"math"
@@ -20,6 +19,15 @@ type NodePoolSpec struct {
 	AutoScalerEnabled bool
 	AutoScalerMin     int
 	AutoScalerMax     int
+
+	// PoolID is the ID of the pool previously created for a keyed pool spec, or zero if there is none.
+	PoolID int
+}
+
+// value returns the spec without its pool identity, for comparison with provisioned pools.
+func (s NodePoolSpec) value() NodePoolSpec {
+	s.PoolID = 0
+	return s
 }
 
 type NodePoolUpdates struct {
@@ -63,8 +71,44 @@ func ReconcileLKENodePoolSpecs(
 	assignedPools := make(map[int]struct{})
 	updates.ToUpdate = make(map[int]linodego.LKENodePoolUpdateOptions)
 
+	provisionedSpecs := make(map[int]NodePoolSpec, len(pools))
+	for spec, ids := range provisionedPools {
+		for id := range ids {
+			provisionedSpecs[id] = spec
+		}
+	}
+
+	// keyed pool specs keep the pool previously created for them, which is replaced if its type changes
+	keyedSpecs := make(map[int]struct{})
+	for i, spec := range poolSpecs {
+		state, ok := provisionedSpecs[spec.PoolID]
+		if spec.PoolID == 0 || !ok {
+			continue
+		}
+
+		keyedSpecs[i] = struct{}{}
+		assignedPools[spec.PoolID] = struct{}{}
+		delete(provisionedPools[state], spec.PoolID)
+		if len(provisionedPools[state]) == 0 {
+			delete(provisionedPools, state)
+		}
+
+		switch {
+		case state.Type != spec.Type:
+			updates.ToCreate = append(updates.ToCreate, nodePoolCreateOptions(spec))
+			updates.ToDelete = append(updates.ToDelete, spec.PoolID)
+		case state != spec.value():
+			updates.ToUpdate[spec.PoolID] = nodePoolUpdateOptions(spec, state)
+		}
+	}
+
 	// find exact pool matches and filter out
 	for i, spec := range poolSpecs {
+		if _, ok := keyedSpecs[i]; ok {
+			continue
+		}
+
+		spec = spec.value()
 		poolSpecsToAssign[i] = struct{}{}
 		if ids, ok := provisionedPools[spec]; ok {
 			for id := range ids {
@@ -116,29 +160,7 @@ func ReconcileLKENodePoolSpecs(
 			continue
 		}
 
-		var newAutoscaler *linodego.LKENodePoolAutoscaler
-
-		if request.Spec.AutoScalerEnabled {
-			newAutoscaler = &linodego.LKENodePoolAutoscaler{
-				Enabled: request.Spec.AutoScalerEnabled,
-				Min:     request.Spec.AutoScalerMin,
-				Max:     request.Spec.AutoScalerMax,
-			}
-		}
-
-		// Only disable if already enabled
-		if !request.Spec.AutoScalerEnabled && request.State.AutoScalerEnabled {
-			newAutoscaler = &linodego.LKENodePoolAutoscaler{
-				Enabled: request.Spec.AutoScalerEnabled,
-				Min:     request.Spec.Count,
-				Max:     request.Spec.Count,
-			}
-		}
-
-		updates.ToUpdate[request.PoolID] = linodego.LKENodePoolUpdateOptions{
-			Count:      request.Spec.Count,
-			Autoscaler: newAutoscaler,
-		}
+		updates.ToUpdate[request.PoolID] = nodePoolUpdateOptions(request.Spec, request.State)
 
 		assignedPools[request.PoolID] = struct{}{}
 		delete(poolSpecsToAssign, request.SpecIndex)
@@ -149,23 +171,7 @@ func ReconcileLKENodePoolSpecs(
 	}
 
 	for i := range poolSpecsToAssign {
-		poolSpec := poolSpecs[i]
-
-		var newAutoscaler *linodego.LKENodePoolAutoscaler
-
-		if poolSpec.AutoScalerEnabled {
-			newAutoscaler = &linodego.LKENodePoolAutoscaler{
-				Enabled: poolSpec.AutoScalerEnabled,
-				Min:     poolSpec.AutoScalerMin,
-				Max:     poolSpec.AutoScalerMax,
-			}
-		}
-
-		updates.ToCreate = append(updates.ToCreate, linodego.LKENodePoolCreateOptions{
-			Count:      poolSpec.Count,
-			Type:       poolSpec.Type,
-			Autoscaler: newAutoscaler,
-		})
+		updates.ToCreate = append(updates.ToCreate, nodePoolCreateOptions(poolSpecs[i]))
 	}
 
 	for spec := range provisionedPools {
@@ -177,21 +183,65 @@ func ReconcileLKENodePoolSpecs(
 	return
 }
 
-func waitForNodePoolReady(
-	ctx context.Context, client *linodego.Client, errCh chan<- error, wg *sync.WaitGroup, pollMs, clusterID, poolID int) {
+func nodePoolCreateOptions(spec NodePoolSpec) linodego.LKENodePoolCreateOptions {
+	var autoscaler *linodego.LKENodePoolAutoscaler
+
+	if spec.AutoScalerEnabled {
+		autoscaler = &linodego.LKENodePoolAutoscaler{
+			Enabled: spec.AutoScalerEnabled,
+			Min:     spec.AutoScalerMin,
+			Max:     spec.AutoScalerMax,
+		}
+	}
+
+	return linodego.LKENodePoolCreateOptions{
+		Count:      spec.Count,
+		Type:       spec.Type,
+		Autoscaler: autoscaler,
+	}
+}
+
+func nodePoolUpdateOptions(spec, state NodePoolSpec) linodego.LKENodePoolUpdateOptions {
+	var autoscaler *linodego.LKENodePoolAutoscaler
+
+	if spec.AutoScalerEnabled {
+		autoscaler = &linodego.LKENodePoolAutoscaler{
+			Enabled: spec.AutoScalerEnabled,
+			Min:     spec.AutoScalerMin,
+			Max:     spec.AutoScalerMax,
+		}
+	}
+
+	// Only disable if already enabled
+	if !spec.AutoScalerEnabled && state.AutoScalerEnabled {
+		autoscaler = &linodego.LKENodePoolAutoscaler{
+			Enabled: spec.AutoScalerEnabled,
+			Min:     spec.Count,
+			Max:     spec.Count,
+		}
+	}
+
+	return linodego.LKENodePoolUpdateOptions{
+		Count:      spec.Count,
+		Autoscaler: autoscaler,
+	}
+}
+
+// waitForNodePoolReady polls an LKE Node Pool until none of its nodes are reported as not ready.
+func waitForNodePoolReady(ctx context.Context, client *linodego.Client, pollMs, clusterID, poolID int) error {
 	eventTicker := time.NewTicker(time.Duration(pollMs) * time.Millisecond)
+	defer eventTicker.Stop()
 
 main:
 	for {
 		select {
 		case <-ctx.Done():
-			log.Printf("[ERROR] timed out waiting for LKE Cluster (%d) Pool (%d) to be ready", clusterID, poolID)
-			return
+			return fmt.Errorf("timed out waiting for LKE Cluster (%d) Pool (%d) to be ready", clusterID, poolID)
 
 		case <-eventTicker.C:
 			pool, err := client.GetLKENodePool(ctx, clusterID, poolID)
 			if err != nil {
-				errCh <- fmt.Errorf("failed to get LKE Cluster (%d) Pool (%d): %w", clusterID, poolID, err)
+				return fmt.Errorf("failed to get LKE Cluster (%d) Pool (%d): %w", clusterID, poolID, err)
 			}
 
 			for _, instance := range pool.Linodes {
@@ -201,14 +251,29 @@ main:
 			}
 
 			log.Printf("[DEBUG] finished waiting for LKE Cluster (%d) Pool (%d) to be ready", clusterID, poolID)
-			wg.Done()
+			return nil
+		}
+	}
+}
+
+// signalNodePoolReady waits for an LKE Node Pool to be ready, marking it done in wg or sending any error to errCh.
+func signalNodePoolReady(
+	ctx context.Context, client *linodego.Client, errCh chan<- error, wg *sync.WaitGroup, pollMs, clusterID, poolID int) {
+	if err := waitForNodePoolReady(ctx, client, pollMs, clusterID, poolID); err != nil {
+		if ctx.Err() != nil {
+			log.Printf("[ERROR] %s", err)
 			return
 		}
+
+		errCh <- err
+		return
 	}
+
+	wg.Done()
 }
 
 func waitForNodePoolsToStartRecycle(
-	ctx context.Context, client *linodego.Client, pollMs, clusterID int, pools []linodego.LKENodePool,
+	ctx context.Context, events *helper.EventWatcher, clusterID int, pools []linodego.LKENodePool, minStart time.Time,
 ) (<-chan int, <-chan error) {
 	clusterInstances := make(map[int]int)
 	poolInstances := make(map[int]map[int]struct{}, len(pools))
@@ -223,15 +288,17 @@ func waitForNodePoolsToStartRecycle(
 	poolRecyclesCh := make(chan int)
 	errCh := make(chan error)
 
-	eventTicker := time.NewTicker(time.Duration(pollMs) * time.Millisecond)
+	sub := events.Subscribe(helper.EventFilter{
+		EntityType: linodego.EntityLinode,
+		Actions:    []linodego.EventAction{linodego.ActionLinodeDelete},
+		MinStart:   minStart,
+	})
 
 	go func() {
-		defer eventTicker.Stop()
+		defer sub.Close()
 		defer close(poolRecyclesCh)
 		defer close(errCh)
 
-		lastEventID := 0
-
 		for len(clusterInstances) != 0 {
 			select {
 			case <-ctx.Done():
@@ -239,44 +306,39 @@ func waitForNodePoolsToStartRecycle(
 					clusterID, len(clusterInstances))
 				return
 
-			case <-eventTicker.C:
-				filterBytes, _ := json.Marshal(map[string]interface{}{
-					"+order_by":   "created",
-					"+gte":        lastEventID,
-					"entity.type": string(linodego.EntityLinode),
-				})
-
-				events, err := client.ListEvents(ctx, linodego.NewListOptions(1, string(filterBytes)))
-				if err != nil {
-					errCh <- err
-					return
+			case err := <-sub.Errors():
+				select {
+				case errCh <- err:
+				case <-ctx.Done():
 				}
+				return
 
-				if len(events) != 0 {
-					lastEventID = events[0].ID
+			case event := <-sub.Events():
+				id, ok := event.Entity.ID.(float64)
+				if !ok {
+					continue
 				}
 
-				for _, event := range events {
-					if event.Action != linodego.ActionLinodeDelete {
-						continue
-					}
-					id := int(event.Entity.ID.(float64))
-					poolID, ok := clusterInstances[id]
-					if !ok {
-						continue
-					}
+				poolID, ok := clusterInstances[int(id)]
+				if !ok {
+					continue
+				}
 
-					delete(clusterInstances, id)
-					delete(poolInstances[poolID], id)
-					log.Printf("[DEBUG] finished waiting for LKE Cluster (%d) Pool (%d) Node (%d) to be deleted\n",
-						clusterID, poolID, id)
-
-					if len(poolInstances[poolID]) == 0 {
-						// all original instances for this pool have been deleted
-						delete(poolInstances, poolID)
-						log.Printf("[DEBUG] finished waiting for all nodes in LKE Cluster (%d) Pool (%d) to be recreated\n",
-							clusterID, poolID)
-						poolRecyclesCh <- poolID
+				delete(clusterInstances, int(id))
+				delete(poolInstances[poolID], int(id))
+				log.Printf("[DEBUG] finished waiting for LKE Cluster (%d) Pool (%d) Node (%d) to be deleted\n",
+					clusterID, poolID, int(id))
+
+				if len(poolInstances[poolID]) == 0 {
+					// all original instances for this pool have been deleted
+					delete(poolInstances, poolID)
+					log.Printf("[DEBUG] finished waiting for all nodes in LKE Cluster (%d) Pool (%d) to be recreated\n",
+						clusterID, poolID)
+
+					select {
+					case poolRecyclesCh <- poolID:
+					case <-ctx.Done():
+						return
 					}
 				}
 			}
@@ -288,6 +350,9 @@ func waitForNodePoolsToStartRecycle(
 func recycleLKECluster(ctx context.Context, meta *helper.ProviderMeta, id int, pools []linodego.LKENodePool) error {
 	client := meta.Client
 
+	// The deletion events are matched against the local time, which may differ from the API's
+	recycleStart := time.Now().Add(-time.Minute)
+
 	if err := client.RecycleLKEClusterNodes(ctx, id); err != nil {
 		return fmt.Errorf("failed to recycle LKE Cluster (%d): %s", id, err)
 	}
@@ -295,8 +360,7 @@ func recycleLKECluster(ctx context.Context, meta *helper.ProviderMeta, id int, p
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	poolRecyclesCh, errCh := waitForNodePoolsToStartRecycle(
-		ctx, &client, meta.Config.LKEEventPollMilliseconds, id, pools)
+	poolRecyclesCh, errCh := waitForNodePoolsToStartRecycle(ctx, meta.Events, id, pools, recycleStart)
 
 	var wg sync.WaitGroup
 	wg.Add(len(pools))
@@ -307,7 +371,7 @@ func recycleLKECluster(ctx context.Context, meta *helper.ProviderMeta, id int, p
 
 	go func() {
 		for poolID := range poolRecyclesCh {
-			go waitForNodePoolReady(ctx, &client, readyErrCh, &wg, meta.Config.LKENodeReadyPollMilliseconds, id, poolID)
+			go signalNodePoolReady(ctx, &client, readyErrCh, &wg, meta.Config.LKENodeReadyPollMilliseconds, id, poolID)
 		}
 	}()
 
@@ -342,13 +406,33 @@ func waitGroupCh(wg *sync.WaitGroup) <-chan struct{} {
 // See: https://github.com/hashicorp/terraform-plugin-sdk/issues/477
 func matchPoolsWithSchema(pools []linodego.LKENodePool, declaredPools []interface{}) []linodego.LKEClusterPool {
 	result := make([]linodego.LKENodePool, len(declaredPools))
+	matched := make([]bool, len(declaredPools))
 
 	poolMap := make(map[int]linodego.LKENodePool, len(declaredPools))
 	for _, pool := range pools {
 		poolMap[pool.ID] = pool
 	}
 
+	// Keyed pools keep their position in state, even if their spec has changed
+	for i, declaredPool := range declaredPools {
+		declaredPool := declaredPool.(map[string]interface{})
+		if key, _ := declaredPool["key"].(string); key == "" {
+			continue
+		}
+
+		id, _ := declaredPool["id"].(int)
+		if pool, ok := poolMap[id]; ok && id != 0 {
+			result[i] = pool
+			matched[i] = true
+			delete(poolMap, id)
+		}
+	}
+
 	for i, declaredPool := range declaredPools {
+		if matched[i] {
+			continue
+		}
+
 		declaredPool := declaredPool.(map[string]interface{})
 
 		for key, pool := range poolMap {
@@ -369,6 +453,49 @@ func matchPoolsWithSchema(pools []linodego.LKENodePool, declaredPools []interfac
 	return result
 }
 
+// FilterManagedPools returns the pools that belong to the declared pools of a cluster: those whose IDs are
+// known, and for each declared pool without a known ID, the first remaining pool of the same type and count.
+func FilterManagedPools(pools []linodego.LKENodePool, declaredPools []interface{}) []linodego.LKENodePool {
+	existingIDs := make(map[int]bool, len(pools))
+	for _, pool := range pools {
+		existingIDs[pool.ID] = true
+	}
+
+	managedIDs := make(map[int]bool, len(declaredPools))
+	for _, declaredPool := range declaredPools {
+		if id := declaredPool.(map[string]interface{})["id"].(int); existingIDs[id] {
+			managedIDs[id] = true
+		}
+	}
+
+	// Declared pools may not have an ID yet, or may have been replaced by a new pool
+	for _, declaredPool := range declaredPools {
+		declaredPool := declaredPool.(map[string]interface{})
+		if existingIDs[declaredPool["id"].(int)] {
+			continue
+		}
+
+		for _, pool := range pools {
+			if !managedIDs[pool.ID] && pool.Count == declaredPool["count"] && pool.Type == declaredPool["type"] {
+				managedIDs[pool.ID] = true
+				break
+			}
+		}
+	}
+
+	return filterPoolsByID(pools, managedIDs)
+}
+
+func filterPoolsByID(pools []linodego.LKENodePool, ids map[int]bool) []linodego.LKENodePool {
+	result := make([]linodego.LKENodePool, 0, len(ids))
+	for _, pool := range pools {
+		if ids[pool.ID] {
+			result = append(result, pool)
+		}
+	}
+	return result
+}
+
 func expandLinodeLKEClusterAutoscalerFromPool(pool map[string]interface{}) *linodego.LKENodePoolAutoscaler {
 	scalersSpec, ok := pool["autoscaler"].([]interface{})
 
@@ -385,7 +512,19 @@ func expandLinodeLKEClusterAutoscalerFromPool(pool map[string]interface{}) *lino
 	}
 }
 
-func expandLinodeLKENodePoolSpecs(pool []interface{}) (poolSpecs []NodePoolSpec) {
+// poolIDsByKey returns the IDs of the keyed pools of a cluster.
+func poolIDsByKey(pools []interface{}) map[string]int {
+	ids := make(map[string]int)
+	for _, pool := range pools {
+		pool := pool.(map[string]interface{})
+		if key := pool["key"].(string); key != "" && pool["id"].(int) != 0 {
+			ids[key] = pool["id"].(int)
+		}
+	}
+	return ids
+}
+
+func expandLinodeLKENodePoolSpecs(pool []interface{}, poolIDs map[string]int) (poolSpecs []NodePoolSpec) {
 	for _, spec := range pool {
 		specMap := spec.(map[string]interface{})
 		autoscaler := expandLinodeLKEClusterAutoscalerFromPool(specMap)
@@ -403,6 +542,7 @@ func expandLinodeLKENodePoolSpecs(pool []interface{}) (poolSpecs []NodePoolSpec)
 			AutoScalerEnabled: autoscaler.Enabled,
 			AutoScalerMin:     autoscaler.Min,
 			AutoScalerMax:     autoscaler.Max,
+			PoolID:            poolIDs[specMap["key"].(string)],
 		})
 	}
 	return
@@ -116,6 +116,59 @@ func TestReconcileLKENodePoolSpecs(t *testing.T) {
 				123: {Count: 3, Autoscaler: &linodego.LKENodePoolAutoscaler{Enabled: false, Min: 3, Max: 3}}, // -1
 			},
 		},
+		{
+			name: "keyed reorder",
+			provisionedPools: []linodego.LKENodePool{
+				{ID: 123, Type: "g6-standard-1", Count: 2},
+				{ID: 124, Type: "g6-standard-2", Count: 3},
+			},
+			specs: []lke.NodePoolSpec{
+				{Type: "g6-standard-2", Count: 3, PoolID: 124},
+				{Type: "g6-standard-1", Count: 2, PoolID: 123},
+			},
+			expectedToUpdate: map[int]linodego.LKENodePoolUpdateOptions{},
+		},
+		{
+			name: "keyed resizes",
+			provisionedPools: []linodego.LKENodePool{
+				{ID: 123, Type: "g6-standard-1", Count: 3},
+				{ID: 124, Type: "g6-standard-1", Count: 2},
+			},
+			specs: []lke.NodePoolSpec{
+				{Type: "g6-standard-1", Count: 2, PoolID: 123},
+				{Type: "g6-standard-1", Count: 3, PoolID: 124},
+			},
+			expectedToUpdate: map[int]linodego.LKENodePoolUpdateOptions{
+				123: {Count: 2},
+				124: {Count: 3},
+			},
+		},
+		{
+			name: "keyed type change with new pool",
+			provisionedPools: []linodego.LKENodePool{
+				{ID: 123, Type: "g6-standard-1", Count: 2},
+			},
+			specs: []lke.NodePoolSpec{
+				{Type: "g6-standard-2", Count: 2, PoolID: 123},
+				{Type: "g6-standard-1", Count: 2},
+			},
+			expectedToCreate: []linodego.LKENodePoolCreateOptions{
+				{Type: "g6-standard-2", Count: 2},
+				{Type: "g6-standard-1", Count: 2},
+			},
+			expectedToDelete: []int{123},
+			expectedToUpdate: map[int]linodego.LKENodePoolUpdateOptions{},
+		},
+		{
+			name: "keyed pool deleted",
+			provisionedPools: []linodego.LKENodePool{
+				{ID: 123, Type: "g6-standard-1", Count: 2},
+			},
+			specs: []lke.NodePoolSpec{
+				{Type: "g6-standard-1", Count: 2, PoolID: 999},
+			},
+			expectedToUpdate: map[int]linodego.LKENodePoolUpdateOptions{},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			updates := lke.ReconcileLKENodePoolSpecs(tc.specs, tc.provisionedPools)
@@ -131,3 +184,60 @@ func TestReconcileLKENodePoolSpecs(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterManagedPools(t *testing.T) {
+	pools := []linodego.LKENodePool{
+		{ID: 123, Type: "g6-standard-1", Count: 3},
+		{ID: 124, Type: "g6-standard-1", Count: 2},
+		{ID: 125, Type: "g6-standard-2", Count: 1},
+		{ID: 126, Type: "g6-standard-2", Count: 1},
+	}
+
+	for _, tc := range []struct {
+		name          string
+		declaredPools []interface{}
+		expectedIDs   []int
+	}{
+		{
+			name: "known IDs",
+			declaredPools: []interface{}{
+				map[string]interface{}{"id": 124, "type": "g6-standard-1", "count": 5},
+			},
+			expectedIDs: []int{124},
+		},
+		{
+			name: "new pools",
+			declaredPools: []interface{}{
+				map[string]interface{}{"id": 125, "type": "g6-standard-2", "count": 1},
+				map[string]interface{}{"id": 0, "type": "g6-standard-2", "count": 1},
+				map[string]interface{}{"id": 0, "type": "g6-standard-1", "count": 3},
+			},
+			expectedIDs: []int{123, 125, 126},
+		},
+		{
+			name: "replaced pools",
+			declaredPools: []interface{}{
+				map[string]interface{}{"id": 127, "type": "g6-standard-1", "count": 3},
+				map[string]interface{}{"id": 0, "type": "g6-standard-4", "count": 1},
+			},
+			expectedIDs: []int{123},
+		},
+		{
+			name: "missing pools",
+			declaredPools: []interface{}{
+				map[string]interface{}{"id": 0, "type": "g6-standard-4", "count": 1},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var ids []int
+			for _, pool := range lke.FilterManagedPools(pools, tc.declaredPools) {
+				ids = append(ids, pool.ID)
+			}
+
+			if !reflect.DeepEqual(tc.expectedIDs, ids) {
+				t.Errorf("expected pools %v, got %v", tc.expectedIDs, ids)
+			}
+		})
+	}
+}
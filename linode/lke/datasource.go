@@ -49,6 +49,9 @@ func readDataSource(ctx context.Context, d *schema.ResourceData, meta interface{
 	d.Set("tags", cluster.Tags)
 	d.Set("status", cluster.Status)
 	d.Set("kubeconfig", kubeconfig.KubeConfig)
+	if err := setKubeconfigDetails(d, kubeconfig.KubeConfig); err != nil {
+		return diag.Errorf("failed to parse kubeconfig for LKE cluster %d: %s", id, err)
+	}
 	d.Set("pools", flattenLKENodePools(pools))
 	d.Set("api_endpoints", flattenLKEClusterAPIEndpoints(endpoints))
 	d.Set("control_plane", []interface{}{flattenedControlPlane})
@@ -0,0 +1,50 @@
+package lke
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+)
+
+func KubeconfigDataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema:      dataSourceKubeconfigSchema,
+		ReadContext: readKubeconfigDataSource,
+	}
+}
+
+func readKubeconfigDataSource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*helper.ProviderMeta).Client
+	id := d.Get("cluster_id").(int)
+
+	kubeconfig, err := client.GetLKEClusterKubeconfig(ctx, id)
+	if err != nil {
+		return diag.Errorf("failed to get kubeconfig for LKE cluster %d: %s", id, err)
+	}
+
+	encoded := kubeconfig.KubeConfig
+
+	contextName := d.Get("context_name").(string)
+	userName := d.Get("user_name").(string)
+	if contextName != "" || userName != "" {
+		if encoded, err = RenameKubeconfig(encoded, contextName, userName); err != nil {
+			return diag.Errorf("failed to render kubeconfig for LKE cluster %d: %s", id, err)
+		}
+	}
+
+	details, err := ParseKubeconfig(encoded)
+	if err != nil {
+		return diag.Errorf("failed to parse kubeconfig for LKE cluster %d: %s", id, err)
+	}
+
+	d.SetId(strconv.Itoa(id))
+	d.Set("kubeconfig", encoded)
+	d.Set("host", details.Host)
+	d.Set("cluster_ca_certificate", details.ClusterCACertificate)
+	d.Set("token", details.Token)
+	d.Set("context", details.Context)
+	return nil
+}
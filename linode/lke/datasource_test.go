@@ -3,13 +3,21 @@ package lke_test
 import (
 	"testing"
 
+	"fmt"
+	"regexp"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/linode/terraform-provider-linode/linode/acceptance"
 	"github.com/linode/terraform-provider-linode/linode/lke/tmpl"
+	"github.com/linode/terraform-provider-linode/linode/mockapi"
 )
 
-const dataSourceClusterName = "data.linode_lke_cluster.test"
+const (
+	dataSourceClusterName    = "data.linode_lke_cluster.test"
+	dataSourceKubeconfigName = "data.linode_lke_kubeconfig.test"
+	dataSourceRenamedName    = "data.linode_lke_kubeconfig.renamed"
+)
 
 func TestAccDataSourceLKECluster_basic(t *testing.T) {
 	t.Parallel()
@@ -105,3 +113,64 @@ func TestAccDataSourceLKECluster_controlPlane(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDataSourceLKEKubeconfig_basic(t *testing.T) {
+	t.Parallel()
+
+	clusterName := acctest.RandomWithPrefix("tf_test")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.PreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CheckLKEClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.DataKubeconfig(t, clusterName, k8sVersionLatest),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceKubeconfigName, "kubeconfig",
+						resourceClusterName, "kubeconfig"),
+					resource.TestCheckResourceAttrPair(dataSourceKubeconfigName, "host",
+						resourceClusterName, "kubeconfig_host"),
+					resource.TestCheckResourceAttrPair(dataSourceKubeconfigName, "context",
+						resourceClusterName, "kubeconfig_context"),
+					resource.TestMatchResourceAttr(resourceClusterName, "kubeconfig_host", regexp.MustCompile("^https://")),
+					resource.TestMatchResourceAttr(resourceClusterName, "kubeconfig_cluster_ca_certificate",
+						regexp.MustCompile("BEGIN CERTIFICATE")),
+					resource.TestCheckResourceAttrSet(resourceClusterName, "kubeconfig_token"),
+					resource.TestCheckResourceAttr(dataSourceRenamedName, "context", clusterName),
+					resource.TestCheckResourceAttrPair(dataSourceRenamedName, "token",
+						resourceClusterName, "kubeconfig_token"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDataSourceLKEKubeconfig_basic(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+
+	clusterName := acctest.RandomWithPrefix("tf_test")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { mockapi.PreCheck(t) },
+		ProviderFactories: mockapi.ProviderFactories,
+		CheckDestroy:      server.CheckDestroy("linode_lke_cluster", "lke/clusters"),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + tmpl.DataKubeconfig(t, clusterName, k8sVersionLatest),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceClusterName, "kubeconfig_host",
+						regexp.MustCompile(fmt.Sprintf("^%s/k8s/", server.URL))),
+					resource.TestMatchResourceAttr(resourceClusterName, "kubeconfig_context", regexp.MustCompile("-ctx$")),
+					resource.TestCheckResourceAttrPair(dataSourceKubeconfigName, "host",
+						resourceClusterName, "kubeconfig_host"),
+					resource.TestCheckResourceAttrPair(dataSourceKubeconfigName, "token",
+						resourceClusterName, "kubeconfig_token"),
+					resource.TestCheckResourceAttr(dataSourceRenamedName, "context", clusterName),
+					resource.TestCheckResourceAttrPair(dataSourceRenamedName, "host",
+						resourceClusterName, "kubeconfig_host"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,157 @@
+package lke
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// KubeconfigDetails are the connection details of the current context of a kubeconfig.
+type KubeconfigDetails struct {
+	Host                 string
+	ClusterCACertificate string
+	Token                string
+	Context              string
+}
+
+// ParseKubeconfig parses a Base64-encoded kubeconfig as returned by the API.
+func ParseKubeconfig(encoded string) (KubeconfigDetails, error) {
+	config, err := loadKubeconfig(encoded)
+	if err != nil {
+		return KubeconfigDetails{}, err
+	}
+
+	contextName, context, err := currentKubeconfigContext(config)
+	if err != nil {
+		return KubeconfigDetails{}, err
+	}
+
+	cluster, ok := config.Clusters[context.Cluster]
+	if !ok {
+		return KubeconfigDetails{}, fmt.Errorf("kubeconfig context %q refers to unknown cluster %q",
+			contextName, context.Cluster)
+	}
+
+	if cluster.Server == "" {
+		return KubeconfigDetails{}, fmt.Errorf("kubeconfig cluster %q has no server", context.Cluster)
+	}
+
+	user, ok := config.AuthInfos[context.AuthInfo]
+	if !ok {
+		return KubeconfigDetails{}, fmt.Errorf("kubeconfig context %q refers to unknown user %q",
+			contextName, context.AuthInfo)
+	}
+
+	return KubeconfigDetails{
+		Host:                 cluster.Server,
+		ClusterCACertificate: string(cluster.CertificateAuthorityData),
+		Token:                user.Token,
+		Context:              contextName,
+	}, nil
+}
+
+// RenameKubeconfig renames the current context and its user of a Base64-encoded kubeconfig,
+// and returns the re-rendered kubeconfig encoded the same way. Empty names are left unchanged.
+func RenameKubeconfig(encoded, contextName, userName string) (string, error) {
+	config, err := loadKubeconfig(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	currentName, context, err := currentKubeconfigContext(config)
+	if err != nil {
+		return "", err
+	}
+
+	if userName != "" && userName != context.AuthInfo {
+		user, ok := config.AuthInfos[context.AuthInfo]
+		if !ok {
+			return "", fmt.Errorf("kubeconfig context %q refers to unknown user %q", currentName, context.AuthInfo)
+		}
+
+		if _, ok := config.AuthInfos[userName]; ok {
+			return "", fmt.Errorf("kubeconfig already has a user named %q", userName)
+		}
+
+		oldUserName := context.AuthInfo
+		delete(config.AuthInfos, oldUserName)
+		config.AuthInfos[userName] = user
+
+		for _, c := range config.Contexts {
+			if c.AuthInfo == oldUserName {
+				c.AuthInfo = userName
+			}
+		}
+	}
+
+	if contextName != "" && contextName != currentName {
+		if _, ok := config.Contexts[contextName]; ok {
+			return "", fmt.Errorf("kubeconfig already has a context named %q", contextName)
+		}
+
+		delete(config.Contexts, currentName)
+		config.Contexts[contextName] = context
+		config.CurrentContext = contextName
+	}
+
+	rendered, err := clientcmd.Write(*config)
+	if err != nil {
+		return "", fmt.Errorf("failed to render kubeconfig: %s", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(rendered), nil
+}
+
+func loadKubeconfig(encoded string) (*clientcmdapi.Config, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode kubeconfig: %s", err)
+	}
+
+	config, err := clientcmd.Load(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %s", err)
+	}
+
+	return config, nil
+}
+
+// currentKubeconfigContext returns the current context of a kubeconfig,
+// or its only context if none is set as current.
+func currentKubeconfigContext(config *clientcmdapi.Config) (string, *clientcmdapi.Context, error) {
+	name := config.CurrentContext
+	if name == "" {
+		if len(config.Contexts) != 1 {
+			return "", nil, fmt.Errorf("kubeconfig has no current context and %d contexts", len(config.Contexts))
+		}
+
+		for contextName := range config.Contexts {
+			name = contextName
+		}
+	}
+
+	context, ok := config.Contexts[name]
+	if !ok {
+		return "", nil, fmt.Errorf("kubeconfig has no context named %q", name)
+	}
+
+	return name, context, nil
+}
+
+// setKubeconfigDetails sets the parsed kubeconfig attributes of an LKE cluster.
+func setKubeconfigDetails(d *schema.ResourceData, kubeconfig string) error {
+	details, err := ParseKubeconfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	d.Set("kubeconfig_host", details.Host)
+	d.Set("kubeconfig_cluster_ca_certificate", details.ClusterCACertificate)
+	d.Set("kubeconfig_token", details.Token)
+	d.Set("kubeconfig_context", details.Context)
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package lke_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/linode/terraform-provider-linode/linode/lke"
+)
+
+const testCACertificate = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+
+var testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    certificate-authority-data: ` + base64.StdEncoding.EncodeToString([]byte(testCACertificate)) + `
+    server: https://1234.us-east.linodelke.net:443
+  name: lke1234
+contexts:
+- context:
+    cluster: lke1234
+    namespace: default
+    user: lke1234-admin
+  name: lke1234-ctx
+current-context: lke1234-ctx
+users:
+- name: lke1234-admin
+  user:
+    token: secret
+`
+
+func encodeKubeconfig(kubeconfig string) string {
+	return base64.StdEncoding.EncodeToString([]byte(kubeconfig))
+}
+
+func TestParseKubeconfig(t *testing.T) {
+	details, err := lke.ParseKubeconfig(encodeKubeconfig(testKubeconfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := lke.KubeconfigDetails{
+		Host:                 "https://1234.us-east.linodelke.net:443",
+		ClusterCACertificate: testCACertificate,
+		Token:                "secret",
+		Context:              "lke1234-ctx",
+	}
+	if details != expected {
+		t.Errorf("expected %+v, got %+v", expected, details)
+	}
+
+	// A kubeconfig with a single context does not need a current context
+	details, err = lke.ParseKubeconfig(encodeKubeconfig(strings.Replace(testKubeconfig,
+		"current-context: lke1234-ctx", "", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if details.Context != "lke1234-ctx" {
+		t.Errorf("expected the only context to be used, got %q", details.Context)
+	}
+}
+
+func TestParseKubeconfigErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		kubeconfig string
+		expected   string
+	}{
+		{
+			name:       "not base64",
+			kubeconfig: "not base64!",
+			expected:   "failed to decode kubeconfig",
+		},
+		{
+			name:       "not yaml",
+			kubeconfig: encodeKubeconfig("clusters: ["),
+			expected:   "failed to parse kubeconfig",
+		},
+		{
+			name:       "unknown current context",
+			kubeconfig: encodeKubeconfig(strings.Replace(testKubeconfig, "current-context: lke1234-ctx", "current-context: other", 1)),
+			expected:   `no context named "other"`,
+		},
+		{
+			name:       "unknown cluster",
+			kubeconfig: encodeKubeconfig(strings.Replace(testKubeconfig, "  name: lke1234\n", "  name: other\n", 1)),
+			expected:   `unknown cluster "lke1234"`,
+		},
+		{
+			name:       "unknown user",
+			kubeconfig: encodeKubeconfig(strings.Replace(testKubeconfig, "- name: lke1234-admin", "- name: other", 1)),
+			expected:   `unknown user "lke1234-admin"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := lke.ParseKubeconfig(tc.kubeconfig)
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("expected an error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestRenameKubeconfig(t *testing.T) {
+	renamed, err := lke.RenameKubeconfig(encodeKubeconfig(testKubeconfig), "production", "deployer")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	details, err := lke.ParseKubeconfig(renamed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if details.Context != "production" || details.Token != "secret" ||
+		details.Host != "https://1234.us-east.linodelke.net:443" {
+		t.Errorf("unexpected details of the renamed kubeconfig: %+v", details)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(renamed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(raw), "user: deployer") || strings.Contains(string(raw), "lke1234-admin") {
+		t.Errorf("expected the user to be renamed:\n%s", raw)
+	}
+
+	if _, err := lke.RenameKubeconfig(encodeKubeconfig("clusters: ["), "production", ""); err == nil {
+		t.Error("expected an error renaming a malformed kubeconfig")
+	}
+}
@@ -2,15 +2,19 @@ package lke
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"reflect"
 	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/linode/linodego"
+	"github.com/linode/linodego/k8s"
 	k8scondition "github.com/linode/linodego/k8s/pkg/condition"
 	"github.com/linode/terraform-provider-linode/linode/helper"
+	"k8s.io/client-go/kubernetes"
 )
 
 const (
@@ -26,6 +30,7 @@ func Resource() *schema.Resource {
 		CreateContext: createResource,
 		UpdateContext: updateResource,
 		DeleteContext: deleteResource,
+		CustomizeDiff: diffResource,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -38,6 +43,13 @@ func Resource() *schema.Resource {
 }
 
 func readResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readCluster(ctx, d, meta, nil)
+}
+
+// readCluster reads an LKE cluster into state. When the cluster ignores unmanaged pools, only the pools in
+// managedPoolIDs are read, or if it is nil, the pools that belong to the pools already in state.
+func readCluster(
+	ctx context.Context, d *schema.ResourceData, meta interface{}, managedPoolIDs map[int]bool) diag.Diagnostics {
 	client := meta.(*helper.ProviderMeta).Client
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
@@ -74,16 +86,41 @@ func readResource(ctx context.Context, d *schema.ResourceData, meta interface{})
 		return diag.Errorf("failed to get API endpoints for LKE cluster %d: %s", id, err)
 	}
 
+	// Pools created by this resource are always managed, and imported clusters manage all of their pools
+	if d.Get("ignore_unmanaged_pools").(bool) && !d.IsNewResource() {
+		if managedPoolIDs == nil {
+			pools = FilterManagedPools(pools, declaredPools)
+		} else {
+			pools = filterPoolsByID(pools, managedPoolIDs)
+		}
+	}
+
 	flattenedControlPlane := flattenLKEClusterControlPlane(cluster.ControlPlane)
 
 	d.Set("label", cluster.Label)
-	d.Set("k8s_version", cluster.K8sVersion)
+	if !isK8sVersionConstraint(d.Get("k8s_version").(string)) {
+		d.Set("k8s_version", cluster.K8sVersion)
+	}
+	d.Set("resolved_k8s_version", cluster.K8sVersion)
 	d.Set("region", cluster.Region)
-	d.Set("tags", cluster.Tags)
+	helper.SetTags(d, meta, cluster.Tags)
 	d.Set("status", cluster.Status)
 	d.Set("kubeconfig", kubeconfig.KubeConfig)
+	if err := setKubeconfigDetails(d, kubeconfig.KubeConfig); err != nil {
+		return diag.Errorf("failed to parse kubeconfig for LKE cluster %d: %s", id, err)
+	}
 	d.Set("api_endpoints", flattenLKEClusterAPIEndpoints(endpoints))
-	d.Set("pool", flattenLKENodePools(matchPoolsWithSchema(pools, declaredPools)))
+	flattenedPools := flattenLKENodePools(matchPoolsWithSchema(pools, declaredPools))
+	poolIDs := make(map[string]interface{})
+	for i, declaredPool := range declaredPools {
+		if key := declaredPool.(map[string]interface{})["key"].(string); key != "" {
+			flattenedPools[i]["key"] = key
+			poolIDs[key] = flattenedPools[i]["id"]
+		}
+	}
+
+	d.Set("pool", flattenedPools)
+	d.Set("pool_ids", poolIDs)
 	d.Set("control_plane", []map[string]interface{}{flattenedControlPlane})
 
 	return nil
@@ -94,10 +131,15 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 
 	controlPlane := d.Get("control_plane").([]interface{})
 
+	k8sVersion, err := plannedK8sVersion(ctx, d, &client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	createOpts := linodego.LKEClusterCreateOptions{
 		Label:      d.Get("label").(string),
 		Region:     d.Get("region").(string),
-		K8sVersion: d.Get("k8s_version").(string),
+		K8sVersion: k8sVersion,
 	}
 
 	if len(controlPlane) > 0 {
@@ -115,11 +157,7 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		})
 	}
 
-	if tagsRaw, tagsOk := d.GetOk("tags"); tagsOk {
-		for _, tag := range tagsRaw.(*schema.Set).List() {
-			createOpts.Tags = append(createOpts.Tags, tag.(string))
-		}
-	}
+	createOpts.Tags = helper.ExpandTags(d, meta)
 
 	cluster, err := client.CreateLKECluster(ctx, createOpts)
 	if err != nil {
@@ -141,9 +179,28 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		return diag.Errorf("failed parsing Linode LKE Cluster ID: %s", err)
 	}
 
+	defer helper.LockEntity("lke_cluster", id)()
+
+	oldK8sVersion, _ := d.GetChange("resolved_k8s_version")
+	if oldK8sVersion.(string) == "" {
+		// The state was written before the resolved version was tracked
+		oldK8sVersion, _ = d.GetChange("k8s_version")
+	}
+
+	k8sVersion, err := plannedK8sVersion(ctx, d, &client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Clusters are only upgraded when the version they resolve to changes,
+	// or to resume recycling the nodes after an upgrade failed partway through
+	versionChanged := k8sVersion != oldK8sVersion.(string)
+	upgradePending, _ := d.GetChange("upgrade_pending")
+	upgrade := versionChanged || upgradePending.(bool)
+
 	updateOpts := linodego.LKEClusterUpdateOptions{}
 	updateOpts.Label = d.Get("label").(string)
-	updateOpts.K8sVersion = d.Get("k8s_version").(string)
+	updateOpts.K8sVersion = k8sVersion
 
 	controlPlane := d.Get("control_plane").([]interface{})
 	if len(controlPlane) > 0 {
@@ -151,15 +208,11 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		updateOpts.ControlPlane = &expandedControlPlane
 	}
 
-	if d.HasChange("tags") {
-		tags := []string{}
-		for _, tag := range d.Get("tags").(*schema.Set).List() {
-			tags = append(tags, tag.(string))
-		}
-
+	if d.HasChanges("tags", "tags_all") {
+		tags := helper.ExpandTags(d, meta)
 		updateOpts.Tags = &tags
 	}
-	if d.HasChanges("label", "tags", "k8s_version", "control_plane") {
+	if d.HasChanges("label", "tags", "tags_all", "control_plane") || versionChanged {
 		if _, err := client.UpdateLKECluster(ctx, id, updateOpts); err != nil {
 			return diag.Errorf("failed to update LKE Cluster %d: %s", id, err)
 		}
@@ -170,14 +223,24 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		return diag.Errorf("failed to get Pools for LKE Cluster %d: %s", id, err)
 	}
 
-	if d.HasChange("k8s_version") {
-		if err := recycleLKECluster(ctx, providerMeta, id, pools); err != nil {
+	if upgrade {
+		if err := upgradeLKECluster(ctx, d, providerMeta, id, k8sVersion, pools, !versionChanged); err != nil {
+			// The cluster already runs the new version, so the next apply recycles the remaining nodes
+			d.Set("upgrade_pending", true)
 			return diag.FromErr(err)
 		}
 	}
 
-	poolSpecs := expandLinodeLKENodePoolSpecs(d.Get("pool").([]interface{}))
-	updates := ReconcileLKENodePoolSpecs(poolSpecs, pools)
+	oldPools, _ := d.GetChange("pool")
+	oldPoolIDs := poolIDsByKey(oldPools.([]interface{}))
+	poolSpecs := expandLinodeLKENodePoolSpecs(d.Get("pool").([]interface{}), oldPoolIDs)
+
+	managedPools := pools
+	if d.Get("ignore_unmanaged_pools").(bool) {
+		managedPools = FilterManagedPools(pools, oldPools.([]interface{}))
+	}
+
+	updates := ReconcileLKENodePoolSpecs(poolSpecs, managedPools)
 
 	for poolID, updateOpts := range updates.ToUpdate {
 		if _, err := client.UpdateLKENodePool(ctx, id, poolID, updateOpts); err != nil {
@@ -185,19 +248,228 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		}
 	}
 
+	var createdPoolIDs []int
 	for _, createOpts := range updates.ToCreate {
-		if _, err := client.CreateLKENodePool(ctx, id, createOpts); err != nil {
+		pool, err := client.CreateLKENodePool(ctx, id, createOpts)
+		if err != nil {
 			return diag.Errorf("failed to create LKE Cluster %d Pool: %s", id, err)
 		}
+		createdPoolIDs = append(createdPoolIDs, pool.ID)
+	}
+
+	// Workloads can only be drained from the deleted pools once their replacements are ready
+	if len(updates.ToDelete) > 0 {
+		for _, poolID := range createdPoolIDs {
+			if err := waitForNodePoolReady(ctx, &client, providerMeta.Config.LKENodeReadyPollMilliseconds,
+				id, poolID); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		strategy, err := expandUpgradeStrategy(d.Get("upgrade_strategy").([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		kubeClient, err := buildKubeClient(ctx, &client, id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		pollInterval := time.Duration(providerMeta.Config.LKENodeReadyPollMilliseconds) * time.Millisecond
+
+		for _, poolID := range updates.ToDelete {
+			// The nodes of the pool may have been recycled by an upgrade since the pools were listed
+			pool, err := client.GetLKENodePool(ctx, id, poolID)
+			if err != nil {
+				return diag.Errorf("failed to get LKE Cluster %d Pool %d: %s", id, poolID, err)
+			}
+
+			if err := DrainAndDeleteLKENodePool(
+				ctx, &client, kubeClient, id, *pool, strategy.DrainTimeout, pollInterval); err != nil {
+				return diag.FromErr(err)
+			}
+		}
 	}
 
+	managedPoolIDs := make(map[int]bool, len(managedPools)+len(createdPoolIDs))
+	for _, pool := range managedPools {
+		managedPoolIDs[pool.ID] = true
+	}
 	for _, poolID := range updates.ToDelete {
-		if err := client.DeleteLKENodePool(ctx, id, poolID); err != nil {
-			return diag.Errorf("failed to delete LKE Cluster %d Pool %d: %s", id, poolID, err)
+		delete(managedPoolIDs, poolID)
+	}
+	for _, poolID := range createdPoolIDs {
+		managedPoolIDs[poolID] = true
+	}
+
+	// The planned pool IDs follow the position of each pool rather than its key
+	declaredPools := d.Get("pool").([]interface{})
+	for i, spec := range poolSpecs {
+		declaredPool := declaredPools[i].(map[string]interface{})
+		declaredPool["id"] = spec.PoolID
+		if containsInt(updates.ToDelete, spec.PoolID) {
+			declaredPool["id"] = 0
 		}
 	}
+	d.Set("pool", declaredPools)
 
-	return nil
+	return readCluster(ctx, d, meta, managedPoolIDs)
+}
+
+// upgradeLKECluster recycles the nodes of a cluster after its Kubernetes version changed to k8sVersion,
+// according to its upgrade_strategy. When resuming an upgrade that failed, only the nodes that do not run
+// k8sVersion yet are recycled.
+func upgradeLKECluster(ctx context.Context, d *schema.ResourceData, meta *helper.ProviderMeta, id int,
+	k8sVersion string, pools []linodego.LKENodePool, resume bool) error {
+	strategy, err := expandUpgradeStrategy(d.Get("upgrade_strategy").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	if !strategy.Rolling && !resume {
+		return recycleLKECluster(ctx, meta, id, pools)
+	}
+
+	kubeClient, err := buildKubeClient(ctx, &meta.Client, id)
+	if err != nil {
+		return err
+	}
+
+	pollInterval := time.Duration(meta.Config.LKENodeReadyPollMilliseconds) * time.Millisecond
+
+	if !strategy.Rolling {
+		return RecycleOutdatedLKENodes(ctx, &meta.Client, kubeClient, id, pools, k8sVersion, pollInterval)
+	}
+
+	return RollingRecycleLKECluster(ctx, &meta.Client, kubeClient, id, pools, k8sVersion, strategy, pollInterval)
+}
+
+// buildKubeClient returns a Kubernetes client for a cluster using its kubeconfig.
+func buildKubeClient(ctx context.Context, client *linodego.Client, id int) (kubernetes.Interface, error) {
+	kubeconfig, err := client.GetLKEClusterKubeconfig(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig for LKE Cluster %d: %s", id, err)
+	}
+
+	kubeClient, err := k8s.BuildClientsetFromConfig(kubeconfig, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build a Kubernetes client for LKE Cluster %d: %s", id, err)
+	}
+
+	return kubeClient, nil
+}
+
+// plannedK8sVersion returns the version a cluster is planned to run, resolving it
+// if its k8s_version was not known at plan time.
+func plannedK8sVersion(ctx context.Context, d *schema.ResourceData, client *linodego.Client) (string, error) {
+	if version := d.Get("resolved_k8s_version").(string); version != "" {
+		return version, nil
+	}
+
+	oldVersion, _ := d.GetChange("resolved_k8s_version")
+
+	return resolveK8sVersion(ctx, client, d.Get("k8s_version").(string), oldVersion.(string))
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func diffResource(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := helper.CustomizeDiffTagsAll(ctx, d, meta); err != nil {
+		return err
+	}
+
+	if err := diffK8sVersion(ctx, d, meta); err != nil {
+		return err
+	}
+
+	// A pending upgrade is resumed by the next apply
+	if d.Get("upgrade_pending").(bool) {
+		if err := d.SetNew("upgrade_pending", false); err != nil {
+			return err
+		}
+	}
+
+	return diffPoolIDs(d)
+}
+
+// diffK8sVersion resolves the planned version of a cluster from its k8s_version.
+func diffK8sVersion(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("k8s_version") {
+		return d.SetNewComputed("resolved_k8s_version")
+	}
+
+	client := meta.(*helper.ProviderMeta).Client
+	current := d.Get("resolved_k8s_version").(string)
+
+	resolved, err := resolveK8sVersion(ctx, &client, d.Get("k8s_version").(string), current)
+	if err != nil {
+		return err
+	}
+
+	if resolved == current {
+		return nil
+	}
+
+	if err := ValidateK8sUpgrade(current, resolved); err != nil {
+		return err
+	}
+
+	return d.SetNew("resolved_k8s_version", resolved)
+}
+
+func diffPoolIDs(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("pool") {
+		return d.SetNewComputed("pool_ids")
+	}
+
+	oldPools, newPools := d.GetChange("pool")
+	oldIDs := poolIDsByKey(oldPools.([]interface{}))
+
+	keys := make(map[string]bool)
+	for _, pool := range newPools.([]interface{}) {
+		key := pool.(map[string]interface{})["key"].(string)
+		if key != "" && keys[key] {
+			return fmt.Errorf("duplicate pool key %q", key)
+		}
+		keys[key] = true
+	}
+
+	oldTypes := make(map[string]string)
+	for _, pool := range oldPools.([]interface{}) {
+		pool := pool.(map[string]interface{})
+		oldTypes[pool["key"].(string)] = pool["type"].(string)
+	}
+
+	// Keyed pools that are new or whose type changes are created as new Node Pools
+	poolIDs := make(map[string]interface{})
+	for _, pool := range newPools.([]interface{}) {
+		pool := pool.(map[string]interface{})
+
+		key := pool["key"].(string)
+		if key == "" {
+			continue
+		}
+
+		id, ok := oldIDs[key]
+		if !ok || oldTypes[key] != pool["type"] {
+			return d.SetNewComputed("pool_ids")
+		}
+		poolIDs[key] = id
+	}
+
+	if reflect.DeepEqual(poolIDs, d.Get("pool_ids")) {
+		return nil
+	}
+
+	return d.SetNew("pool_ids", poolIDs)
 }
 
 func deleteResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -0,0 +1,225 @@
+package lke
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/linode/linodego"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+)
+
+const (
+	createLKENodePoolTimeout = 15 * time.Minute
+	updateLKENodePoolTimeout = 15 * time.Minute
+	deleteLKENodePoolTimeout = 5 * time.Minute
+)
+
+func NodePoolResource() *schema.Resource {
+	return &schema.Resource{
+		Schema:        resourceNodePoolSchema,
+		ReadContext:   readNodePoolResource,
+		CreateContext: createNodePoolResource,
+		UpdateContext: updateNodePoolResource,
+		DeleteContext: deleteNodePoolResource,
+		Importer: &schema.ResourceImporter{
+			StateContext: importNodePoolResource,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(createLKENodePoolTimeout),
+			Update: schema.DefaultTimeout(updateLKENodePoolTimeout),
+			Delete: schema.DefaultTimeout(deleteLKENodePoolTimeout),
+		},
+	}
+}
+
+func readNodePoolResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*helper.ProviderMeta).Client
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("failed to parse LKE Node Pool ID %s: %s", d.Id(), err)
+	}
+	clusterID := d.Get("cluster_id").(int)
+
+	pool, err := client.GetLKENodePool(ctx, clusterID, id)
+	if err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			log.Printf("[WARN] removing LKE Cluster (%d) Pool ID %q from state because it no longer exists",
+				clusterID, d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("failed to get LKE Cluster (%d) Pool (%d): %s", clusterID, id, err)
+	}
+
+	d.Set("type", pool.Type)
+	d.Set("node_count", pool.Count)
+	d.Set("autoscaler", flattenNodePoolAutoscaler(pool.Autoscaler))
+	d.Set("nodes", flattenNodePoolNodes(pool.Linodes))
+
+	return nil
+}
+
+func importNodePoolResource(
+	ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := strings.Split(d.Id(), ",")
+	if len(s) != 2 {
+		return nil, fmt.Errorf("invalid linode_lke_node_pool ID: expected cluster_id,pool_id; got %s", d.Id())
+	}
+
+	clusterID, err := strconv.Atoi(s[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid LKE Cluster ID: %s", err)
+	}
+
+	// Validate that this is an ID by making sure it can be converted into an int
+	if _, err := strconv.Atoi(s[1]); err != nil {
+		return nil, fmt.Errorf("invalid LKE Node Pool ID: %s", err)
+	}
+
+	d.SetId(s[1])
+	d.Set("cluster_id", clusterID)
+
+	if diags := readNodePoolResource(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("unable to import %v as linode_lke_node_pool: %s", d.Id(), diags[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func createNodePoolResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerMeta := meta.(*helper.ProviderMeta)
+	client := providerMeta.Client
+	clusterID := d.Get("cluster_id").(int)
+
+	createOpts := linodego.LKENodePoolCreateOptions{
+		Type:       d.Get("type").(string),
+		Count:      d.Get("node_count").(int),
+		Autoscaler: expandNodePoolAutoscaler(d.Get("autoscaler").([]interface{})),
+	}
+
+	if createOpts.Count == 0 && createOpts.Autoscaler != nil {
+		createOpts.Count = createOpts.Autoscaler.Min
+	}
+
+	unlock := helper.LockEntity("lke_cluster", clusterID)
+	pool, err := client.CreateLKENodePool(ctx, clusterID, createOpts)
+	unlock()
+	if err != nil {
+		return diag.Errorf("failed to create LKE Cluster (%d) Pool: %s", clusterID, err)
+	}
+	d.SetId(strconv.Itoa(pool.ID))
+
+	if err := waitForNodePoolReady(ctx, &client, providerMeta.Config.LKENodeReadyPollMilliseconds,
+		clusterID, pool.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return readNodePoolResource(ctx, d, meta)
+}
+
+func updateNodePoolResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerMeta := meta.(*helper.ProviderMeta)
+	client := providerMeta.Client
+	clusterID := d.Get("cluster_id").(int)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("failed to parse LKE Node Pool ID %s: %s", d.Id(), err)
+	}
+
+	if !d.HasChanges("node_count", "autoscaler") {
+		return readNodePoolResource(ctx, d, meta)
+	}
+
+	count := d.Get("node_count").(int)
+	updateOpts := linodego.LKENodePoolUpdateOptions{
+		Count:      count,
+		Autoscaler: expandNodePoolAutoscaler(d.Get("autoscaler").([]interface{})),
+	}
+
+	// Only disable the autoscaler if it was previously enabled
+	if updateOpts.Autoscaler == nil && d.HasChange("autoscaler") {
+		updateOpts.Autoscaler = &linodego.LKENodePoolAutoscaler{
+			Enabled: false,
+			Min:     count,
+			Max:     count,
+		}
+	}
+
+	unlock := helper.LockEntity("lke_cluster", clusterID)
+	_, err = client.UpdateLKENodePool(ctx, clusterID, id, updateOpts)
+	unlock()
+	if err != nil {
+		return diag.Errorf("failed to update LKE Cluster (%d) Pool (%d): %s", clusterID, id, err)
+	}
+
+	if err := waitForNodePoolReady(ctx, &client, providerMeta.Config.LKENodeReadyPollMilliseconds,
+		clusterID, id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return readNodePoolResource(ctx, d, meta)
+}
+
+func deleteNodePoolResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*helper.ProviderMeta).Client
+	clusterID := d.Get("cluster_id").(int)
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("failed to parse LKE Node Pool ID %s: %s", d.Id(), err)
+	}
+
+	defer helper.LockEntity("lke_cluster", clusterID)()
+
+	if err := client.DeleteLKENodePool(ctx, clusterID, id); err != nil {
+		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
+			return nil
+		}
+		return diag.Errorf("failed to delete LKE Cluster (%d) Pool (%d): %s", clusterID, id, err)
+	}
+
+	return nil
+}
+
+func expandNodePoolAutoscaler(autoscaler []interface{}) *linodego.LKENodePoolAutoscaler {
+	if len(autoscaler) < 1 || autoscaler[0] == nil {
+		return nil
+	}
+
+	scaler := autoscaler[0].(map[string]interface{})
+	return &linodego.LKENodePoolAutoscaler{
+		Enabled: true,
+		Min:     scaler["min"].(int),
+		Max:     scaler["max"].(int),
+	}
+}
+
+func flattenNodePoolAutoscaler(autoscaler linodego.LKENodePoolAutoscaler) []map[string]interface{} {
+	if !autoscaler.Enabled {
+		return nil
+	}
+
+	return []map[string]interface{}{
+		{
+			"min": autoscaler.Min,
+			"max": autoscaler.Max,
+		},
+	}
+}
+
+func flattenNodePoolNodes(nodes []linodego.LKENodePoolLinode) []map[string]interface{} {
+	flattened := make([]map[string]interface{}, len(nodes))
+	for i, node := range nodes {
+		flattened[i] = map[string]interface{}{
+			"id":          node.ID,
+			"instance_id": node.InstanceID,
+			"status":      node.Status,
+		}
+	}
+	return flattened
+}
@@ -0,0 +1,160 @@
+package lke_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/linode/terraform-provider-linode/linode/acceptance"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+	"github.com/linode/terraform-provider-linode/linode/lke/tmpl"
+	"github.com/linode/terraform-provider-linode/linode/mockapi"
+)
+
+const resourceNodePoolName = "linode_lke_node_pool.test"
+
+func importNodePoolID(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources[resourceNodePoolName]
+	if !ok {
+		return "", fmt.Errorf("resource not found: %s", resourceNodePoolName)
+	}
+
+	return fmt.Sprintf("%s,%s", rs.Primary.Attributes["cluster_id"], rs.Primary.ID), nil
+}
+
+func checkNodePoolExists(s *terraform.State) error {
+	client := acceptance.TestAccProvider.Meta().(*helper.ProviderMeta).Client
+
+	rs, ok := s.RootModule().Resources[resourceNodePoolName]
+	if !ok {
+		return fmt.Errorf("could not find resource %s", resourceNodePoolName)
+	}
+
+	clusterID, err := strconv.Atoi(rs.Primary.Attributes["cluster_id"])
+	if err != nil {
+		return fmt.Errorf("Error parsing %v to int", rs.Primary.Attributes["cluster_id"])
+	}
+
+	id, err := strconv.Atoi(rs.Primary.ID)
+	if err != nil {
+		return fmt.Errorf("Error parsing %v to int", rs.Primary.ID)
+	}
+
+	if _, err := client.GetLKENodePool(context.Background(), clusterID, id); err != nil {
+		return fmt.Errorf("Error retrieving LKE Cluster %d Pool %d: %s", clusterID, id, err)
+	}
+
+	return nil
+}
+
+func TestAccResourceLKENodePool_basic(t *testing.T) {
+	t.Parallel()
+
+	clusterName := acctest.RandomWithPrefix("tf_test")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.PreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CheckLKEClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.NodePoolBasic(t, clusterName, k8sVersionLatest, 1),
+				Check: resource.ComposeTestCheckFunc(
+					checkNodePoolExists,
+					resource.TestCheckResourceAttrPair(resourceNodePoolName, "cluster_id", resourceClusterName, "id"),
+					resource.TestCheckResourceAttr(resourceNodePoolName, "type", "g6-standard-2"),
+					resource.TestCheckResourceAttr(resourceNodePoolName, "node_count", "1"),
+					resource.TestCheckResourceAttr(resourceNodePoolName, "nodes.#", "1"),
+					resource.TestCheckResourceAttr(resourceNodePoolName, "autoscaler.#", "0"),
+					resource.TestCheckResourceAttr(resourceClusterName, "pool.#", "1"),
+				),
+			},
+			{
+				Config: tmpl.NodePoolBasic(t, clusterName, k8sVersionLatest, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNodePoolName, "node_count", "2"),
+					resource.TestCheckResourceAttr(resourceNodePoolName, "nodes.#", "2"),
+					resource.TestCheckResourceAttr(resourceClusterName, "pool.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceNodePoolName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importNodePoolID,
+			},
+		},
+	})
+}
+
+func TestAccResourceLKENodePool_autoscaler(t *testing.T) {
+	t.Parallel()
+
+	clusterName := acctest.RandomWithPrefix("tf_test")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.PreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CheckLKEClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.NodePoolAutoscaler(t, clusterName, k8sVersionLatest),
+				Check: resource.ComposeTestCheckFunc(
+					checkNodePoolExists,
+					resource.TestCheckResourceAttr(resourceNodePoolName, "node_count", "1"),
+					resource.TestCheckResourceAttr(resourceNodePoolName, "autoscaler.#", "1"),
+					resource.TestCheckResourceAttr(resourceNodePoolName, "autoscaler.0.min", "1"),
+					resource.TestCheckResourceAttr(resourceNodePoolName, "autoscaler.0.max", "3"),
+				),
+			},
+			{
+				Config: tmpl.NodePoolBasic(t, clusterName, k8sVersionLatest, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNodePoolName, "node_count", "2"),
+					resource.TestCheckResourceAttr(resourceNodePoolName, "autoscaler.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitResourceLKENodePool_basic(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+
+	clusterName := acctest.RandomWithPrefix("tf_test")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { mockapi.PreCheck(t) },
+		ProviderFactories: mockapi.ProviderFactories,
+		CheckDestroy:      server.CheckDestroy("linode_lke_cluster", "lke/clusters"),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + tmpl.NodePoolBasic(t, clusterName, k8sVersionLatest, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceNodePoolName, "cluster_id", resourceClusterName, "id"),
+					resource.TestCheckResourceAttr(resourceNodePoolName, "node_count", "1"),
+					resource.TestCheckResourceAttr(resourceNodePoolName, "nodes.#", "1"),
+					resource.TestCheckResourceAttr(resourceClusterName, "pool.#", "1"),
+					resource.TestCheckResourceAttr(resourceClusterName, "pool.0.type", "g6-standard-1"),
+				),
+			},
+			{
+				Config: server.ProviderConfig() + tmpl.NodePoolBasic(t, clusterName, k8sVersionLatest, 3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNodePoolName, "node_count", "3"),
+					resource.TestCheckResourceAttr(resourceNodePoolName, "nodes.#", "3"),
+					resource.TestCheckResourceAttr(resourceClusterName, "pool.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceNodePoolName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importNodePoolID,
+			},
+		},
+	})
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
 	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,6 +20,7 @@ import (
 	"github.com/linode/terraform-provider-linode/linode/acceptance"
 	"github.com/linode/terraform-provider-linode/linode/helper"
 	"github.com/linode/terraform-provider-linode/linode/lke/tmpl"
+	"github.com/linode/terraform-provider-linode/linode/mockapi"
 )
 
 var k8sVersions []string
@@ -31,6 +35,13 @@ func init() {
 		F:    sweep,
 	})
 
+	// Unit tests run against the mock API without a token, which knows these versions
+	if os.Getenv("LINODE_TOKEN") == "" {
+		k8sVersions = []string{"1.21", "1.22"}
+		k8sVersionLatest, k8sVersionPrevious = "1.22", "1.21"
+		return
+	}
+
 	// Get valid K8s versions for testing
 	client, err := acceptance.GetClientForSweepers()
 	if err != nil {
@@ -211,6 +222,41 @@ func TestAccResourceLKECluster_k8sUpgrade(t *testing.T) {
 	})
 }
 
+func TestAccResourceLKECluster_rollingUpgrade(t *testing.T) {
+	t.Parallel()
+
+	var cluster linodego.LKECluster
+
+	clusterName := acctest.RandomWithPrefix("tf_test")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.PreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CheckLKEClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.RollingUpgrade(t, clusterName, k8sVersionPrevious),
+				Check: resource.ComposeTestCheckFunc(
+					checkLKEExists(&cluster),
+					resource.TestCheckResourceAttr(resourceClusterName, "k8s_version", k8sVersionPrevious),
+					resource.TestCheckResourceAttr(resourceClusterName, "upgrade_strategy.0.rolling", "true"),
+					resource.TestCheckResourceAttr(resourceClusterName, "upgrade_strategy.0.max_unavailable", "2"),
+					resource.TestCheckResourceAttr(resourceClusterName, "upgrade_strategy.0.drain_timeout", "5m"),
+				),
+			},
+			{
+				PreConfig: func() {
+					waitForAllNodesReady(t, &cluster, time.Second*5, time.Minute*5)
+				},
+				Config: tmpl.RollingUpgrade(t, clusterName, k8sVersionLatest),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceClusterName, "k8s_version", k8sVersionLatest),
+					resource.TestCheckResourceAttr(resourceClusterName, "pool.0.count", "3"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceLKECluster_basicUpdates(t *testing.T) {
 	t.Parallel()
 
@@ -328,6 +374,87 @@ func TestAccResourceLKECluster_removeUnmanagedPool(t *testing.T) {
 	})
 }
 
+func TestAccResourceLKECluster_keyedPools(t *testing.T) {
+	t.Parallel()
+
+	var poolIDs map[string]string
+
+	clusterName := acctest.RandomWithPrefix("tf_test")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.PreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CheckLKEClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.KeyedPools(t, clusterName, k8sVersionLatest, []tmpl.PoolTemplateData{
+					{Key: "system", Type: "g6-standard-1", Count: 1},
+					{Key: "apps", Type: "g6-standard-2", Count: 2},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceClusterName, "pool.#", "2"),
+					resource.TestCheckResourceAttr(resourceClusterName, "pool.0.key", "system"),
+					resource.TestCheckResourceAttr(resourceClusterName, "pool.1.key", "apps"),
+					resource.TestCheckResourceAttrPair(resourceClusterName, "pool_ids.system", resourceClusterName, "pool.0.id"),
+					resource.TestCheckResourceAttrPair(resourceClusterName, "pool_ids.apps", resourceClusterName, "pool.1.id"),
+					checkPoolIDs(&poolIDs, nil),
+				),
+			},
+			{
+				// Reordering keyed pools keeps every pool
+				Config: tmpl.KeyedPools(t, clusterName, k8sVersionLatest, []tmpl.PoolTemplateData{
+					{Key: "apps", Type: "g6-standard-2", Count: 2},
+					{Key: "system", Type: "g6-standard-1", Count: 1},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceClusterName, "pool.0.key", "apps"),
+					resource.TestCheckResourceAttrPair(resourceClusterName, "pool_ids.apps", resourceClusterName, "pool.0.id"),
+					checkPoolIDs(&poolIDs, map[string]bool{"apps": true, "system": true}),
+				),
+			},
+			{
+				// Changing the type of a keyed pool replaces only that pool
+				Config: tmpl.KeyedPools(t, clusterName, k8sVersionLatest, []tmpl.PoolTemplateData{
+					{Key: "apps", Type: "g6-standard-4", Count: 2},
+					{Key: "system", Type: "g6-standard-1", Count: 1},
+					{Key: "batch", Type: "g6-standard-2", Count: 1},
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceClusterName, "pool.#", "3"),
+					resource.TestCheckResourceAttr(resourceClusterName, "pool.0.type", "g6-standard-4"),
+					checkPoolIDs(&poolIDs, map[string]bool{"apps": false, "system": true}),
+				),
+			},
+		},
+	})
+}
+
+// checkPoolIDs compares the pool_ids of the cluster with the IDs from the previous check,
+// expecting each of the given keys to have kept or changed its ID, and records them for the next check.
+func checkPoolIDs(previous *map[string]string, kept map[string]bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceClusterName]
+		if !ok {
+			return fmt.Errorf("could not find resource %s", resourceClusterName)
+		}
+
+		current := make(map[string]string)
+		for k, v := range rs.Primary.Attributes {
+			if key := strings.TrimPrefix(k, "pool_ids."); key != k && key != "%" {
+				current[key] = v
+			}
+		}
+
+		for key, keep := range kept {
+			if (current[key] == (*previous)[key]) != keep {
+				return fmt.Errorf("expected pool %q to be kept (%t): %s -> %s", key, keep, (*previous)[key], current[key])
+			}
+		}
+
+		*previous = current
+		return nil
+	}
+}
+
 func TestAccResourceLKECluster_autoScaler(t *testing.T) {
 	t.Parallel()
 
@@ -436,3 +563,160 @@ func TestAccResourceLKECluster_controlPlane(t *testing.T) {
 		},
 	})
 }
+
+func TestUnitResourceLKECluster_basic(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+
+	resName := "linode_lke_cluster.test"
+	clusterName := acctest.RandomWithPrefix("tf_test")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { mockapi.PreCheck(t) },
+		ProviderFactories: mockapi.ProviderFactories,
+		CheckDestroy:      server.CheckDestroy("linode_lke_cluster", "lke/clusters"),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + tmpl.Basic(t, clusterName, k8sVersionLatest),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "label", clusterName),
+					resource.TestCheckResourceAttr(resName, "region", "us-central"),
+					resource.TestCheckResourceAttr(resName, "k8s_version", k8sVersionLatest),
+					resource.TestCheckResourceAttr(resName, "pool.#", "1"),
+					resource.TestCheckResourceAttr(resName, "pool.0.nodes.#", "3"),
+					resource.TestCheckResourceAttrSet(resName, "kubeconfig"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceLKECluster_latestVersion(t *testing.T) {
+	t.Parallel()
+
+	clusterName := acctest.RandomWithPrefix("tf_test")
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.PreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CheckLKEClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.Basic(t, clusterName, "latest"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceClusterName, "k8s_version", "latest"),
+					resource.TestCheckResourceAttr(resourceClusterName, "resolved_k8s_version", k8sVersionLatest),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitResourceLKECluster_versionConstraint(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+
+	clusterName := acctest.RandomWithPrefix("tf_test")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { mockapi.PreCheck(t) },
+		ProviderFactories: mockapi.ProviderFactories,
+		CheckDestroy:      server.CheckDestroy("linode_lke_cluster", "lke/clusters"),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + tmpl.Basic(t, clusterName, k8sVersionPrevious),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceClusterName, "k8s_version", k8sVersionPrevious),
+					resource.TestCheckResourceAttr(resourceClusterName, "resolved_k8s_version", k8sVersionPrevious),
+				),
+			},
+			{
+				// Upgrades the cluster to the latest version satisfying the constraint
+				Config: server.ProviderConfig() + tmpl.Basic(t, clusterName, "~> "+k8sVersionPrevious),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceClusterName, "k8s_version", "~> "+k8sVersionPrevious),
+					resource.TestCheckResourceAttr(resourceClusterName, "resolved_k8s_version", k8sVersionLatest),
+				),
+			},
+			{
+				// Resolves to the same version, so the cluster is not upgraded again
+				Config: server.ProviderConfig() + tmpl.Basic(t, clusterName, "latest"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceClusterName, "k8s_version", "latest"),
+					resource.TestCheckResourceAttr(resourceClusterName, "resolved_k8s_version", k8sVersionLatest),
+				),
+			},
+			{
+				Config:      server.ProviderConfig() + tmpl.Basic(t, clusterName, "~> 99.0"),
+				ExpectError: regexp.MustCompile("no available Kubernetes version satisfies"),
+			},
+		},
+	})
+}
+
+func TestUnitResourceLKECluster_resumeRollingUpgrade(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+
+	clusterName := acctest.RandomWithPrefix("tf_test")
+
+	var poolPath string
+	var created, failed []interface{}
+
+	poolNodes := func() []interface{} {
+		nodes, _ := server.Get(poolPath)["nodes"].([]interface{})
+		return nodes
+	}
+
+	instanceID := func(node interface{}) interface{} {
+		return node.(map[string]interface{})["instance_id"]
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { mockapi.PreCheck(t) },
+		ProviderFactories: mockapi.ProviderFactories,
+		CheckDestroy:      server.CheckDestroy("linode_lke_cluster", "lke/clusters"),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + tmpl.RollingUpgrade(t, clusterName, k8sVersionPrevious),
+				Check: func(s *terraform.State) error {
+					rs := s.RootModule().Resources[resourceClusterName]
+					poolPath = fmt.Sprintf("lke/clusters/%s/pools/%s", rs.Primary.ID, rs.Primary.Attributes["pool.0.id"])
+					created = poolNodes()
+					return nil
+				},
+			},
+			{
+				// The second node of the first batch fails to recycle
+				PreConfig: func() {
+					server.Fail(http.MethodPost, "lke/clusters/*/nodes/*/recycle", 1, http.StatusBadRequest)
+				},
+				Config:      server.ProviderConfig() + tmpl.RollingUpgrade(t, clusterName, k8sVersionLatest),
+				ExpectError: regexp.MustCompile("failed to recycle LKE Cluster"),
+			},
+			{
+				PreConfig: func() {
+					failed = poolNodes()
+				},
+				Config: server.ProviderConfig() + tmpl.RollingUpgrade(t, clusterName, k8sVersionLatest),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceClusterName, "resolved_k8s_version", k8sVersionLatest),
+					resource.TestCheckResourceAttr(resourceClusterName, "upgrade_pending", "false"),
+					func(s *terraform.State) error {
+						if instanceID(failed[0]) == instanceID(created[0]) {
+							return fmt.Errorf("expected the first node to be recycled before the failure")
+						}
+
+						// Only the nodes left on the previous version are recycled when resuming
+						for i, node := range poolNodes() {
+							if resumed := instanceID(node) != instanceID(failed[i]); resumed != (i > 0) {
+								return fmt.Errorf("expected node %d to be recycled when resuming: %t", i, i > 0)
+							}
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
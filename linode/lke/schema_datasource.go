@@ -43,6 +43,27 @@ var dataSourceSchema = map[string]*schema.Schema{
 		Sensitive:   true,
 		Description: "The Base64-encoded Kubeconfig for the cluster.",
 	},
+	"kubeconfig_host": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The Kubernetes API server URL of the kubeconfig's current context.",
+	},
+	"kubeconfig_cluster_ca_certificate": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The PEM-encoded CA certificate of the Kubernetes API server of the kubeconfig's current context.",
+	},
+	"kubeconfig_token": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "The token of the user of the kubeconfig's current context.",
+	},
+	"kubeconfig_context": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The name of the kubeconfig's current context.",
+	},
 	"status": {
 		Type:        schema.TypeString,
 		Computed:    true,
@@ -0,0 +1,49 @@
+package lke
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+var dataSourceKubeconfigSchema = map[string]*schema.Schema{
+	"cluster_id": {
+		Type:        schema.TypeInt,
+		Required:    true,
+		Description: "The ID of the LKE Cluster.",
+	},
+	"context_name": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "The name to give the current context of the kubeconfig.",
+	},
+	"user_name": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "The name to give the user of the current context of the kubeconfig.",
+	},
+
+	"kubeconfig": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "The Base64-encoded kubeconfig for the cluster.",
+	},
+	"host": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The Kubernetes API server URL of the kubeconfig's current context.",
+	},
+	"cluster_ca_certificate": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The PEM-encoded CA certificate of the Kubernetes API server of the kubeconfig's current context.",
+	},
+	"token": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "The token of the user of the kubeconfig's current context.",
+	},
+	"context": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The name of the kubeconfig's current context.",
+	},
+}
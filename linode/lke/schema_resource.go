@@ -3,6 +3,7 @@ package lke
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/linode/terraform-provider-linode/linode/helper"
 )
 
 var resourceSchema = map[string]*schema.Schema{
@@ -12,10 +13,16 @@ var resourceSchema = map[string]*schema.Schema{
 		Description: "The unique label for the cluster.",
 	},
 	"k8s_version": {
-		Type:     schema.TypeString,
-		Required: true,
-		Description: "The desired Kubernetes version for this Kubernetes cluster in the format of <major>.<minor>. " +
-			"The latest supported patch version will be deployed.",
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validateK8sVersion,
+		Description: "The desired Kubernetes version for this Kubernetes cluster in the format of <major>.<minor>, " +
+			"`latest`, or a version constraint such as `~> 1.23`. The latest supported patch version will be deployed.",
+	},
+	"resolved_k8s_version": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The Kubernetes version of the cluster, as resolved from k8s_version.",
 	},
 	"tags": {
 		Type:        schema.TypeSet,
@@ -23,6 +30,7 @@ var resourceSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "An array of tags applied to this object. Tags are for organizational purposes only.",
 	},
+	"tags_all": helper.TagsAllSchema(),
 	"region": {
 		Type:        schema.TypeString,
 		Required:    true,
@@ -41,6 +49,27 @@ var resourceSchema = map[string]*schema.Schema{
 		Sensitive:   true,
 		Description: "The Base64-encoded Kubeconfig for the cluster.",
 	},
+	"kubeconfig_host": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The Kubernetes API server URL of the kubeconfig's current context.",
+	},
+	"kubeconfig_cluster_ca_certificate": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The PEM-encoded CA certificate of the Kubernetes API server of the kubeconfig's current context.",
+	},
+	"kubeconfig_token": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "The token of the user of the kubeconfig's current context.",
+	},
+	"kubeconfig_context": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The name of the kubeconfig's current context.",
+	},
 	"status": {
 		Type:        schema.TypeString,
 		Computed:    true,
@@ -55,6 +84,12 @@ var resourceSchema = map[string]*schema.Schema{
 					Computed:    true,
 					Description: "The ID of the Node Pool.",
 				},
+				"key": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "A unique key identifying the Node Pool. Keyed pools keep the same Node Pool when " +
+						"pools are reordered, and are replaced by a new Node Pool when their type changes.",
+				},
 				"count": {
 					Type:         schema.TypeInt,
 					ValidateFunc: validation.IntAtLeast(1),
@@ -117,6 +152,56 @@ var resourceSchema = map[string]*schema.Schema{
 		Required:    true,
 		Description: "A node pool in the cluster.",
 	},
+	"pool_ids": {
+		Type:        schema.TypeMap,
+		Elem:        &schema.Schema{Type: schema.TypeInt},
+		Computed:    true,
+		Description: "The IDs of the keyed Node Pools of the cluster, by key.",
+	},
+	"ignore_unmanaged_pools": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "Whether to ignore node pools that are not declared in this resource, " +
+			"such as those managed by linode_lke_node_pool resources.",
+	},
+	"upgrade_strategy": {
+		Type:     schema.TypeList,
+		MaxItems: 1,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"rolling": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+					Description: "Whether to cordon, drain and recycle the nodes one at a time, " +
+						"rather than recycling all nodes of the cluster at once.",
+				},
+				"max_unavailable": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      1,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "The maximum number of nodes of a pool to recycle at a time during a rolling upgrade.",
+				},
+				"drain_timeout": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "10m",
+					ValidateFunc: helper.ValidateDuration,
+					Description:  "The maximum time to wait for the pods of a node to be evicted before it is recycled or deleted.",
+				},
+			},
+		},
+		Description: "Defines how the nodes of the cluster are recycled when its Kubernetes version changes.",
+	},
+	"upgrade_pending": {
+		Type:     schema.TypeBool,
+		Computed: true,
+		Description: "Whether the nodes of the cluster have not all been recycled since its Kubernetes version changed. " +
+			"The next apply resumes the upgrade.",
+	},
 	"control_plane": {
 		Type:     schema.TypeList,
 		MaxItems: 1,
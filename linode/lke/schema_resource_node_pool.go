@@ -0,0 +1,77 @@
+package lke
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var resourceNodePoolSchema = map[string]*schema.Schema{
+	"cluster_id": {
+		Type:        schema.TypeInt,
+		Required:    true,
+		ForceNew:    true,
+		Description: "The ID of the LKE Cluster this Node Pool belongs to.",
+	},
+	"type": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "A Linode Type for all of the nodes in the Node Pool.",
+	},
+	"node_count": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+		AtLeastOneOf: []string{"node_count", "autoscaler"},
+		Description: "The number of nodes in the Node Pool. " +
+			"Defaults to the autoscaler's minimum when an autoscaler is specified.",
+	},
+	"autoscaler": {
+		Type:     schema.TypeList,
+		MaxItems: 1,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"min": {
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "The minimum number of nodes to autoscale to.",
+					Required:     true,
+				},
+				"max": {
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "The maximum number of nodes to autoscale to.",
+					Required:     true,
+				},
+			},
+		},
+		Description: "When specified, the number of nodes autoscales within " +
+			"the defined minimum and maximum values.",
+	},
+	"nodes": {
+		Type: schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeString,
+					Description: "The ID of the node.",
+					Computed:    true,
+				},
+				"instance_id": {
+					Type:        schema.TypeInt,
+					Description: "The ID of the underlying Linode instance.",
+					Computed:    true,
+				},
+				"status": {
+					Type:        schema.TypeString,
+					Description: "The status of the node.",
+					Computed:    true,
+				},
+			},
+		},
+		Computed:    true,
+		Description: "The nodes in the Node Pool.",
+	},
+}
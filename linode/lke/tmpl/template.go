@@ -10,6 +10,14 @@ type TemplateData struct {
 	Label            string
 	K8sVersion       string
 	HighAvailability bool
+	PoolCount        int
+	Pools            []PoolTemplateData
+}
+
+type PoolTemplateData struct {
+	Key   string
+	Type  string
+	Count int
 }
 
 func Basic(t *testing.T, name, version string) string {
@@ -30,11 +38,21 @@ func ManyPools(t *testing.T, name, k8sVersion string) string {
 		})
 }
 
+func RollingUpgrade(t *testing.T, name, version string) string {
+	return acceptance.ExecuteTemplate(t,
+		"lke_cluster_rolling_upgrade", TemplateData{Label: name, K8sVersion: version})
+}
+
 func ComplexPools(t *testing.T, name, version string) string {
 	return acceptance.ExecuteTemplate(t,
 		"lke_cluster_complex_pools", TemplateData{Label: name, K8sVersion: version})
 }
 
+func KeyedPools(t *testing.T, name, version string, pools []PoolTemplateData) string {
+	return acceptance.ExecuteTemplate(t,
+		"lke_cluster_keyed_pools", TemplateData{Label: name, K8sVersion: version, Pools: pools})
+}
+
 func Autoscaler(t *testing.T, name, version string) string {
 	return acceptance.ExecuteTemplate(t,
 		"lke_cluster_autoscaler", TemplateData{Label: name, K8sVersion: version})
@@ -65,7 +83,22 @@ func DataAutoscaler(t *testing.T, name, version string) string {
 		"lke_cluster_data_autoscaler", TemplateData{Label: name, K8sVersion: version})
 }
 
+func DataKubeconfig(t *testing.T, name, version string) string {
+	return acceptance.ExecuteTemplate(t,
+		"lke_cluster_data_kubeconfig", TemplateData{Label: name, K8sVersion: version})
+}
+
 func DataControlPlane(t *testing.T, name, version string, ha bool) string {
 	return acceptance.ExecuteTemplate(t,
 		"lke_cluster_data_control_plane", TemplateData{Label: name, HighAvailability: ha, K8sVersion: version})
 }
+
+func NodePoolBasic(t *testing.T, name, version string, count int) string {
+	return acceptance.ExecuteTemplate(t,
+		"lke_node_pool_basic", TemplateData{Label: name, K8sVersion: version, PoolCount: count})
+}
+
+func NodePoolAutoscaler(t *testing.T, name, version string) string {
+	return acceptance.ExecuteTemplate(t,
+		"lke_node_pool_autoscaler", TemplateData{Label: name, K8sVersion: version})
+}
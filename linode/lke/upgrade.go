@@ -0,0 +1,394 @@
+package lke
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/linode/linodego"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// UpgradeStrategy configures how the nodes of an LKE cluster are recycled when its Kubernetes version changes.
+type UpgradeStrategy struct {
+	Rolling        bool
+	MaxUnavailable int
+	DrainTimeout   time.Duration
+}
+
+func expandUpgradeStrategy(strategies []interface{}) (UpgradeStrategy, error) {
+	strategy := UpgradeStrategy{MaxUnavailable: 1}
+
+	if len(strategies) < 1 || strategies[0] == nil {
+		return strategy, nil
+	}
+
+	spec := strategies[0].(map[string]interface{})
+
+	drainTimeout, err := time.ParseDuration(spec["drain_timeout"].(string))
+	if err != nil {
+		return strategy, fmt.Errorf("failed to parse upgrade_strategy drain_timeout: %s", err)
+	}
+
+	strategy.Rolling = spec["rolling"].(bool)
+	strategy.MaxUnavailable = spec["max_unavailable"].(int)
+	strategy.DrainTimeout = drainTimeout
+
+	return strategy, nil
+}
+
+// RollingRecycleLKECluster recycles the nodes of an LKE cluster one pool at a time, and at most
+// strategy.MaxUnavailable nodes of a pool at a time. Each node is cordoned and drained through the eviction API,
+// which respects PodDisruptionBudgets, before it is recycled, and the next nodes are only recycled once
+// the replacements are ready. Nodes that already run k8sVersion are left as they are, so an upgrade that failed
+// partway through resumes with the remaining nodes.
+func RollingRecycleLKECluster(ctx context.Context, client *linodego.Client, kubeClient kubernetes.Interface,
+	clusterID int, pools []linodego.LKENodePool, k8sVersion string, strategy UpgradeStrategy,
+	pollInterval time.Duration) error {
+	batchSize := strategy.MaxUnavailable
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	for _, pool := range pools {
+		nodes, err := nodesToUpgrade(ctx, kubeClient, clusterID, pool.Linodes, k8sVersion)
+		if err != nil {
+			return err
+		}
+
+		for start := 0; start < len(nodes); start += batchSize {
+			end := start + batchSize
+			if end > len(nodes) {
+				end = len(nodes)
+			}
+			batch := nodes[start:end]
+
+			for _, node := range batch {
+				if err := cordonNode(ctx, kubeClient, kubernetesNodeName(clusterID, node.ID)); err != nil {
+					return err
+				}
+			}
+
+			recycled := make(map[int]bool, len(batch))
+			for _, node := range batch {
+				name := kubernetesNodeName(clusterID, node.ID)
+				if err := drainNode(ctx, kubeClient, name, strategy.DrainTimeout, pollInterval); err != nil {
+					return err
+				}
+
+				if err := recycleLKENode(ctx, client, clusterID, node.ID); err != nil {
+					return err
+				}
+				recycled[node.InstanceID] = true
+
+				log.Printf("[DEBUG] recycled LKE Cluster (%d) Pool (%d) Node (%s)", clusterID, pool.ID, node.ID)
+			}
+
+			if err := waitForNodeReplacements(
+				ctx, client, kubeClient, clusterID, pool.ID, recycled, pollInterval); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// RecycleOutdatedLKENodes recycles the nodes of an LKE cluster that do not run k8sVersion yet, all at once
+// and without draining them, and waits for their replacements to be ready. It resumes an upgrade that
+// recycled the whole cluster and failed partway through.
+func RecycleOutdatedLKENodes(ctx context.Context, client *linodego.Client, kubeClient kubernetes.Interface,
+	clusterID int, pools []linodego.LKENodePool, k8sVersion string, pollInterval time.Duration) error {
+	recycled := make(map[int]map[int]bool, len(pools))
+
+	for _, pool := range pools {
+		nodes, err := nodesToUpgrade(ctx, kubeClient, clusterID, pool.Linodes, k8sVersion)
+		if err != nil {
+			return err
+		}
+
+		for _, node := range nodes {
+			if err := recycleLKENode(ctx, client, clusterID, node.ID); err != nil {
+				return err
+			}
+
+			if recycled[pool.ID] == nil {
+				recycled[pool.ID] = make(map[int]bool, len(nodes))
+			}
+			recycled[pool.ID][node.InstanceID] = true
+
+			log.Printf("[DEBUG] recycled LKE Cluster (%d) Pool (%d) Node (%s)", clusterID, pool.ID, node.ID)
+		}
+	}
+
+	for _, pool := range pools {
+		if recycled[pool.ID] == nil {
+			continue
+		}
+
+		if err := waitForNodeReplacements(
+			ctx, client, kubeClient, clusterID, pool.ID, recycled[pool.ID], pollInterval); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DrainAndDeleteLKENodePool cordons every node of a pool, drains them one at a time through the eviction API,
+// which respects PodDisruptionBudgets, and then deletes the pool. The pool is kept if a node can not be drained.
+func DrainAndDeleteLKENodePool(ctx context.Context, client *linodego.Client, kubeClient kubernetes.Interface,
+	clusterID int, pool linodego.LKENodePool, drainTimeout, pollInterval time.Duration) error {
+	// Every node is cordoned first so the evicted pods are not scheduled on the other nodes of the pool
+	for _, node := range pool.Linodes {
+		if err := cordonNode(ctx, kubeClient, kubernetesNodeName(clusterID, node.ID)); err != nil {
+			return err
+		}
+	}
+
+	for _, node := range pool.Linodes {
+		name := kubernetesNodeName(clusterID, node.ID)
+		if err := drainNode(ctx, kubeClient, name, drainTimeout, pollInterval); err != nil {
+			return fmt.Errorf("failed to drain LKE Cluster (%d) Pool (%d) before deleting it: %s",
+				clusterID, pool.ID, err)
+		}
+	}
+
+	if err := client.DeleteLKENodePool(ctx, clusterID, pool.ID); err != nil {
+		return fmt.Errorf("failed to delete LKE Cluster %d Pool %d: %s", clusterID, pool.ID, err)
+	}
+
+	return nil
+}
+
+// nodesToUpgrade returns the nodes whose Kubernetes node does not run the given version yet.
+func nodesToUpgrade(ctx context.Context, kubeClient kubernetes.Interface, clusterID int,
+	nodes []linodego.LKENodePoolLinode, k8sVersion string) ([]linodego.LKENodePoolLinode, error) {
+	var result []linodego.LKENodePoolLinode
+
+	for _, node := range nodes {
+		name := kubernetesNodeName(clusterID, node.ID)
+
+		kubeNode, err := kubeClient.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get Kubernetes node %s: %s", name, err)
+		}
+
+		if err == nil && kubeletRunsVersion(kubeNode, k8sVersion) {
+			log.Printf("[DEBUG] Kubernetes node %s already runs %s and will not be recycled", name, k8sVersion)
+			continue
+		}
+
+		result = append(result, node)
+	}
+
+	return result, nil
+}
+
+// kubeletRunsVersion returns whether the kubelet of a node runs a version, such as v1.23.4 for 1.23.
+func kubeletRunsVersion(node *corev1.Node, k8sVersion string) bool {
+	if k8sVersion == "" {
+		return false
+	}
+
+	return strings.HasPrefix(node.Status.NodeInfo.KubeletVersion+".", "v"+k8sVersion+".")
+}
+
+// kubernetesNodeName returns the name of the Kubernetes node of an LKE node.
+func kubernetesNodeName(clusterID int, nodeID string) string {
+	return fmt.Sprintf("lke%d-%s", clusterID, nodeID)
+}
+
+func cordonNode(ctx context.Context, kubeClient kubernetes.Interface, name string) error {
+	node, err := kubeClient.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Printf("[WARN] Kubernetes node %s was not found and will not be drained", name)
+			return nil
+		}
+		return fmt.Errorf("failed to get Kubernetes node %s: %s", name, err)
+	}
+
+	if node.Spec.Unschedulable {
+		return nil
+	}
+
+	node.Spec.Unschedulable = true
+	if _, err := kubeClient.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to cordon Kubernetes node %s: %s", name, err)
+	}
+
+	return nil
+}
+
+// drainNode evicts the pods of a node until none are left, retrying evictions that are refused
+// because of a PodDisruptionBudget until the timeout.
+func drainNode(ctx context.Context, kubeClient kubernetes.Interface, name string,
+	timeout, pollInterval time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		pods, err := podsToEvict(ctx, kubeClient, name)
+		if err != nil {
+			return err
+		}
+
+		if len(pods) == 0 {
+			log.Printf("[DEBUG] finished draining Kubernetes node %s", name)
+			return nil
+		}
+
+		for _, pod := range pods {
+			err := kubeClient.CoreV1().Pods(pod.Namespace).Evict(ctx, &policyv1beta1.Eviction{
+				ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+			})
+
+			switch {
+			case err == nil, apierrors.IsNotFound(err):
+			case apierrors.IsTooManyRequests(err):
+				log.Printf("[DEBUG] eviction of pod %s/%s is blocked by a PodDisruptionBudget", pod.Namespace, pod.Name)
+			default:
+				return fmt.Errorf("failed to evict pod %s/%s from Kubernetes node %s: %s",
+					pod.Namespace, pod.Name, name, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out draining Kubernetes node %s: %d pods were not evicted", name, len(pods))
+		case <-ticker.C:
+		}
+	}
+}
+
+// podsToEvict returns the pods of a node that must be evicted before it is recycled,
+// leaving out pods of DaemonSets, mirror pods and pods that have finished.
+func podsToEvict(ctx context.Context, kubeClient kubernetes.Interface, name string) ([]corev1.Pod, error) {
+	pods, err := kubeClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", name).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the pods of Kubernetes node %s: %s", name, err)
+	}
+
+	var result []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != name || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			continue
+		}
+
+		if controller := metav1.GetControllerOf(&pod); controller != nil && controller.Kind == "DaemonSet" {
+			continue
+		}
+
+		result = append(result, pod)
+	}
+
+	return result, nil
+}
+
+func recycleLKENode(ctx context.Context, client *linodego.Client, clusterID int, nodeID string) error {
+	// linodego does not support recycling a single node, so the request is built directly
+	endpoint, err := client.LKEClusters.Endpoint()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.R(ctx).Post(fmt.Sprintf("%s/%d/nodes/%s/recycle", endpoint, clusterID, nodeID))
+	if err == nil && resp.IsError() {
+		err = linodego.NewError(resp)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to recycle LKE Cluster (%d) Node (%s): %s", clusterID, nodeID, err)
+	}
+
+	return nil
+}
+
+// waitForNodeReplacements waits until none of the recycled instances remain in a pool,
+// and every node of the pool is ready in both LKE and Kubernetes.
+func waitForNodeReplacements(ctx context.Context, client *linodego.Client, kubeClient kubernetes.Interface,
+	clusterID, poolID int, recycled map[int]bool, pollInterval time.Duration) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the recycled nodes of LKE Cluster (%d) Pool (%d) to be replaced",
+				clusterID, poolID)
+
+		case <-ticker.C:
+			ready, err := nodePoolReplaced(ctx, client, kubeClient, clusterID, poolID, recycled)
+			if err != nil {
+				return err
+			}
+
+			if ready {
+				log.Printf("[DEBUG] finished waiting for the recycled nodes of LKE Cluster (%d) Pool (%d) to be ready",
+					clusterID, poolID)
+				return nil
+			}
+		}
+	}
+}
+
+func nodePoolReplaced(ctx context.Context, client *linodego.Client, kubeClient kubernetes.Interface,
+	clusterID, poolID int, recycled map[int]bool) (bool, error) {
+	pool, err := client.GetLKENodePool(ctx, clusterID, poolID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get LKE Cluster (%d) Pool (%d): %s", clusterID, poolID, err)
+	}
+
+	if len(pool.Linodes) < pool.Count {
+		return false, nil
+	}
+
+	for _, node := range pool.Linodes {
+		if recycled[node.InstanceID] || node.Status != linodego.LKELinodeReady {
+			return false, nil
+		}
+	}
+
+	for _, node := range pool.Linodes {
+		kubeNode, err := kubeClient.CoreV1().Nodes().Get(ctx, kubernetesNodeName(clusterID, node.ID), metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to get Kubernetes node %s: %s", kubernetesNodeName(clusterID, node.ID), err)
+		}
+
+		if !isKubernetesNodeReady(kubeNode) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func isKubernetesNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,372 @@
+package lke_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+	"github.com/linode/terraform-provider-linode/linode/lke"
+	"github.com/linode/terraform-provider-linode/linode/mockapi"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var nodesResource = schema.GroupVersionResource{Version: "v1", Resource: "nodes"}
+
+// testUpgradeCluster creates a cluster with a single pool of nodes on a fake API,
+// and a fake Kubernetes cluster with a pod and a DaemonSet pod on each node.
+func testUpgradeCluster(t *testing.T, count int) (*linodego.Client, *fake.Clientset, *linodego.LKECluster) {
+	t.Helper()
+
+	server := mockapi.NewServer()
+	t.Cleanup(server.Close)
+
+	client := (&helper.Config{AccessToken: "mock", APIURL: server.URL, APIVersion: "v4"}).Client()
+
+	cluster, err := client.CreateLKECluster(context.Background(), linodego.LKEClusterCreateOptions{
+		Label: "upgrade", Region: "us-east", K8sVersion: "1.22",
+		NodePools: []linodego.LKENodePoolCreateOptions{{Type: "g6-standard-1", Count: count}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pools, err := client.ListLKENodePools(context.Background(), cluster.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var objects []runtime.Object
+	for _, node := range pools[0].Linodes {
+		name := fmt.Sprintf("lke%d-%s", cluster.ID, node.ID)
+		objects = append(objects, readyNode(name),
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-" + node.ID, Namespace: "default"},
+				Spec:       corev1.PodSpec{NodeName: name},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "proxy-" + node.ID, Namespace: "kube-system",
+					OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "proxy", Controller: boolPtr(true)}},
+				},
+				Spec: corev1.PodSpec{NodeName: name},
+			})
+	}
+
+	kubeClient := fake.NewSimpleClientset(objects...)
+
+	// Replacement nodes join the cluster as soon as they are recycled
+	kubeClient.PrependReactor("get", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		name := action.(k8stesting.GetAction).GetName()
+		if _, err := kubeClient.Tracker().Get(nodesResource, "", name); apierrors.IsNotFound(err) {
+			return true, readyNode(name), nil
+		}
+		return false, nil, nil
+	})
+
+	return &client, kubeClient, cluster
+}
+
+func readyNode(name string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// handleEvictions evicts pods from the fake cluster, refusing the first eviction of each pod
+// as if it were blocked by a PodDisruptionBudget when blockFirst is set.
+func handleEvictions(kubeClient *fake.Clientset, blockFirst bool) *sync.Map {
+	evicted := &sync.Map{}
+
+	kubeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+
+		name := action.(k8stesting.CreateAction).GetObject().(metav1.Object).GetName()
+		if _, attempted := evicted.LoadOrStore(name, false); !attempted && blockFirst {
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 1)
+		}
+
+		evicted.Store(name, true)
+		return true, nil, kubeClient.Tracker().Delete(
+			schema.GroupVersionResource{Version: "v1", Resource: "pods"}, action.GetNamespace(), name)
+	})
+
+	return evicted
+}
+
+func TestRollingRecycleLKECluster(t *testing.T) {
+	client, kubeClient, cluster := testUpgradeCluster(t, 3)
+	evicted := handleEvictions(kubeClient, true)
+
+	ctx := context.Background()
+
+	pools, err := client.ListLKENodePools(ctx, cluster.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := lke.RollingRecycleLKECluster(ctx, client, kubeClient, cluster.ID, pools, "1.23", lke.UpgradeStrategy{
+		Rolling: true, MaxUnavailable: 2, DrainTimeout: time.Minute,
+	}, 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	recycledPool, err := client.GetLKENodePool(ctx, cluster.ID, pools[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(recycledPool.Linodes) != 3 {
+		t.Fatalf("expected 3 nodes after the recycle, got %d", len(recycledPool.Linodes))
+	}
+
+	for i, node := range pools[0].Linodes {
+		if recycledPool.Linodes[i].InstanceID == node.InstanceID {
+			t.Errorf("expected node %s to be recycled", node.ID)
+		}
+
+		name := fmt.Sprintf("lke%d-%s", cluster.ID, node.ID)
+
+		kubeNode, err := kubeClient.Tracker().Get(nodesResource, "", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !kubeNode.(*corev1.Node).Spec.Unschedulable {
+			t.Errorf("expected node %s to be cordoned", name)
+		}
+
+		if done, _ := evicted.Load("web-" + node.ID); done != true {
+			t.Errorf("expected pod web-%s to be evicted", node.ID)
+		}
+
+		if _, ok := evicted.Load("proxy-" + node.ID); ok {
+			t.Errorf("expected DaemonSet pod proxy-%s not to be evicted", node.ID)
+		}
+	}
+
+	// Each node is cordoned exactly once
+	var cordons int
+	for _, action := range kubeClient.Actions() {
+		if action.GetVerb() == "update" && action.GetResource().Resource == "nodes" {
+			cordons++
+		}
+	}
+
+	if cordons != 3 {
+		t.Errorf("expected 3 nodes to be cordoned, got %d", cordons)
+	}
+}
+
+func TestRollingRecycleLKEClusterDrainTimeout(t *testing.T) {
+	client, kubeClient, cluster := testUpgradeCluster(t, 1)
+
+	// Every eviction is blocked by a PodDisruptionBudget
+	kubeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 1)
+	})
+
+	ctx := context.Background()
+
+	pools, err := client.ListLKENodePools(ctx, cluster.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = lke.RollingRecycleLKECluster(ctx, client, kubeClient, cluster.ID, pools, "1.23", lke.UpgradeStrategy{
+		Rolling: true, MaxUnavailable: 1, DrainTimeout: 50 * time.Millisecond,
+	}, 10*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "timed out draining") {
+		t.Fatalf("expected a drain timeout, got %v", err)
+	}
+
+	// A node that could not be drained must not be recycled
+	pool, err := client.GetLKENodePool(ctx, cluster.ID, pools[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pool.Linodes[0].InstanceID != pools[0].Linodes[0].InstanceID {
+		t.Error("expected the node not to be recycled")
+	}
+}
+
+func TestRollingRecycleLKEClusterResume(t *testing.T) {
+	client, kubeClient, cluster := testUpgradeCluster(t, 3)
+	handleEvictions(kubeClient, false)
+
+	ctx := context.Background()
+
+	pools, err := client.ListLKENodePools(ctx, cluster.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first node was recycled before a previous upgrade failed
+	upgraded := readyNode(fmt.Sprintf("lke%d-%s", cluster.ID, pools[0].Linodes[0].ID))
+	upgraded.Status.NodeInfo.KubeletVersion = "v1.23.4"
+	if err := kubeClient.Tracker().Update(nodesResource, upgraded, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := lke.RollingRecycleLKECluster(ctx, client, kubeClient, cluster.ID, pools, "1.23", lke.UpgradeStrategy{
+		Rolling: true, MaxUnavailable: 1, DrainTimeout: time.Minute,
+	}, 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	recycledPool, err := client.GetLKENodePool(ctx, cluster.ID, pools[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, node := range pools[0].Linodes {
+		if recycled := recycledPool.Linodes[i].InstanceID != node.InstanceID; recycled != (i > 0) {
+			t.Errorf("expected node %s to be recycled: %t", node.ID, i > 0)
+		}
+	}
+}
+
+func TestRecycleOutdatedLKENodes(t *testing.T) {
+	client, kubeClient, cluster := testUpgradeCluster(t, 3)
+	evicted := handleEvictions(kubeClient, false)
+
+	ctx := context.Background()
+
+	pools, err := client.ListLKENodePools(ctx, cluster.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first node was replaced before a previous upgrade failed
+	upgraded := readyNode(fmt.Sprintf("lke%d-%s", cluster.ID, pools[0].Linodes[0].ID))
+	upgraded.Status.NodeInfo.KubeletVersion = "v1.23.4"
+	if err := kubeClient.Tracker().Update(nodesResource, upgraded, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := lke.RecycleOutdatedLKENodes(
+		ctx, client, kubeClient, cluster.ID, pools, "1.23", 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	recycledPool, err := client.GetLKENodePool(ctx, cluster.ID, pools[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, node := range pools[0].Linodes {
+		if recycled := recycledPool.Linodes[i].InstanceID != node.InstanceID; recycled != (i > 0) {
+			t.Errorf("expected node %s to be recycled: %t", node.ID, i > 0)
+		}
+	}
+
+	// The nodes are not drained unless the upgrade is rolling
+	evicted.Range(func(name, _ interface{}) bool {
+		t.Errorf("expected pod %s not to be evicted", name)
+		return true
+	})
+}
+
+func TestDrainAndDeleteLKENodePool(t *testing.T) {
+	client, kubeClient, cluster := testUpgradeCluster(t, 2)
+	evicted := handleEvictions(kubeClient, false)
+
+	ctx := context.Background()
+
+	pools, err := client.ListLKENodePools(ctx, cluster.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Pods must be evicted from cordoned nodes while the pool still exists
+	var evictedBeforeDelete int
+	kubeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+
+		if _, err := client.GetLKENodePool(ctx, cluster.ID, pools[0].ID); err != nil {
+			t.Errorf("expected the pool to exist while its nodes are drained: %s", err)
+		}
+
+		for _, node := range pools[0].Linodes {
+			obj, err := kubeClient.Tracker().Get(nodesResource, "", fmt.Sprintf("lke%d-%s", cluster.ID, node.ID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !obj.(*corev1.Node).Spec.Unschedulable {
+				t.Errorf("expected node %s to be cordoned before any pod is evicted", node.ID)
+			}
+		}
+
+		evictedBeforeDelete++
+		return false, nil, nil
+	})
+
+	if err := lke.DrainAndDeleteLKENodePool(
+		ctx, client, kubeClient, cluster.ID, pools[0], time.Minute, 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	if evictedBeforeDelete != 2 {
+		t.Errorf("expected 2 evictions before the pool was deleted, got %d", evictedBeforeDelete)
+	}
+
+	for _, node := range pools[0].Linodes {
+		if done, _ := evicted.Load("web-" + node.ID); done != true {
+			t.Errorf("expected pod web-%s to be evicted", node.ID)
+		}
+	}
+
+	_, err = client.GetLKENodePool(ctx, cluster.ID, pools[0].ID)
+	if apiErr, ok := err.(*linodego.Error); !ok || apiErr.Code != 404 {
+		t.Errorf("expected the pool to be deleted, got %v", err)
+	}
+}
+
+func TestDrainAndDeleteLKENodePoolDrainTimeout(t *testing.T) {
+	client, kubeClient, cluster := testUpgradeCluster(t, 1)
+
+	// Every eviction is blocked by a PodDisruptionBudget
+	kubeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 1)
+	})
+
+	ctx := context.Background()
+
+	pools, err := client.ListLKENodePools(ctx, cluster.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = lke.DrainAndDeleteLKENodePool(
+		ctx, client, kubeClient, cluster.ID, pools[0], 50*time.Millisecond, 10*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "timed out draining") {
+		t.Fatalf("expected a drain timeout, got %v", err)
+	}
+
+	// A pool whose nodes could not be drained must not be deleted
+	if _, err := client.GetLKENodePool(ctx, cluster.ID, pools[0].ID); err != nil {
+		t.Errorf("expected the pool to be kept: %s", err)
+	}
+}
@@ -0,0 +1,135 @@
+package lke
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/linode/linodego"
+)
+
+// latestK8sVersion is the k8s_version that resolves to the latest available Kubernetes version.
+const latestK8sVersion = "latest"
+
+// isK8sVersionConstraint returns whether a k8s_version must be resolved against the available versions,
+// rather than being a version itself.
+func isK8sVersionConstraint(expr string) bool {
+	return expr == latestK8sVersion || strings.IndexAny(strings.TrimSpace(expr), "~<>=!") == 0
+}
+
+func parseK8sVersionConstraint(expr string) (version.Constraints, error) {
+	if expr == latestK8sVersion {
+		return version.Constraints{}, nil
+	}
+
+	constraints, err := version.NewConstraint(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Kubernetes version constraint %q: %s", expr, err)
+	}
+
+	return constraints, nil
+}
+
+func validateK8sVersion(i interface{}, k string) (s []string, es []error) {
+	expr, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if !isK8sVersionConstraint(expr) {
+		return
+	}
+
+	if _, err := parseK8sVersionConstraint(expr); err != nil {
+		es = append(es, fmt.Errorf("%s: %s", k, err))
+	}
+
+	return
+}
+
+// ResolveK8sVersion resolves a k8s_version to a Kubernetes version. Versions are returned as they are, while
+// `latest` and constraints such as `~> 1.23` resolve to the latest available version that satisfies them.
+// The current version of a cluster is kept if it satisfies the constraint and is newer, as clusters can
+// not be downgraded.
+func ResolveK8sVersion(expr string, available []string, current string) (string, error) {
+	if !isK8sVersionConstraint(expr) {
+		return expr, nil
+	}
+
+	constraints, err := parseK8sVersionConstraint(expr)
+	if err != nil {
+		return "", err
+	}
+
+	var resolved *version.Version
+	var resolvedID string
+
+	for _, id := range available {
+		v, err := version.NewVersion(id)
+		if err != nil || !constraints.Check(v) {
+			continue
+		}
+
+		if resolved == nil || v.GreaterThan(resolved) {
+			resolved, resolvedID = v, id
+		}
+	}
+
+	if resolved == nil {
+		return "", fmt.Errorf("no available Kubernetes version satisfies %q", expr)
+	}
+
+	if current != "" {
+		if v, err := version.NewVersion(current); err == nil && constraints.Check(v) && v.GreaterThan(resolved) {
+			return current, nil
+		}
+	}
+
+	return resolvedID, nil
+}
+
+// ValidateK8sUpgrade returns an error if the resolved version of a cluster is older than its current version,
+// as clusters can not be downgraded.
+func ValidateK8sUpgrade(current, resolved string) error {
+	if current == "" {
+		return nil
+	}
+
+	currentVersion, err := version.NewVersion(current)
+	if err != nil {
+		return nil
+	}
+
+	resolvedVersion, err := version.NewVersion(resolved)
+	if err != nil {
+		return nil
+	}
+
+	if resolvedVersion.LessThan(currentVersion) {
+		return fmt.Errorf("k8s_version resolves to %s, which is older than the current version %s of the cluster; "+
+			"LKE clusters can not be downgraded", resolved, current)
+	}
+
+	return nil
+}
+
+// resolveK8sVersion resolves a k8s_version against the versions available from the API.
+func resolveK8sVersion(ctx context.Context, client *linodego.Client, expr, current string) (string, error) {
+	if !isK8sVersionConstraint(expr) {
+		return expr, nil
+	}
+
+	versions, err := client.ListLKEVersions(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to list LKE versions: %s", err)
+	}
+
+	available := make([]string, len(versions))
+	for i, v := range versions {
+		available[i] = v.ID
+	}
+
+	return ResolveK8sVersion(expr, available, current)
+}
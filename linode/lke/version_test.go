@@ -0,0 +1,64 @@
+package lke_test
+
+import (
+	"testing"
+
+	"github.com/linode/terraform-provider-linode/linode/lke"
+)
+
+func TestResolveK8sVersion(t *testing.T) {
+	available := []string{"1.21", "1.23", "1.22", "2.0"}
+
+	for _, tc := range []struct {
+		name     string
+		expr     string
+		current  string
+		expected string
+	}{
+		{name: "version", expr: "1.21", expected: "1.21"},
+		{name: "unavailable version", expr: "1.19", expected: "1.19"},
+		{name: "latest", expr: "latest", expected: "2.0"},
+		{name: "pessimistic minor", expr: "~> 1.21", expected: "1.23"},
+		{name: "pessimistic patch", expr: "~> 1.22.0", expected: "1.22"},
+		{name: "range", expr: ">= 1.21, < 1.23", expected: "1.22"},
+		{name: "current is kept when newer", expr: "~> 1.21", current: "1.24", expected: "1.24"},
+		{name: "current is ignored when not satisfying", expr: "~> 1.21", current: "2.1", expected: "1.23"},
+		{name: "current is upgraded", expr: "latest", current: "1.21", expected: "2.0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolved, err := lke.ResolveK8sVersion(tc.expr, available, tc.current)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resolved != tc.expected {
+				t.Errorf("expected %q to resolve to %q, got %q", tc.expr, tc.expected, resolved)
+			}
+		})
+	}
+
+	for _, expr := range []string{"~> 3.0", "~> banana"} {
+		if _, err := lke.ResolveK8sVersion(expr, available, ""); err == nil {
+			t.Errorf("expected an error resolving %q", expr)
+		}
+	}
+}
+
+func TestValidateK8sUpgrade(t *testing.T) {
+	for _, tc := range []struct {
+		current  string
+		resolved string
+		valid    bool
+	}{
+		{current: "", resolved: "1.21", valid: true},
+		{current: "1.21", resolved: "1.21", valid: true},
+		{current: "1.21", resolved: "1.22", valid: true},
+		{current: "1.23", resolved: "1.22", valid: false},
+		{current: "2.1", resolved: "1.23", valid: false},
+	} {
+		err := lke.ValidateK8sUpgrade(tc.current, tc.resolved)
+		if valid := err == nil; valid != tc.valid {
+			t.Errorf("expected an upgrade from %q to %q to be valid=%t, got %v", tc.current, tc.resolved, tc.valid, err)
+		}
+	}
+}
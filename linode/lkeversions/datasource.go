@@ -0,0 +1,53 @@
+package lkeversions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/linode/linodego"
+)
+
+func DataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema:      dataSourceSchema,
+		ReadContext: readDataSource,
+	}
+}
+
+func readDataSource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	results, err := filterConfig.FilterDataSource(ctx, d, meta, listVersions, flattenVersion)
+	if err != nil {
+		return diag.Errorf("failed to list LKE versions: %s", err)
+	}
+
+	d.Set("versions", results)
+
+	return nil
+}
+
+func listVersions(
+	ctx context.Context, client *linodego.Client, options *linodego.ListOptions) ([]interface{}, error) {
+	versions, err := client.ListLKEVersions(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, len(versions))
+
+	for i, v := range versions {
+		result[i] = v
+	}
+
+	return result, nil
+}
+
+func flattenVersion(data interface{}) map[string]interface{} {
+	version := data.(linodego.LKEVersion)
+
+	result := make(map[string]interface{})
+
+	result["id"] = version.ID
+
+	return result
+}
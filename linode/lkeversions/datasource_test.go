@@ -0,0 +1,55 @@
+package lkeversions_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/linode/terraform-provider-linode/linode/acceptance"
+	"github.com/linode/terraform-provider-linode/linode/lkeversions/tmpl"
+	"github.com/linode/terraform-provider-linode/linode/mockapi"
+)
+
+const dataSourceName = "data.linode_lke_versions.foobar"
+
+func TestAccDataSourceLKEVersions_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.PreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.DataBasic(t),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CheckResourceAttrGreaterThan(dataSourceName, "versions.#", 0),
+					resource.TestCheckResourceAttrSet(dataSourceName, "versions.0.id"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDataSourceLKEVersions_filter(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { mockapi.PreCheck(t) },
+		ProviderFactories: mockapi.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + tmpl.DataBasic(t),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "versions.#", "2"),
+				),
+			},
+			{
+				Config: server.ProviderConfig() + tmpl.DataFilter(t, "1.21"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "versions.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.0.id", "1.21"),
+				),
+			},
+		},
+	})
+}
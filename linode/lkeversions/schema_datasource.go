@@ -0,0 +1,33 @@
+package lkeversions
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+)
+
+var filterConfig = helper.FilterConfig{
+	"id": {TypeFunc: helper.FilterTypeString},
+}
+
+var versionSchema = map[string]*schema.Schema{
+	"id": {
+		Type:        schema.TypeString,
+		Description: "The Kubernetes version in the format of <major>.<minor>.",
+		Computed:    true,
+	},
+}
+
+var dataSourceSchema = map[string]*schema.Schema{
+	"order_by":     filterConfig.OrderBySchema(),
+	"order":        filterConfig.OrderSchema(),
+	"filter":       filterConfig.FilterSchema(),
+	"filter_group": filterConfig.FilterGroupSchema(),
+	"versions": {
+		Type:        schema.TypeList,
+		Description: "The returned list of Kubernetes versions available to LKE Clusters.",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: versionSchema,
+		},
+	},
+}
@@ -0,0 +1,21 @@
+package tmpl
+
+import (
+	"testing"
+
+	"github.com/linode/terraform-provider-linode/linode/acceptance"
+)
+
+type TemplateData struct {
+	Version string
+}
+
+func DataBasic(t *testing.T) string {
+	return acceptance.ExecuteTemplate(t,
+		"lke_versions_data_basic", nil)
+}
+
+func DataFilter(t *testing.T, version string) string {
+	return acceptance.ExecuteTemplate(t,
+		"lke_versions_data_filter", TemplateData{Version: version})
+}
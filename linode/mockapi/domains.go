@@ -0,0 +1,95 @@
+package mockapi
+
+import (
+	"net/http"
+)
+
+const domainsCollection = "domains"
+
+var domainUpdateKeys = []string{
+	"domain", "type", "group", "status", "description", "soa_email", "retry_sec", "master_ips", "axfr_ips",
+	"tags", "expire_sec", "refresh_sec", "ttl_sec",
+}
+
+var domainRecordKeys = []string{
+	"type", "name", "target", "priority", "weight", "port", "service", "protocol", "ttl_sec", "tag",
+}
+
+func (s *Server) registerDomainRoutes() {
+	s.handle(http.MethodDelete, "domains/*", s.deleteDomain)
+	s.crud(domainsCollection, s.createDomain, domainUpdateKeys)
+
+	domainExists := func(r *request) bool {
+		return s.find(domainsCollection, r.id(0)) != nil
+	}
+
+	s.crud("domains/*/records", s.createDomainRecord, domainRecordKeys, domainExists)
+}
+
+func (s *Server) createDomain(r *request, collection string) (int, interface{}) {
+	name := stringValue(r.body["domain"])
+	if name == "" {
+		return apiError(http.StatusBadRequest, "domain is required")
+	}
+
+	for _, domain := range s.collections[collection] {
+		if domain["domain"] == name {
+			return apiError(http.StatusBadRequest, "Domain already exists")
+		}
+	}
+
+	domainType := stringValue(r.body["type"])
+	if domainType == "master" && stringValue(r.body["soa_email"]) == "" {
+		return apiError(http.StatusBadRequest, "soa_email is required for master domains")
+	}
+
+	domain := s.insert(collection, defaults(pick(r.body, domainUpdateKeys...), Object{
+		"type":        "master",
+		"group":       "",
+		"status":      "active",
+		"description": "",
+		"soa_email":   "",
+		"retry_sec":   0,
+		"master_ips":  []string{},
+		"axfr_ips":    []string{},
+		"tags":        []string{},
+		"expire_sec":  0,
+		"refresh_sec": 0,
+		"ttl_sec":     0,
+	}))
+	s.event("domain_create", entity("domain", domainsCollection, domain), nil)
+
+	return http.StatusOK, domain
+}
+
+func (s *Server) deleteDomain(r *request) (int, interface{}) {
+	domain := s.find(domainsCollection, r.id(0))
+	if domain == nil {
+		return notFound()
+	}
+
+	s.remove(domainsCollection, r.id(0))
+	s.event("domain_delete", entity("domain", domainsCollection, domain), nil)
+
+	return http.StatusOK, Object{}
+}
+
+func (s *Server) createDomainRecord(r *request, collection string) (int, interface{}) {
+	if stringValue(r.body["type"]) == "" {
+		return apiError(http.StatusBadRequest, "type is required")
+	}
+
+	record := s.insert(collection, defaults(pick(r.body, domainRecordKeys...), Object{
+		"name":     "",
+		"target":   "",
+		"priority": 0,
+		"weight":   0,
+		"port":     0,
+		"service":  nil,
+		"protocol": nil,
+		"ttl_sec":  0,
+		"tag":      nil,
+	}))
+
+	return http.StatusOK, record
+}
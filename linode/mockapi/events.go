@@ -0,0 +1,71 @@
+package mockapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const eventsCollection = "account/events"
+
+// entity returns the event entity for an object of the given type.
+func entity(entityType, urlPrefix string, obj Object) Object {
+	label := obj["label"]
+	if label == nil {
+		label = obj["domain"]
+	}
+
+	return Object{
+		"id":    obj["id"],
+		"type":  entityType,
+		"label": label,
+		"url":   fmt.Sprintf("/v4/%s/%d", urlPrefix, intValue(obj["id"])),
+	}
+}
+
+// event records a finished event for the given entity.
+func (s *Server) event(action string, entity, secondaryEntity Object) Object {
+	return s.insert(eventsCollection, Object{
+		"action":           action,
+		"entity":           entity,
+		"secondary_entity": secondaryEntity,
+		"status":           "finished",
+		"percent_complete": 100,
+		"seen":             false,
+		"read":             false,
+		"username":         "mock",
+		"rate":             nil,
+		"time_remaining":   nil,
+		"duration":         0,
+	})
+}
+
+func (s *Server) instanceEvent(action string, instance Object) Object {
+	return s.event(action, entity("linode", "linode/instances", instance), nil)
+}
+
+func (s *Server) registerEventRoutes() {
+	s.handle(http.MethodGet, "account/events", func(r *request) (int, interface{}) {
+		return list(r, s.collections[eventsCollection])
+	})
+
+	s.handle(http.MethodGet, "account/events/*", func(r *request) (int, interface{}) {
+		event := s.find(eventsCollection, r.id(0))
+		if event == nil {
+			return notFound()
+		}
+		return http.StatusOK, event
+	})
+
+	for _, action := range []string{"seen", "read"} {
+		action := action
+
+		s.handle(http.MethodPost, "account/events/*/"+action, func(r *request) (int, interface{}) {
+			event := s.find(eventsCollection, r.id(0))
+			if event == nil {
+				return notFound()
+			}
+			event[action] = true
+			return http.StatusOK, Object{}
+		})
+	}
+}
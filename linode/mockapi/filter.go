@@ -0,0 +1,159 @@
+package mockapi
+
+import (
+	"strconv"
+	"strings"
+)
+
+// matchesFilter returns whether the object matches an X-Filter expression. Only the operators
+// used by the provider and linodego are supported: +and, +or, +gt, +gte, +lt, +lte, +neq and +contains.
+func matchesFilter(obj Object, filter Object) bool {
+	for key, expected := range filter {
+		switch key {
+		case "+order_by", "+order":
+			continue
+		case "+and":
+			for _, child := range toFilterList(expected) {
+				if !matchesFilter(obj, child) {
+					return false
+				}
+			}
+		case "+or":
+			matched := false
+			for _, child := range toFilterList(expected) {
+				if matchesFilter(obj, child) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		default:
+			if !matchesField(lookupPath(obj, key), expected) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func toFilterList(v interface{}) []Object {
+	items, _ := v.([]interface{})
+
+	result := make([]Object, 0, len(items))
+	for _, item := range items {
+		if child, ok := item.(map[string]interface{}); ok {
+			result = append(result, child)
+		}
+	}
+
+	return result
+}
+
+// matchesField compares a field value with an expected value or operator expression.
+// List fields such as tags match if any of their elements match.
+func matchesField(actual, expected interface{}) bool {
+	if list, ok := actual.([]interface{}); ok {
+		for _, item := range list {
+			if matchesField(item, expected) {
+				return true
+			}
+		}
+		return false
+	}
+
+	operators, ok := expected.(map[string]interface{})
+	if !ok {
+		return compareValues(actual, expected) == 0
+	}
+
+	for op, value := range operators {
+		cmp := compareValues(actual, value)
+
+		var matched bool
+		switch op {
+		case "+gt":
+			matched = cmp > 0
+		case "+gte":
+			matched = cmp >= 0
+		case "+lt":
+			matched = cmp < 0
+		case "+lte":
+			matched = cmp <= 0
+		case "+neq":
+			matched = cmp != 0
+		case "+contains":
+			matched = strings.Contains(stringValue(actual), stringValue(value))
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookupPath returns the value at a dotted path such as entity.id.
+func lookupPath(obj Object, path string) interface{} {
+	var current interface{} = map[string]interface{}(obj)
+
+	for _, key := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[key]
+	}
+
+	return current
+}
+
+// compareValues orders two JSON values, returning a negative number, zero or a positive number.
+// Values of different types compare by their string form.
+func compareValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case float64:
+		if b, ok := b.(float64); ok {
+			switch {
+			case a < b:
+				return -1
+			case a > b:
+				return 1
+			}
+			return 0
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			if a == b {
+				return 0
+			}
+			if !a {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return strings.Compare(toString(a), toString(b))
+}
+
+func toString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	}
+
+	return ""
+}
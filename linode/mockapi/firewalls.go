@@ -0,0 +1,112 @@
+package mockapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const firewallsCollection = "networking/firewalls"
+
+func firewallDevicesCollection(firewallID int) string {
+	return fmt.Sprintf("%s/%d/devices", firewallsCollection, firewallID)
+}
+
+func (s *Server) registerFirewallRoutes() {
+	s.crud(firewallsCollection, s.createFirewall, []string{"label", "status", "tags"})
+
+	s.handle(http.MethodGet, "networking/firewalls/*/rules", func(r *request) (int, interface{}) {
+		firewall := s.find(firewallsCollection, r.id(0))
+		if firewall == nil {
+			return notFound()
+		}
+		return http.StatusOK, firewall["rules"]
+	})
+
+	s.handle(http.MethodPut, "networking/firewalls/*/rules", func(r *request) (int, interface{}) {
+		firewall := s.find(firewallsCollection, r.id(0))
+		if firewall == nil {
+			return notFound()
+		}
+		firewall["rules"] = newFirewallRules(r.body)
+		firewall["updated"] = timestamp()
+		return http.StatusOK, firewall["rules"]
+	})
+
+	firewallExists := func(r *request) bool {
+		return s.find(firewallsCollection, r.id(0)) != nil
+	}
+
+	s.crud("networking/firewalls/*/devices", func(r *request, collection string) (int, interface{}) {
+		return s.createFirewallDevice(collection, stringValue(r.body["type"]), intValue(r.body["id"]))
+	}, nil, firewallExists)
+}
+
+func newFirewallRules(rules Object) interface{} {
+	return normalizeValue(defaults(pick(rules, "inbound", "outbound", "inbound_policy", "outbound_policy"), Object{
+		"inbound":         []Object{},
+		"outbound":        []Object{},
+		"inbound_policy":  "ACCEPT",
+		"outbound_policy": "ACCEPT",
+	}))
+}
+
+func (s *Server) createFirewall(r *request, collection string) (int, interface{}) {
+	if stringValue(r.body["label"]) == "" {
+		return apiError(http.StatusBadRequest, "label is required")
+	}
+
+	rules, _ := r.body["rules"].(map[string]interface{})
+
+	firewall := s.insert(collection, defaults(pick(r.body, "label", "tags"), Object{
+		"tags":   []string{},
+		"status": "enabled",
+		"rules":  newFirewallRules(rules),
+	}))
+
+	if devices, ok := r.body["devices"].(map[string]interface{}); ok {
+		for key, deviceType := range map[string]string{"linodes": "linode", "nodebalancers": "nodebalancer"} {
+			ids, _ := devices[key].([]interface{})
+			for _, id := range ids {
+				if status, body := s.createFirewallDevice(
+					firewallDevicesCollection(intValue(firewall["id"])), deviceType, intValue(id),
+				); status != http.StatusOK {
+					s.remove(collection, intValue(firewall["id"]))
+					return status, body
+				}
+			}
+		}
+	}
+
+	s.event("firewall_create", entity("firewall", firewallsCollection, firewall), nil)
+
+	return http.StatusOK, firewall
+}
+
+func (s *Server) createFirewallDevice(collection, deviceType string, id int) (int, interface{}) {
+	var target Object
+	var urlPrefix string
+
+	switch deviceType {
+	case "linode":
+		target, urlPrefix = s.find(instancesCollection, id), instancesCollection
+	case "nodebalancer":
+		target, urlPrefix = s.find(nodeBalancersCollection, id), nodeBalancersCollection
+	}
+
+	if target == nil {
+		return apiError(http.StatusBadRequest, fmt.Sprintf("%s %d is not valid", deviceType, id))
+	}
+
+	for _, device := range s.collections[collection] {
+		deviceEntity := device["entity"].(map[string]interface{})
+		if deviceEntity["type"] == deviceType && intValue(deviceEntity["id"]) == id {
+			return apiError(http.StatusBadRequest, "Device is already assigned to this firewall")
+		}
+	}
+
+	device := s.insert(collection, Object{
+		"entity": entity(deviceType, urlPrefix, target),
+	})
+
+	return http.StatusOK, device
+}
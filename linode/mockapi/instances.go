@@ -0,0 +1,682 @@
+package mockapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const instancesCollection = "linode/instances"
+
+func instanceDisksCollection(instanceID int) string {
+	return fmt.Sprintf("%s/%d/disks", instancesCollection, instanceID)
+}
+
+func instanceConfigsCollection(instanceID int) string {
+	return fmt.Sprintf("%s/%d/configs", instancesCollection, instanceID)
+}
+
+func (s *Server) registerInstanceRoutes() {
+	s.handle(http.MethodDelete, "linode/instances/*", s.deleteInstance)
+	s.handle(http.MethodPut, "linode/instances/*", s.updateInstance)
+	s.crud(instancesCollection, s.createInstance, nil)
+
+	s.handle(http.MethodPost, "linode/instances/*/boot", s.instanceAction("linode_boot", s.bootInstance))
+	s.handle(http.MethodPost, "linode/instances/*/reboot", s.instanceAction("linode_reboot", s.bootInstance))
+	s.handle(http.MethodPost, "linode/instances/*/shutdown", s.instanceAction("linode_shutdown", s.shutdownInstance))
+	s.handle(http.MethodPost, "linode/instances/*/resize", s.instanceAction("linode_resize", s.resizeInstance))
+	s.handle(http.MethodPost, "linode/instances/*/rebuild", s.instanceAction("linode_rebuild", s.rebuildInstance))
+	s.handle(http.MethodPost, "linode/instances/*/migrate",
+		s.instanceAction("linode_migrate_datacenter", s.migrateInstance))
+	s.handle(http.MethodPost, "linode/instances/*/backups/enable",
+		s.instanceAction("backups_enable", s.setInstanceBackups(true)))
+	s.handle(http.MethodPost, "linode/instances/*/backups/cancel",
+		s.instanceAction("backups_cancel", s.setInstanceBackups(false)))
+	s.handle(http.MethodPost, "linode/instances/*/clone", s.cloneInstance)
+
+	s.handle(http.MethodGet, "linode/instances/*/ips", s.getInstanceIPs)
+	s.handle(http.MethodPost, "linode/instances/*/ips", s.addInstanceIP)
+	s.handle(http.MethodGet, "linode/instances/*/ips/*", s.getInstanceIP)
+	s.handle(http.MethodPut, "linode/instances/*/ips/*", s.updateInstanceIP)
+	s.handle(http.MethodDelete, "linode/instances/*/ips/*", s.deleteInstanceIP)
+
+	s.handle(http.MethodGet, "linode/instances/*/volumes", func(r *request) (int, interface{}) {
+		var volumes []Object
+		for _, volume := range s.collections[volumesCollection] {
+			if intValue(volume["linode_id"]) == r.id(0) {
+				volumes = append(volumes, volume)
+			}
+		}
+		return list(r, volumes)
+	})
+
+	instanceExists := func(r *request) bool {
+		return s.find(instancesCollection, r.id(0)) != nil
+	}
+
+	s.handle(http.MethodDelete, "linode/instances/*/disks/*", s.deleteInstanceDisk)
+	s.handle(http.MethodPost, "linode/instances/*/disks/*/resize", s.resizeInstanceDisk)
+	s.handle(http.MethodPost, "linode/instances/*/disks/*/password", func(r *request) (int, interface{}) {
+		if s.find(instanceDisksCollection(r.id(0)), r.id(1)) == nil {
+			return notFound()
+		}
+		return http.StatusOK, Object{}
+	})
+	s.crud("linode/instances/*/disks", s.createInstanceDisk, []string{"label"}, instanceExists)
+
+	s.handle(http.MethodPut, "linode/instances/*/configs/*", s.updateInstanceConfig)
+	s.handle(http.MethodDelete, "linode/instances/*/configs/*", s.deleteInstanceConfig)
+	s.crud("linode/instances/*/configs", s.createInstanceConfig, nil, instanceExists)
+}
+
+// instanceAction returns a handler for an instance action that records an event once applied.
+func (s *Server) instanceAction(action string, apply func(r *request, instance Object) (int, interface{})) handlerFunc {
+	return func(r *request) (int, interface{}) {
+		instance := s.find(instancesCollection, r.id(0))
+		if instance == nil {
+			return notFound()
+		}
+
+		if status, body := apply(r, instance); status != http.StatusOK {
+			return status, body
+		}
+
+		instance["updated"] = timestamp()
+		s.instanceEvent(action, instance)
+
+		return http.StatusOK, Object{}
+	}
+}
+
+func (s *Server) createInstance(r *request, collection string) (int, interface{}) {
+	instanceType := findStatic(Types, stringValue(r.body["type"]))
+	if instanceType == nil {
+		return apiError(http.StatusBadRequest, "A valid plan type is required")
+	}
+
+	if findStatic(Regions, stringValue(r.body["region"])) == nil {
+		return apiError(http.StatusBadRequest, "region is not valid")
+	}
+
+	instance := s.insertInstance(pick(r.body, "label", "region", "group", "tags", "image"), instanceType,
+		r.body["backups_enabled"] == true)
+	if r.body["private_ip"] == true {
+		s.allocateInstanceIP(instance, false)
+	}
+
+	s.instanceEvent("linode_create", instance)
+
+	if image := stringValue(r.body["image"]); image != "" {
+		s.deployImage(instance, image, r.body)
+
+		if r.body["booted"] != false {
+			instance["status"] = "running"
+			s.instanceEvent("linode_boot", instance)
+		}
+	}
+
+	return http.StatusOK, instance
+}
+
+// insertInstance stores a new instance of the given type with a public IPv4 address.
+func (s *Server) insertInstance(fields, instanceType Object, backupsEnabled bool) Object {
+	instance := s.insert(instancesCollection, defaults(fields, Object{
+		"group":            "",
+		"tags":             []string{},
+		"image":            nil,
+		"status":           "offline",
+		"hypervisor":       "kvm",
+		"watchdog_enabled": true,
+		"ipv4":             []string{},
+		"alerts": Object{
+			"cpu": 90, "io": 10000, "network_in": 10, "network_out": 10, "transfer_quota": 80,
+		},
+		"backups": Object{
+			"enabled":   backupsEnabled,
+			"schedule":  Object{"day": "Scheduling", "window": "Scheduling"},
+			"available": false,
+		},
+	}))
+
+	id := intValue(instance["id"])
+	if instance["label"] == nil {
+		instance["label"] = fmt.Sprintf("linode%d", id)
+	}
+
+	setInstanceType(instance, instanceType)
+
+	instance["ipv6"] = fmt.Sprintf("2600:3c03::f03c:93ff:fe%02x:%04x/128", id/65536%256, id%65536)
+	s.allocateInstanceIP(instance, true)
+
+	return instance
+}
+
+func (s *Server) updateInstance(r *request) (int, interface{}) {
+	instance := s.find(instancesCollection, r.id(0))
+	if instance == nil {
+		return notFound()
+	}
+
+	update(instance, r.body, "label", "group", "tags", "watchdog_enabled")
+
+	if alerts, ok := r.body["alerts"].(map[string]interface{}); ok {
+		for key, value := range alerts {
+			instance["alerts"].(map[string]interface{})[key] = value
+		}
+	}
+
+	s.instanceEvent("linode_update", instance)
+
+	return http.StatusOK, instance
+}
+
+func (s *Server) deleteInstance(r *request) (int, interface{}) {
+	instance := s.find(instancesCollection, r.id(0))
+	if instance == nil {
+		return notFound()
+	}
+
+	for _, volume := range s.collections[volumesCollection] {
+		if intValue(volume["linode_id"]) == r.id(0) {
+			volume["linode_id"] = nil
+		}
+	}
+
+	s.remove(instancesCollection, r.id(0))
+	delete(s.instanceIPs, r.id(0))
+	s.instanceEvent("linode_delete", instance)
+
+	return http.StatusOK, Object{}
+}
+
+func setInstanceType(instance, instanceType Object) {
+	instance["type"] = instanceType["id"]
+	instance["specs"] = normalizeValue(Object{
+		"disk":     instanceType["disk"],
+		"memory":   instanceType["memory"],
+		"vcpus":    instanceType["vcpus"],
+		"transfer": instanceType["transfer"],
+		"gpus":     0,
+	})
+}
+
+// deployImage replaces the disks and configs of an instance with an image disk, a swap disk and
+// a config that boots from them, as is done when creating or rebuilding an instance from an image.
+func (s *Server) deployImage(instance Object, image string, body Object) {
+	id := intValue(instance["id"])
+	delete(s.collections, instanceDisksCollection(id))
+	delete(s.collections, instanceConfigsCollection(id))
+
+	swapSize := 512
+	if size, ok := body["swap_size"]; ok {
+		swapSize = intValue(size)
+	}
+
+	imageLabel := image
+	if obj := findStatic(Images, image); obj != nil {
+		imageLabel = stringValue(obj["label"])
+	}
+
+	instance["image"] = image
+	specs := instance["specs"].(map[string]interface{})
+
+	imageDisk := s.insert(instanceDisksCollection(id), newDisk(Object{
+		"label":      imageLabel + " Disk",
+		"size":       intValue(specs["disk"]) - swapSize,
+		"filesystem": "ext4",
+	}))
+
+	devices := Object{"sda": Object{"disk_id": imageDisk["id"], "volume_id": nil}}
+
+	if swapSize > 0 {
+		swapDisk := s.insert(instanceDisksCollection(id), newDisk(Object{
+			"label":      fmt.Sprintf("%d MB Swap Image", swapSize),
+			"size":       swapSize,
+			"filesystem": "swap",
+		}))
+		devices["sdb"] = Object{"disk_id": swapDisk["id"], "volume_id": nil}
+	}
+
+	interfaces := body["interfaces"]
+	if interfaces == nil {
+		interfaces = []interface{}{}
+	}
+
+	s.insert(instanceConfigsCollection(id), newConfig(Object{
+		"label":      fmt.Sprintf("My %s Disk Profile", imageLabel),
+		"kernel":     "linode/grub2",
+		"devices":    devices,
+		"interfaces": interfaces,
+	}))
+}
+
+func (s *Server) bootInstance(r *request, instance Object) (int, interface{}) {
+	configs := s.collections[instanceConfigsCollection(intValue(instance["id"]))]
+	if len(configs) == 0 {
+		return apiError(http.StatusBadRequest, "Linode has no configs to boot")
+	}
+
+	if configID, ok := r.body["config_id"]; ok && configID != nil && intValue(configID) != 0 {
+		if s.find(instanceConfigsCollection(intValue(instance["id"])), intValue(configID)) == nil {
+			return apiError(http.StatusBadRequest, "config_id is not valid")
+		}
+	}
+
+	instance["status"] = "running"
+
+	return http.StatusOK, nil
+}
+
+func (s *Server) shutdownInstance(r *request, instance Object) (int, interface{}) {
+	instance["status"] = "offline"
+	return http.StatusOK, nil
+}
+
+func (s *Server) resizeInstance(r *request, instance Object) (int, interface{}) {
+	instanceType := findStatic(Types, stringValue(r.body["type"]))
+	if instanceType == nil {
+		return apiError(http.StatusBadRequest, "A valid plan type is required")
+	}
+
+	if instance["type"] == instanceType["id"] {
+		return apiError(http.StatusBadRequest, "Linode is already running this service plan")
+	}
+
+	s.instanceEvent("linode_resize_create", instance)
+	setInstanceType(instance, instanceType)
+
+	return http.StatusOK, nil
+}
+
+func (s *Server) rebuildInstance(r *request, instance Object) (int, interface{}) {
+	image := stringValue(r.body["image"])
+	if image == "" {
+		return apiError(http.StatusBadRequest, "image is required")
+	}
+
+	s.deployImage(instance, image, r.body)
+	instance["status"] = "running"
+
+	if r.body["booted"] == false {
+		instance["status"] = "offline"
+	}
+
+	return http.StatusOK, nil
+}
+
+func (s *Server) migrateInstance(r *request, instance Object) (int, interface{}) {
+	region := stringValue(r.body["region"])
+	if findStatic(Regions, region) == nil {
+		return apiError(http.StatusBadRequest, "region is not valid")
+	}
+
+	s.instanceEvent("linode_migrate_datacenter_create", instance)
+
+	// Migrated instances are assigned new addresses
+	id := intValue(instance["id"])
+	hadPrivate := false
+	for _, ip := range s.instanceIPs[id] {
+		if ip["public"] == false {
+			hadPrivate = true
+		}
+	}
+
+	instance["region"] = region
+	instance["ipv4"] = []interface{}{}
+	delete(s.instanceIPs, id)
+
+	s.allocateInstanceIP(instance, true)
+	if hadPrivate {
+		s.allocateInstanceIP(instance, false)
+	}
+
+	return http.StatusOK, nil
+}
+
+func (s *Server) setInstanceBackups(enabled bool) func(r *request, instance Object) (int, interface{}) {
+	return func(r *request, instance Object) (int, interface{}) {
+		instance["backups"].(map[string]interface{})["enabled"] = enabled
+		return http.StatusOK, nil
+	}
+}
+
+func (s *Server) cloneInstance(r *request) (int, interface{}) {
+	source := s.find(instancesCollection, r.id(0))
+	if source == nil {
+		return notFound()
+	}
+
+	body := Object{
+		"region": source["region"],
+		"type":   source["type"],
+		"group":  source["group"],
+		"tags":   source["tags"],
+	}
+	for key, value := range r.body {
+		body[key] = value
+	}
+	delete(body, "image")
+
+	status, result := s.createInstance(&request{Request: r.Request, body: body}, instancesCollection)
+	if status != http.StatusOK {
+		return status, result
+	}
+
+	clone := result.(Object)
+	sourceID, cloneID := intValue(source["id"]), intValue(clone["id"])
+
+	selected := func(key string, id interface{}) bool {
+		ids, ok := r.body[key].([]interface{})
+		if !ok {
+			return true
+		}
+		for _, selectedID := range ids {
+			if intValue(selectedID) == intValue(id) {
+				return true
+			}
+		}
+		return false
+	}
+
+	diskIDs := make(map[int]interface{})
+	for _, disk := range s.collections[instanceDisksCollection(sourceID)] {
+		if !selected("disks", disk["id"]) {
+			continue
+		}
+		copied := normalize(disk)
+		delete(copied, "id")
+		delete(copied, "created")
+		delete(copied, "updated")
+		diskIDs[intValue(disk["id"])] = s.insert(instanceDisksCollection(cloneID), copied)["id"]
+	}
+
+	for _, config := range s.collections[instanceConfigsCollection(sourceID)] {
+		if !selected("configs", config["id"]) {
+			continue
+		}
+		copied := normalize(config)
+		delete(copied, "id")
+		delete(copied, "created")
+		delete(copied, "updated")
+
+		for _, device := range copied["devices"].(map[string]interface{}) {
+			if device, ok := device.(map[string]interface{}); ok && device["disk_id"] != nil {
+				device["disk_id"] = diskIDs[intValue(device["disk_id"])]
+			}
+		}
+		s.insert(instanceConfigsCollection(cloneID), copied)
+	}
+
+	clone["image"] = source["image"]
+	s.event("linode_clone", entity("linode", instancesCollection, source),
+		entity("linode", instancesCollection, clone))
+
+	return http.StatusOK, clone
+}
+
+// allocateInstanceIP assigns a new public or private IPv4 address to the instance.
+func (s *Server) allocateInstanceIP(instance Object, public bool) Object {
+	s.nextID++
+	n := s.nextID
+
+	address := fmt.Sprintf("172.105.%d.%d", n/254%256, n%254+1)
+	gateway := fmt.Sprintf("172.105.%d.1", n/254%256)
+	ipType := "ipv4"
+	if !public {
+		address = fmt.Sprintf("192.168.%d.%d", 128+n/254%64, n%254+1)
+		gateway = ""
+	}
+
+	id := intValue(instance["id"])
+	ip := normalize(Object{
+		"address":     address,
+		"gateway":     gateway,
+		"subnet_mask": "255.255.255.0",
+		"prefix":      24,
+		"type":        ipType,
+		"public":      public,
+		"rdns":        fmt.Sprintf("%s.ip.linodeusercontent.com", address),
+		"linode_id":   id,
+		"region":      instance["region"],
+	})
+
+	s.instanceIPs[id] = append(s.instanceIPs[id], ip)
+	instance["ipv4"] = append(instance["ipv4"].([]interface{}), address)
+
+	return ip
+}
+
+func (s *Server) getInstanceIPs(r *request) (int, interface{}) {
+	instance := s.find(instancesCollection, r.id(0))
+	if instance == nil {
+		return notFound()
+	}
+
+	public, private := []Object{}, []Object{}
+	for _, ip := range s.instanceIPs[r.id(0)] {
+		if ip["public"] == true {
+			public = append(public, ip)
+		} else {
+			private = append(private, ip)
+		}
+	}
+
+	slaac := stringValue(instance["ipv6"])
+	if i := len(slaac) - len("/128"); i > 0 {
+		slaac = slaac[:i]
+	}
+
+	return http.StatusOK, Object{
+		"ipv4": Object{
+			"public":   public,
+			"private":  private,
+			"shared":   []Object{},
+			"reserved": []Object{},
+		},
+		"ipv6": Object{
+			"slaac": Object{
+				"address": slaac, "prefix": 64, "type": "ipv6", "public": true,
+				"linode_id": r.id(0), "region": instance["region"],
+			},
+			"link_local": Object{
+				"address": "fe80::f03c:93ff:fe00:1", "prefix": 64, "type": "ipv6", "public": false,
+				"linode_id": r.id(0), "region": instance["region"],
+			},
+			"global": []Object{},
+		},
+	}
+}
+
+func (s *Server) addInstanceIP(r *request) (int, interface{}) {
+	instance := s.find(instancesCollection, r.id(0))
+	if instance == nil {
+		return notFound()
+	}
+
+	if r.body["type"] != "ipv4" {
+		return apiError(http.StatusBadRequest, "Only IPv4 addresses can be allocated")
+	}
+
+	ip := s.allocateInstanceIP(instance, r.body["public"] == true)
+	s.instanceEvent("linode_addip", instance)
+
+	return http.StatusOK, ip
+}
+
+func (s *Server) findInstanceIP(r *request) (int, Object) {
+	for i, ip := range s.instanceIPs[r.id(0)] {
+		if ip["address"] == r.params[1] {
+			return i, ip
+		}
+	}
+
+	return -1, nil
+}
+
+func (s *Server) getInstanceIP(r *request) (int, interface{}) {
+	if _, ip := s.findInstanceIP(r); ip != nil {
+		return http.StatusOK, ip
+	}
+
+	return notFound()
+}
+
+func (s *Server) updateInstanceIP(r *request) (int, interface{}) {
+	_, ip := s.findInstanceIP(r)
+	if ip == nil {
+		return notFound()
+	}
+
+	if rdns, ok := r.body["rdns"]; ok {
+		ip["rdns"] = rdns
+	}
+
+	return http.StatusOK, ip
+}
+
+func (s *Server) deleteInstanceIP(r *request) (int, interface{}) {
+	i, ip := s.findInstanceIP(r)
+	if ip == nil {
+		return notFound()
+	}
+
+	id := r.id(0)
+	s.instanceIPs[id] = append(s.instanceIPs[id][:i:i], s.instanceIPs[id][i+1:]...)
+
+	instance := s.find(instancesCollection, id)
+	addresses := []interface{}{}
+	for _, address := range instance["ipv4"].([]interface{}) {
+		if address != ip["address"] {
+			addresses = append(addresses, address)
+		}
+	}
+	instance["ipv4"] = addresses
+
+	s.instanceEvent("linode_deleteip", instance)
+
+	return http.StatusOK, Object{}
+}
+
+func newDisk(disk Object) Object {
+	return defaults(disk, Object{
+		"filesystem": "ext4",
+		"status":     "ready",
+	})
+}
+
+func (s *Server) createInstanceDisk(r *request, collection string) (int, interface{}) {
+	instance := s.find(instancesCollection, r.id(0))
+
+	if intValue(r.body["size"]) <= 0 {
+		return apiError(http.StatusBadRequest, "size is required")
+	}
+
+	used := 0
+	for _, disk := range s.collections[collection] {
+		used += intValue(disk["size"])
+	}
+
+	specs := instance["specs"].(map[string]interface{})
+	if used+intValue(r.body["size"]) > intValue(specs["disk"]) {
+		return apiError(http.StatusBadRequest, "Insufficient space for requested disk")
+	}
+
+	disk := s.insert(collection, newDisk(pick(r.body, "label", "size", "filesystem")))
+	s.event("disk_create", entity("linode", instancesCollection, instance), entity("disk", collection, disk))
+
+	return http.StatusOK, disk
+}
+
+func (s *Server) resizeInstanceDisk(r *request) (int, interface{}) {
+	instance := s.find(instancesCollection, r.id(0))
+	disk := s.find(instanceDisksCollection(r.id(0)), r.id(1))
+	if instance == nil || disk == nil {
+		return notFound()
+	}
+
+	if instance["status"] != "offline" {
+		return apiError(http.StatusBadRequest, "Linode must be shut down to resize a disk")
+	}
+
+	disk["size"] = normalizeValue(r.body["size"])
+	disk["updated"] = timestamp()
+	s.event("disk_resize", entity("linode", instancesCollection, instance),
+		entity("disk", instanceDisksCollection(r.id(0)), disk))
+
+	return http.StatusOK, Object{}
+}
+
+func (s *Server) deleteInstanceDisk(r *request) (int, interface{}) {
+	instance := s.find(instancesCollection, r.id(0))
+	disk := s.find(instanceDisksCollection(r.id(0)), r.id(1))
+	if instance == nil || disk == nil {
+		return notFound()
+	}
+
+	s.remove(instanceDisksCollection(r.id(0)), r.id(1))
+	s.event("disk_delete", entity("linode", instancesCollection, instance),
+		entity("disk", instanceDisksCollection(r.id(0)), disk))
+
+	return http.StatusOK, Object{}
+}
+
+var configKeys = []string{
+	"label", "comments", "devices", "helpers", "interfaces", "memory_limit", "kernel", "init_rd",
+	"root_device", "run_level", "virt_mode",
+}
+
+func newConfig(config Object) Object {
+	return defaults(config, Object{
+		"comments":     "",
+		"kernel":       "linode/latest-64bit",
+		"memory_limit": 0,
+		"root_device":  "/dev/sda",
+		"run_level":    "default",
+		"virt_mode":    "paravirt",
+		"init_rd":      nil,
+		"devices":      Object{},
+		"interfaces":   []Object{},
+		"helpers": Object{
+			"updatedb_disabled":  true,
+			"distro":             true,
+			"modules_dep":        true,
+			"network":            true,
+			"devtmpfs_automount": true,
+		},
+	})
+}
+
+func (s *Server) createInstanceConfig(r *request, collection string) (int, interface{}) {
+	if stringValue(r.body["label"]) == "" {
+		return apiError(http.StatusBadRequest, "label is required")
+	}
+
+	instance := s.find(instancesCollection, r.id(0))
+	config := s.insert(collection, newConfig(pick(r.body, configKeys...)))
+	s.instanceEvent("linode_config_create", instance)
+
+	return http.StatusOK, config
+}
+
+func (s *Server) updateInstanceConfig(r *request) (int, interface{}) {
+	instance := s.find(instancesCollection, r.id(0))
+	config := s.find(instanceConfigsCollection(r.id(0)), r.id(1))
+	if instance == nil || config == nil {
+		return notFound()
+	}
+
+	update(config, r.body, configKeys...)
+	s.instanceEvent("linode_config_update", instance)
+
+	return http.StatusOK, config
+}
+
+func (s *Server) deleteInstanceConfig(r *request) (int, interface{}) {
+	instance := s.find(instancesCollection, r.id(0))
+	if instance == nil || !s.remove(instanceConfigsCollection(r.id(0)), r.id(1)) {
+		return notFound()
+	}
+
+	s.instanceEvent("linode_config_delete", instance)
+
+	return http.StatusOK, Object{}
+}
@@ -0,0 +1,382 @@
+package mockapi
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+)
+
+const lkeClustersCollection = "lke/clusters"
+
+func lkePoolsCollection(clusterID int) string {
+	return fmt.Sprintf("%s/%d/pools", lkeClustersCollection, clusterID)
+}
+
+const kubeconfigTemplate = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: %[1]s/k8s/%[2]d
+  name: lke%[2]d
+contexts:
+- context:
+    cluster: lke%[2]d
+    namespace: default
+    user: lke%[2]d-admin
+  name: lke%[2]d-ctx
+current-context: lke%[2]d-ctx
+users:
+- name: lke%[2]d-admin
+  user:
+    token: mock-%[3]d
+`
+
+var lkePoolUpdateKeys = []string{"count", "autoscaler", "tags"}
+
+func (s *Server) registerLKERoutes() {
+	s.handle(http.MethodDelete, "lke/clusters/*", s.deleteLKECluster)
+	s.handle(http.MethodPut, "lke/clusters/*", s.updateLKECluster)
+	s.crud(lkeClustersCollection, s.createLKECluster, nil)
+
+	clusterExists := func(r *request) bool {
+		return s.find(lkeClustersCollection, r.id(0)) != nil
+	}
+
+	s.handle(http.MethodPut, "lke/clusters/*/pools/*", s.updateLKEPool)
+	s.handle(http.MethodPost, "lke/clusters/*/pools/*/recycle", s.recycleLKEPool)
+	s.crud("lke/clusters/*/pools", func(r *request, collection string) (int, interface{}) {
+		return s.createLKEPool(r.id(0), r.body)
+	}, nil, clusterExists)
+
+	s.handle(http.MethodGet, "lke/clusters/*/nodes/*", s.getLKENode)
+	s.handle(http.MethodDelete, "lke/clusters/*/nodes/*", s.deleteLKENode)
+	s.handle(http.MethodPost, "lke/clusters/*/nodes/*/recycle", s.recycleLKENode)
+	s.handle(http.MethodPost, "lke/clusters/*/recycle", s.recycleLKECluster)
+
+	s.handle(http.MethodGet, "lke/clusters/*/kubeconfig", s.getLKEKubeconfig)
+	s.handle(http.MethodDelete, "lke/clusters/*/kubeconfig", func(r *request) (int, interface{}) {
+		cluster := s.find(lkeClustersCollection, r.id(0))
+		if cluster == nil {
+			return notFound()
+		}
+		s.nextID++
+		cluster["kubeconfig_version"] = s.nextID
+		return http.StatusOK, Object{}
+	})
+
+	s.handle(http.MethodGet, "lke/clusters/*/api-endpoints", func(r *request) (int, interface{}) {
+		cluster := s.find(lkeClustersCollection, r.id(0))
+		if cluster == nil {
+			return notFound()
+		}
+		return list(r, []Object{{"endpoint": fmt.Sprintf("%s/k8s/%d", s.URL, r.id(0))}})
+	})
+
+	s.handle(http.MethodGet, "lke/clusters/*/dashboard", func(r *request) (int, interface{}) {
+		if s.find(lkeClustersCollection, r.id(0)) == nil {
+			return notFound()
+		}
+		return http.StatusOK, Object{"url": fmt.Sprintf("%s/k8s/%d/dashboard", s.URL, r.id(0))}
+	})
+
+	// The kubeconfig of a fake cluster points at the fake API, which reports every node as ready
+	// and without any pods to evict
+	s.handle(http.MethodGet, "*/api/v1/nodes", s.listKubernetesNodes)
+	s.handle(http.MethodGet, "*/api/v1/nodes/*", s.getKubernetesNode)
+	s.handle(http.MethodPut, "*/api/v1/nodes/*", func(r *request) (int, interface{}) {
+		return http.StatusOK, r.body
+	})
+	s.handle(http.MethodGet, "*/api/v1/pods", func(r *request) (int, interface{}) {
+		return http.StatusOK, Object{"kind": "PodList", "apiVersion": "v1", "items": []Object{}}
+	})
+}
+
+func (s *Server) createLKECluster(r *request, collection string) (int, interface{}) {
+	if stringValue(r.body["label"]) == "" {
+		return apiError(http.StatusBadRequest, "label is required")
+	}
+
+	if findStatic(Regions, stringValue(r.body["region"])) == nil {
+		return apiError(http.StatusBadRequest, "region is not valid")
+	}
+
+	if findStatic(LKEVersions, stringValue(r.body["k8s_version"])) == nil {
+		return apiError(http.StatusBadRequest, "k8s_version is not valid")
+	}
+
+	pools, _ := r.body["node_pools"].([]interface{})
+	if len(pools) == 0 {
+		return apiError(http.StatusBadRequest, "At least one node pool is required")
+	}
+
+	cluster := s.insert(collection, defaults(pick(r.body, "label", "region", "k8s_version", "tags", "control_plane"),
+		Object{
+			"tags":          []string{},
+			"status":        "ready",
+			"control_plane": Object{"high_availability": false},
+		}))
+
+	for _, pool := range pools {
+		pool, _ := pool.(map[string]interface{})
+		if status, body := s.createLKEPool(intValue(cluster["id"]), pool); status != http.StatusOK {
+			s.remove(collection, intValue(cluster["id"]))
+			return status, body
+		}
+	}
+
+	return http.StatusOK, cluster
+}
+
+func (s *Server) updateLKECluster(r *request) (int, interface{}) {
+	cluster := s.find(lkeClustersCollection, r.id(0))
+	if cluster == nil {
+		return notFound()
+	}
+
+	if version, ok := r.body["k8s_version"]; ok && findStatic(LKEVersions, stringValue(version)) == nil {
+		return apiError(http.StatusBadRequest, "k8s_version is not valid")
+	}
+
+	update(cluster, r.body, "label", "tags", "k8s_version", "control_plane")
+
+	return http.StatusOK, cluster
+}
+
+func (s *Server) deleteLKECluster(r *request) (int, interface{}) {
+	if !s.remove(lkeClustersCollection, r.id(0)) {
+		return notFound()
+	}
+
+	for _, pool := range s.collections[lkePoolsCollection(r.id(0))] {
+		s.setLKEPoolCount(r.id(0), pool, 0)
+	}
+	delete(s.collections, lkePoolsCollection(r.id(0)))
+
+	return http.StatusOK, Object{}
+}
+
+// newLKENode creates a node of a pool along with the instance backing it,
+// which is labeled and tagged after the cluster as LKE does.
+func (s *Server) newLKENode(clusterID int, pool Object) Object {
+	s.nextID++
+	instanceID := s.nextID
+	poolID := intValue(pool["id"])
+
+	cluster := s.find(lkeClustersCollection, clusterID)
+
+	s.insertInstance(Object{
+		"id":     instanceID,
+		"label":  fmt.Sprintf("lke%d-%d-%08x", clusterID, poolID, instanceID),
+		"region": cluster["region"],
+		"tags":   []string{fmt.Sprintf("lke%d", clusterID)},
+		"status": "running",
+	}, findStatic(Types, stringValue(pool["type"])), false)
+
+	// Nodes run the version of the cluster at the time they were created
+	id := fmt.Sprintf("%d-%08x", poolID, instanceID)
+	s.kubeletVersions[id] = fmt.Sprintf("v%s.0", stringValue(cluster["k8s_version"]))
+
+	return Object{
+		"id":          id,
+		"instance_id": instanceID,
+		"status":      "ready",
+	}
+}
+
+func (s *Server) setLKEPoolCount(clusterID int, pool Object, count int) {
+	nodes, _ := pool["nodes"].([]interface{})
+
+	if len(nodes) > count {
+		for _, node := range nodes[count:] {
+			s.deleteLKENodeInstance(node)
+		}
+		nodes = nodes[:count]
+	}
+	for len(nodes) < count {
+		nodes = append(nodes, s.newLKENode(clusterID, pool))
+	}
+
+	pool["count"] = count
+	pool["nodes"] = normalizeValue(nodes)
+}
+
+func (s *Server) createLKEPool(clusterID int, body Object) (int, interface{}) {
+	if findStatic(Types, stringValue(body["type"])) == nil {
+		return apiError(http.StatusBadRequest, "A valid plan type is required")
+	}
+
+	count := intValue(body["count"])
+	if count < 1 {
+		return apiError(http.StatusBadRequest, "count must be at least 1")
+	}
+
+	fields := pick(body, "type", "count", "disks", "tags", "autoscaler")
+
+	pool := s.insert(lkePoolsCollection(clusterID), defaults(fields, Object{
+		"disks":      []Object{},
+		"tags":       []string{},
+		"autoscaler": Object{"enabled": false, "min": count, "max": count},
+		"nodes":      []Object{},
+	}))
+	s.setLKEPoolCount(clusterID, pool, count)
+
+	return http.StatusOK, pool
+}
+
+func (s *Server) updateLKEPool(r *request) (int, interface{}) {
+	pool := s.find(lkePoolsCollection(r.id(0)), r.id(1))
+	if pool == nil {
+		return notFound()
+	}
+
+	update(pool, r.body, lkePoolUpdateKeys...)
+
+	if count, ok := r.body["count"]; ok {
+		s.setLKEPoolCount(r.id(0), pool, intValue(count))
+	}
+
+	return http.StatusOK, pool
+}
+
+func (s *Server) recycleLKEPool(r *request) (int, interface{}) {
+	pool := s.find(lkePoolsCollection(r.id(0)), r.id(1))
+	if pool == nil {
+		return notFound()
+	}
+
+	s.recycleLKEPoolNodes(r.id(0), pool)
+
+	return http.StatusOK, Object{}
+}
+
+func (s *Server) recycleLKECluster(r *request) (int, interface{}) {
+	if s.find(lkeClustersCollection, r.id(0)) == nil {
+		return notFound()
+	}
+
+	for _, pool := range s.collections[lkePoolsCollection(r.id(0))] {
+		s.recycleLKEPoolNodes(r.id(0), pool)
+	}
+
+	return http.StatusOK, Object{}
+}
+
+// recycleLKEPoolNodes replaces every node of a pool along with its instance.
+func (s *Server) recycleLKEPoolNodes(clusterID int, pool Object) {
+	count := intValue(pool["count"])
+	s.setLKEPoolCount(clusterID, pool, 0)
+	s.setLKEPoolCount(clusterID, pool, count)
+}
+
+// deleteLKENodeInstance deletes the instance backing a node, recording its deletion.
+func (s *Server) deleteLKENodeInstance(node interface{}) {
+	id := intValue(node.(map[string]interface{})["instance_id"])
+
+	s.remove(instancesCollection, id)
+	delete(s.instanceIPs, id)
+	delete(s.kubeletVersions, stringValue(node.(map[string]interface{})["id"]))
+	s.instanceEvent("linode_delete", Object{"id": id})
+}
+
+// findLKENode returns the pool containing the node with the ID given by the second path parameter
+// and the index of the node within the pool.
+func (s *Server) findLKENode(r *request) (Object, int) {
+	for _, pool := range s.collections[lkePoolsCollection(r.id(0))] {
+		for i, node := range pool["nodes"].([]interface{}) {
+			if node.(map[string]interface{})["id"] == r.params[1] {
+				return pool, i
+			}
+		}
+	}
+
+	return nil, -1
+}
+
+func (s *Server) getLKENode(r *request) (int, interface{}) {
+	pool, i := s.findLKENode(r)
+	if pool == nil {
+		return notFound()
+	}
+
+	return http.StatusOK, pool["nodes"].([]interface{})[i]
+}
+
+func (s *Server) deleteLKENode(r *request) (int, interface{}) {
+	pool, i := s.findLKENode(r)
+	if pool == nil {
+		return notFound()
+	}
+
+	nodes := pool["nodes"].([]interface{})
+	s.deleteLKENodeInstance(nodes[i])
+	pool["nodes"] = append(nodes[:i:i], nodes[i+1:]...)
+	pool["count"] = float64(len(nodes) - 1)
+
+	return http.StatusOK, Object{}
+}
+
+func (s *Server) recycleLKENode(r *request) (int, interface{}) {
+	pool, i := s.findLKENode(r)
+	if pool == nil {
+		return notFound()
+	}
+
+	s.deleteLKENodeInstance(pool["nodes"].([]interface{})[i])
+	pool["nodes"].([]interface{})[i] = normalizeValue(s.newLKENode(r.id(0), pool))
+
+	return http.StatusOK, Object{}
+}
+
+func (s *Server) getLKEKubeconfig(r *request) (int, interface{}) {
+	cluster := s.find(lkeClustersCollection, r.id(0))
+	if cluster == nil {
+		return notFound()
+	}
+
+	kubeconfig := fmt.Sprintf(kubeconfigTemplate, s.URL, r.id(0), intValue(cluster["kubeconfig_version"]))
+
+	return http.StatusOK, Object{"kubeconfig": base64.StdEncoding.EncodeToString([]byte(kubeconfig))}
+}
+
+func (s *Server) listKubernetesNodes(r *request) (int, interface{}) {
+	items := []Object{}
+
+	for _, pool := range s.collections[lkePoolsCollection(r.id(0))] {
+		for _, node := range pool["nodes"].([]interface{}) {
+			items = append(items, s.kubernetesNode(r.id(0), stringValue(node.(map[string]interface{})["id"])))
+		}
+	}
+
+	return http.StatusOK, Object{
+		"kind":       "NodeList",
+		"apiVersion": "v1",
+		"items":      items,
+	}
+}
+
+func (s *Server) getKubernetesNode(r *request) (int, interface{}) {
+	prefix := fmt.Sprintf("lke%d-", r.id(0))
+
+	for _, pool := range s.collections[lkePoolsCollection(r.id(0))] {
+		for _, node := range pool["nodes"].([]interface{}) {
+			if id := stringValue(node.(map[string]interface{})["id"]); prefix+id == r.params[1] {
+				return http.StatusOK, s.kubernetesNode(r.id(0), id)
+			}
+		}
+	}
+
+	return notFound()
+}
+
+// kubernetesNode returns the Kubernetes node of an LKE node, which is always ready.
+func (s *Server) kubernetesNode(clusterID int, nodeID string) Object {
+	return Object{
+		"kind":       "Node",
+		"apiVersion": "v1",
+		"metadata":   Object{"name": fmt.Sprintf("lke%d-%s", clusterID, nodeID)},
+		"status": Object{
+			"conditions": []Object{{"type": "Ready", "status": "True"}},
+			"nodeInfo":   Object{"kubeletVersion": s.kubeletVersions[nodeID]},
+		},
+	}
+}
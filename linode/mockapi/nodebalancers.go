@@ -0,0 +1,196 @@
+package mockapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const nodeBalancersCollection = "nodebalancers"
+
+func nodeBalancerConfigsCollection(nodeBalancerID int) string {
+	return fmt.Sprintf("%s/%d/configs", nodeBalancersCollection, nodeBalancerID)
+}
+
+func nodeBalancerNodesCollection(nodeBalancerID, configID int) string {
+	return fmt.Sprintf("%s/%d/configs/%d/nodes", nodeBalancersCollection, nodeBalancerID, configID)
+}
+
+var nodeBalancerConfigKeys = []string{
+	"port", "protocol", "proxy_protocol", "algorithm", "stickiness", "check", "check_interval", "check_attempts",
+	"check_path", "check_body", "check_passive", "check_timeout", "cipher_suite", "ssl_cert", "ssl_key",
+}
+
+var nodeBalancerNodeKeys = []string{"address", "label", "weight", "mode"}
+
+func (s *Server) registerNodeBalancerRoutes() {
+	s.handle(http.MethodDelete, "nodebalancers/*", s.deleteNodeBalancer)
+	s.crud(nodeBalancersCollection, s.createNodeBalancer, []string{"label", "client_conn_throttle", "tags"})
+
+	nodeBalancerExists := func(r *request) bool {
+		return s.find(nodeBalancersCollection, r.id(0)) != nil
+	}
+
+	configExists := func(r *request) bool {
+		return s.find(nodeBalancerConfigsCollection(r.id(0)), r.id(1)) != nil
+	}
+
+	s.handle(http.MethodPut, "nodebalancers/*/configs/*", s.updateNodeBalancerConfig)
+	s.handle(http.MethodPost, "nodebalancers/*/configs/*/rebuild", s.rebuildNodeBalancerConfig)
+	s.crud("nodebalancers/*/configs", func(r *request, collection string) (int, interface{}) {
+		return s.createNodeBalancerConfig(r.id(0), r.body)
+	}, nil, nodeBalancerExists)
+
+	s.crud("nodebalancers/*/configs/*/nodes", func(r *request, collection string) (int, interface{}) {
+		return s.createNodeBalancerNode(r.id(0), r.id(1), r.body)
+	}, nodeBalancerNodeKeys, nodeBalancerExists, configExists)
+}
+
+func (s *Server) createNodeBalancer(r *request, collection string) (int, interface{}) {
+	if findStatic(Regions, stringValue(r.body["region"])) == nil {
+		return apiError(http.StatusBadRequest, "region is not valid")
+	}
+
+	nodeBalancer := s.insert(collection, defaults(pick(r.body, "label", "region", "client_conn_throttle", "tags"),
+		Object{
+			"client_conn_throttle": 0,
+			"tags":                 []string{},
+			"transfer":             Object{"in": nil, "out": nil, "total": nil},
+		}))
+
+	id := intValue(nodeBalancer["id"])
+	if nodeBalancer["label"] == nil {
+		nodeBalancer["label"] = fmt.Sprintf("nodebalancer%d", id)
+	}
+
+	nodeBalancer["ipv4"] = fmt.Sprintf("45.79.%d.%d", id/254%256, id%254+1)
+	nodeBalancer["ipv6"] = fmt.Sprintf("2600:3c03:1::2d4f:%04x", id%65536)
+	nodeBalancer["hostname"] = fmt.Sprintf("nb-45-79-%d-%d.newark.nodebalancer.linode.com", id/254%256, id%254+1)
+
+	configs, _ := r.body["configs"].([]interface{})
+	for _, config := range configs {
+		config, _ := config.(map[string]interface{})
+		if status, body := s.createNodeBalancerConfig(id, config); status != http.StatusOK {
+			s.remove(collection, id)
+			return status, body
+		}
+	}
+
+	s.event("nodebalancer_create", entity("nodebalancer", nodeBalancersCollection, nodeBalancer), nil)
+
+	return http.StatusOK, nodeBalancer
+}
+
+func (s *Server) deleteNodeBalancer(r *request) (int, interface{}) {
+	nodeBalancer := s.find(nodeBalancersCollection, r.id(0))
+	if nodeBalancer == nil {
+		return notFound()
+	}
+
+	s.remove(nodeBalancersCollection, r.id(0))
+	s.event("nodebalancer_delete", entity("nodebalancer", nodeBalancersCollection, nodeBalancer), nil)
+
+	return http.StatusOK, Object{}
+}
+
+func (s *Server) createNodeBalancerConfig(nodeBalancerID int, body Object) (int, interface{}) {
+	collection := nodeBalancerConfigsCollection(nodeBalancerID)
+
+	config := defaults(pick(body, nodeBalancerConfigKeys...), Object{
+		"port":            80,
+		"protocol":        "http",
+		"proxy_protocol":  "none",
+		"algorithm":       "roundrobin",
+		"stickiness":      "none",
+		"check":           "none",
+		"check_interval":  0,
+		"check_attempts":  0,
+		"check_path":      "",
+		"check_body":      "",
+		"check_passive":   true,
+		"check_timeout":   0,
+		"cipher_suite":    "recommended",
+		"ssl_cert":        nil,
+		"ssl_key":         nil,
+		"ssl_commonname":  "",
+		"ssl_fingerprint": "",
+		"nodebalancer_id": nodeBalancerID,
+	})
+
+	for _, existing := range s.collections[collection] {
+		if intValue(existing["port"]) == intValue(config["port"]) {
+			return apiError(http.StatusBadRequest, "Port is already in use by another config")
+		}
+	}
+
+	config = s.insert(collection, config)
+
+	nodes, _ := body["nodes"].([]interface{})
+	for _, node := range nodes {
+		node, _ := node.(map[string]interface{})
+		if status, result := s.createNodeBalancerNode(nodeBalancerID, intValue(config["id"]), node); status != http.StatusOK {
+			return status, result
+		}
+	}
+	s.updateNodesStatus(nodeBalancerID, config)
+
+	return http.StatusOK, config
+}
+
+func (s *Server) updateNodeBalancerConfig(r *request) (int, interface{}) {
+	config := s.find(nodeBalancerConfigsCollection(r.id(0)), r.id(1))
+	if config == nil {
+		return notFound()
+	}
+
+	update(config, r.body, nodeBalancerConfigKeys...)
+
+	return http.StatusOK, config
+}
+
+// rebuildNodeBalancerConfig updates a config and replaces all of its nodes.
+func (s *Server) rebuildNodeBalancerConfig(r *request) (int, interface{}) {
+	config := s.find(nodeBalancerConfigsCollection(r.id(0)), r.id(1))
+	if config == nil {
+		return notFound()
+	}
+
+	update(config, r.body, nodeBalancerConfigKeys...)
+	delete(s.collections, nodeBalancerNodesCollection(r.id(0), r.id(1)))
+
+	nodes, _ := r.body["nodes"].([]interface{})
+	for _, node := range nodes {
+		node, _ := node.(map[string]interface{})
+		if status, result := s.createNodeBalancerNode(r.id(0), r.id(1), node); status != http.StatusOK {
+			return status, result
+		}
+	}
+	s.updateNodesStatus(r.id(0), config)
+
+	return http.StatusOK, config
+}
+
+func (s *Server) createNodeBalancerNode(nodeBalancerID, configID int, body Object) (int, interface{}) {
+	if stringValue(body["address"]) == "" || stringValue(body["label"]) == "" {
+		return apiError(http.StatusBadRequest, "address and label are required")
+	}
+
+	node := s.insert(nodeBalancerNodesCollection(nodeBalancerID, configID),
+		defaults(pick(body, nodeBalancerNodeKeys...), Object{
+			"weight":          100,
+			"mode":            "accept",
+			"status":          "UP",
+			"config_id":       configID,
+			"nodebalancer_id": nodeBalancerID,
+		}))
+
+	if config := s.find(nodeBalancerConfigsCollection(nodeBalancerID), configID); config != nil {
+		s.updateNodesStatus(nodeBalancerID, config)
+	}
+
+	return http.StatusOK, node
+}
+
+func (s *Server) updateNodesStatus(nodeBalancerID int, config Object) {
+	up := len(s.collections[nodeBalancerNodesCollection(nodeBalancerID, intValue(config["id"]))])
+	config["nodes_status"] = normalizeValue(Object{"up": up, "down": 0})
+}
@@ -0,0 +1,21 @@
+package mockapi
+
+import (
+	"net/http"
+)
+
+const sshKeysCollection = "profile/sshkeys"
+
+func (s *Server) registerProfileRoutes() {
+	s.crud(sshKeysCollection, s.createSSHKey, []string{"label"})
+}
+
+func (s *Server) createSSHKey(r *request, collection string) (int, interface{}) {
+	if stringValue(r.body["ssh_key"]) == "" {
+		return apiError(http.StatusBadRequest, "ssh_key is required")
+	}
+
+	return http.StatusOK, s.insert(collection, defaults(pick(r.body, "label", "ssh_key"), Object{
+		"label": "",
+	}))
+}
@@ -0,0 +1,533 @@
+// Package mockapi provides an in-memory fake of the Linode API for unit testing resources
+// without a Linode account.
+//
+// The fake models instances, disks, configs, volumes, domains, firewalls, LKE clusters,
+// NodeBalancers, SSH keys and events. Every operation completes immediately and records a finished
+// event, so the provider's wait helpers return on their first poll.
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const timestampFormat = "2006-01-02T15:04:05"
+
+const defaultPageSize = 100
+
+// Object is a JSON object stored by the fake API.
+type Object map[string]interface{}
+
+// Server is a fake Linode API backed by an httptest.Server.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	nextID      int
+	collections map[string][]Object
+	routes      []route
+
+	// Instance IP addresses are not addressable by ID, so they are kept apart from the collections
+	instanceIPs map[int][]Object
+
+	// The kubelet versions of LKE nodes are not part of the LKE API, so they are kept by node ID
+	kubeletVersions map[string]string
+
+	failures []*failure
+}
+
+// failure makes a request matching its method and pattern fail once the given number of them succeeded.
+type failure struct {
+	method  string
+	pattern []string
+	after   int
+	status  int
+}
+
+type handlerFunc func(r *request) (int, interface{})
+
+type route struct {
+	method  string
+	pattern []string
+	handler handlerFunc
+}
+
+type request struct {
+	*http.Request
+	params []string
+	body   Object
+}
+
+// NewServer starts a fake Linode API server. The caller must call Close when finished with it.
+func NewServer() *Server {
+	s := &Server{
+		nextID:      1000,
+		collections: make(map[string][]Object),
+		instanceIPs: make(map[int][]Object),
+
+		kubeletVersions: make(map[string]string),
+	}
+
+	s.registerStaticRoutes()
+	s.registerEventRoutes()
+	s.registerInstanceRoutes()
+	s.registerVolumeRoutes()
+	s.registerDomainRoutes()
+	s.registerFirewallRoutes()
+	s.registerLKERoutes()
+	s.registerNodeBalancerRoutes()
+	s.registerProfileRoutes()
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// ProviderConfig returns a linode provider block that points the provider at the fake API.
+func (s *Server) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "linode" {
+  url                = %q
+  api_version        = "v4"
+  token              = "mock"
+  event_poll_ms      = 10
+  min_retry_delay_ms = 10
+  max_retry_delay_ms = 50
+}
+`, s.URL)
+}
+
+// Get returns a copy of the object at the given API path, e.g. "linode/instances/1000".
+func (s *Server) Get(path string) Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	collection, id, ok := splitObjectPath(strings.Trim(path, "/"))
+	if !ok {
+		return nil
+	}
+
+	obj := s.find(collection, id)
+	if obj == nil {
+		return nil
+	}
+
+	return normalize(obj)
+}
+
+// Put adds an object to the collection at the given API path, e.g. "linode/instances",
+// and returns the stored copy. An ID is assigned if the object does not have one.
+func (s *Server) Put(collection string, obj Object) Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return normalize(s.insert(strings.Trim(collection, "/"), obj))
+}
+
+// Fail makes the next request with the given method and path pattern, e.g. "lke/clusters/*/recycle",
+// fail with the given status once after the given number of matching requests succeeded.
+func (s *Server) Fail(method, pattern string, after, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, &failure{
+		method:  method,
+		pattern: strings.Split(pattern, "/"),
+		after:   after,
+		status:  status,
+	})
+}
+
+func (s *Server) handle(method, pattern string, handler handlerFunc) {
+	s.routes = append(s.routes, route{
+		method:  method,
+		pattern: strings.Split(pattern, "/"),
+		handler: handler,
+	})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The first segment is the API version, or k8s for requests made with a fake kubeconfig
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) > 1 {
+		segments = segments[1:]
+	}
+
+	status, body := s.route(r, segments)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func (s *Server) route(r *http.Request, segments []string) (int, interface{}) {
+	req := &request{Request: r, body: Object{}}
+	if r.Body != nil && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req.body); err != nil {
+			return apiError(http.StatusBadRequest, "Invalid JSON")
+		}
+	}
+
+	for i, f := range s.failures {
+		if _, ok := matchRoute(f.pattern, segments); !ok || f.method != r.Method {
+			continue
+		}
+
+		if f.after > 0 {
+			f.after--
+			break
+		}
+
+		s.failures = append(s.failures[:i:i], s.failures[i+1:]...)
+		return apiError(f.status, "Injected failure")
+	}
+
+	for _, rt := range s.routes {
+		params, ok := matchRoute(rt.pattern, segments)
+		if !ok || rt.method != r.Method {
+			continue
+		}
+
+		req.params = params
+		return rt.handler(req)
+	}
+
+	return notFound()
+}
+
+func matchRoute(pattern, segments []string) ([]string, bool) {
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+
+	var params []string
+
+	for i, segment := range pattern {
+		switch segment {
+		case "*":
+			params = append(params, segments[i])
+		case segments[i]:
+		default:
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// id returns the i-th path parameter of the request as an int.
+func (r *request) id(i int) int {
+	id, _ := strconv.Atoi(r.params[i])
+	return id
+}
+
+func apiError(status int, reason string) (int, interface{}) {
+	return status, Object{
+		"errors": []Object{{"reason": reason}},
+	}
+}
+
+func notFound() (int, interface{}) {
+	return apiError(http.StatusNotFound, "Not found")
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(timestampFormat)
+}
+
+// normalize returns a deep copy of the object as it would be decoded from JSON.
+func normalize(obj Object) Object {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		panic(err)
+	}
+
+	var result Object
+	if err := json.Unmarshal(data, &result); err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+func splitObjectPath(path string) (string, int, bool) {
+	i := strings.LastIndex(path, "/")
+	if i < 0 {
+		return "", 0, false
+	}
+
+	id, err := strconv.Atoi(path[i+1:])
+	if err != nil {
+		return "", 0, false
+	}
+
+	return path[:i], id, true
+}
+
+// insert stores a new object in the given collection, assigning an ID and timestamps if missing.
+func (s *Server) insert(collection string, obj Object) Object {
+	obj = normalize(obj)
+
+	if _, ok := obj["id"]; !ok {
+		s.nextID++
+		obj["id"] = float64(s.nextID)
+	}
+
+	now := timestamp()
+	if _, ok := obj["created"]; !ok {
+		obj["created"] = now
+	}
+	if _, ok := obj["updated"]; !ok {
+		obj["updated"] = now
+	}
+
+	s.collections[collection] = append(s.collections[collection], obj)
+
+	return obj
+}
+
+func (s *Server) find(collection string, id int) Object {
+	for _, obj := range s.collections[collection] {
+		if intValue(obj["id"]) == id {
+			return obj
+		}
+	}
+
+	return nil
+}
+
+// remove deletes an object from the given collection along with all of its nested collections.
+func (s *Server) remove(collection string, id int) bool {
+	objects := s.collections[collection]
+
+	for i, obj := range objects {
+		if intValue(obj["id"]) != id {
+			continue
+		}
+
+		s.collections[collection] = append(objects[:i:i], objects[i+1:]...)
+
+		prefix := fmt.Sprintf("%s/%d/", collection, id)
+		for path := range s.collections {
+			if strings.HasPrefix(path, prefix) {
+				delete(s.collections, path)
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// update copies the given keys from the request body into the object if they are present.
+func update(obj, body Object, keys ...string) {
+	for _, key := range keys {
+		if value, ok := body[key]; ok {
+			obj[key] = normalizeValue(value)
+		}
+	}
+
+	obj["updated"] = timestamp()
+}
+
+func normalizeValue(value interface{}) interface{} {
+	return normalize(Object{"v": value})["v"]
+}
+
+// defaults copies the given default values into the object where the object has no value.
+func defaults(obj, values Object) Object {
+	for key, value := range values {
+		if current, ok := obj[key]; !ok || current == nil {
+			obj[key] = normalizeValue(value)
+		}
+	}
+
+	return obj
+}
+
+// pick returns a new object with the given keys copied from the request body.
+func pick(body Object, keys ...string) Object {
+	obj := Object{}
+	for _, key := range keys {
+		if value, ok := body[key]; ok {
+			obj[key] = value
+		}
+	}
+
+	return obj
+}
+
+func intValue(v interface{}) int {
+	switch v := v.(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case string:
+		i, _ := strconv.Atoi(v)
+		return i
+	}
+
+	return 0
+}
+
+func stringValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	return ""
+}
+
+// list responds with a filtered, sorted and paginated view of the given objects.
+func list(r *request, objects []Object) (int, interface{}) {
+	var filter Object
+	if header := r.Header.Get("X-Filter"); header != "" {
+		if err := json.Unmarshal([]byte(header), &filter); err != nil {
+			return apiError(http.StatusBadRequest, "Invalid X-Filter")
+		}
+	}
+
+	matched := make([]Object, 0, len(objects))
+	for _, obj := range objects {
+		if matchesFilter(obj, filter) {
+			matched = append(matched, obj)
+		}
+	}
+
+	if orderBy, ok := filter["+order_by"].(string); ok {
+		descending := filter["+order"] == "desc"
+		sort.SliceStable(matched, func(i, j int) bool {
+			// Objects created within the same second are ordered by ID
+			cmp := compareValues(lookupPath(matched[i], orderBy), lookupPath(matched[j], orderBy))
+			if cmp == 0 {
+				cmp = compareValues(matched[i]["id"], matched[j]["id"])
+			}
+
+			if descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		})
+	}
+
+	pageSize := defaultPageSize
+	if size, err := strconv.Atoi(r.URL.Query().Get("page_size")); err == nil {
+		if size < 25 || size > 500 {
+			return apiError(http.StatusBadRequest, "page_size must be between 25 and 500")
+		}
+		pageSize = size
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	pages := (len(matched) + pageSize - 1) / pageSize
+	if pages == 0 {
+		pages = 1
+	}
+
+	start := (page - 1) * pageSize
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + pageSize
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	return http.StatusOK, Object{
+		"data":    matched[start:end],
+		"page":    page,
+		"pages":   pages,
+		"results": len(matched),
+	}
+}
+
+// crud registers list, create, get, update and delete routes for a collection.
+// The collection path may contain wildcards that are resolved from the request path.
+func (s *Server) crud(pattern string, create func(r *request, collection string) (int, interface{}),
+	updateKeys []string, parents ...func(r *request) bool) {
+	resolve := func(r *request) (string, bool) {
+		for _, parent := range parents {
+			if !parent(r) {
+				return "", false
+			}
+		}
+
+		// Item routes have one more parameter than the collection pattern, which is left unused
+		path := pattern
+		for _, param := range r.params {
+			path = strings.Replace(path, "*", param, 1)
+		}
+
+		return path, true
+	}
+
+	s.handle(http.MethodGet, pattern, func(r *request) (int, interface{}) {
+		collection, ok := resolve(r)
+		if !ok {
+			return notFound()
+		}
+		return list(r, s.collections[collection])
+	})
+
+	s.handle(http.MethodPost, pattern, func(r *request) (int, interface{}) {
+		collection, ok := resolve(r)
+		if !ok {
+			return notFound()
+		}
+		return create(r, collection)
+	})
+
+	s.handle(http.MethodGet, pattern+"/*", func(r *request) (int, interface{}) {
+		collection, ok := resolve(r)
+		if !ok {
+			return notFound()
+		}
+		obj := s.find(collection, r.id(len(r.params)-1))
+		if obj == nil {
+			return notFound()
+		}
+		return http.StatusOK, obj
+	})
+
+	if updateKeys != nil {
+		s.handle(http.MethodPut, pattern+"/*", func(r *request) (int, interface{}) {
+			collection, ok := resolve(r)
+			if !ok {
+				return notFound()
+			}
+			obj := s.find(collection, r.id(len(r.params)-1))
+			if obj == nil {
+				return notFound()
+			}
+			update(obj, r.body, updateKeys...)
+			return http.StatusOK, obj
+		})
+	}
+
+	s.handle(http.MethodDelete, pattern+"/*", func(r *request) (int, interface{}) {
+		collection, ok := resolve(r)
+		if !ok {
+			return notFound()
+		}
+		if !s.remove(collection, r.id(len(r.params)-1)) {
+			return notFound()
+		}
+		return http.StatusOK, Object{}
+	})
+}
@@ -0,0 +1,423 @@
+package mockapi_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	k8scondition "github.com/linode/linodego/k8s/pkg/condition"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+	"github.com/linode/terraform-provider-linode/linode/mockapi"
+)
+
+func newClient(t *testing.T) (*mockapi.Server, linodego.Client) {
+	t.Helper()
+
+	server := mockapi.NewServer()
+	t.Cleanup(server.Close)
+
+	config := &helper.Config{
+		AccessToken:           "mock",
+		APIURL:                server.URL,
+		APIVersion:            "v4",
+		EventPollMilliseconds: 10,
+	}
+
+	return server, config.Client()
+}
+
+func TestInstance(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	instance, err := client.CreateInstance(ctx, linodego.InstanceCreateOptions{
+		Region:    "us-east",
+		Type:      "g6-nanode-1",
+		Label:     "test-instance",
+		Image:     "linode/alpine3.15",
+		RootPass:  "terraform-test",
+		PrivateIP: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create instance: %s", err)
+	}
+
+	if instance.Status != linodego.InstanceRunning || len(instance.IPv4) != 2 || instance.Specs.Disk != 25600 {
+		t.Fatalf("unexpected instance: %#v", instance)
+	}
+
+	if _, err := client.WaitForEventFinished(ctx, instance.ID, linodego.EntityLinode, linodego.ActionLinodeBoot,
+		*instance.Created, 5); err != nil {
+		t.Fatalf("failed to wait for boot event: %s", err)
+	}
+
+	disks, err := client.ListInstanceDisks(ctx, instance.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(disks) != 2 || disks[0].Size+disks[1].Size != instance.Specs.Disk {
+		t.Fatalf("unexpected disks: %#v", disks)
+	}
+
+	configs, err := client.ListInstanceConfigs(ctx, instance.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 1 || configs[0].Devices.SDA.DiskID != disks[0].ID {
+		t.Fatalf("unexpected configs: %#v", configs)
+	}
+
+	ips, err := client.GetInstanceIPAddresses(ctx, instance.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ips.IPv4.Public) != 1 || len(ips.IPv4.Private) != 1 || ips.IPv6.SLAAC == nil {
+		t.Fatalf("unexpected IP addresses: %#v", ips)
+	}
+
+	if err := client.ShutdownInstance(ctx, instance.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.WaitForInstanceStatus(ctx, instance.ID, linodego.InstanceOffline, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.ResizeInstanceDisk(ctx, instance.ID, disks[0].ID, 20000); err != nil {
+		t.Fatalf("failed to resize disk: %s", err)
+	}
+
+	if err := client.DeleteInstance(ctx, instance.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.GetInstance(ctx, instance.ID)
+	if lerr, ok := err.(*linodego.Error); !ok || lerr.Code != http.StatusNotFound {
+		t.Fatalf("expected a 404 error, got %v", err)
+	}
+}
+
+func TestInstanceClone(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	source, err := client.CreateInstance(ctx, linodego.InstanceCreateOptions{
+		Region: "us-east", Type: "g6-standard-1", Image: "linode/debian11", RootPass: "terraform-test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := client.CloneInstance(ctx, source.ID, linodego.InstanceCloneOptions{Label: "clone"})
+	if err != nil {
+		t.Fatalf("failed to clone instance: %s", err)
+	}
+
+	if clone.Status != linodego.InstanceOffline || clone.Region != source.Region || clone.Type != source.Type {
+		t.Fatalf("unexpected clone: %#v", clone)
+	}
+
+	disks, err := client.ListInstanceDisks(ctx, clone.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configs, err := client.ListInstanceConfigs(ctx, clone.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(disks) != 2 || len(configs) != 1 || configs[0].Devices.SDA.DiskID != disks[0].ID {
+		t.Fatalf("unexpected clone disks %#v and configs %#v", disks, configs)
+	}
+}
+
+func TestListFilterAndPagination(t *testing.T) {
+	server, client := newClient(t)
+	ctx := context.Background()
+
+	for i := 0; i < 30; i++ {
+		tags := []string{"even"}
+		if i%2 == 1 {
+			tags = []string{"odd"}
+		}
+		server.Put("domains", mockapi.Object{"domain": "example.com", "type": "master", "tags": tags, "ttl_sec": i})
+	}
+
+	filter := linodego.Filter{OrderBy: "ttl_sec", Order: linodego.Descending}
+	filter.AddField(linodego.Eq, "tags", "odd")
+	filter.AddField(linodego.Gte, "ttl_sec", 10)
+
+	filterJSON, err := filter.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	domains, err := client.ListDomains(ctx, &linodego.ListOptions{Filter: string(filterJSON)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domains) != 10 || domains[0].TTLSec != 29 || domains[9].TTLSec != 11 {
+		t.Fatalf("unexpected filtered domains: %#v", domains)
+	}
+
+	page := &linodego.ListOptions{PageOptions: &linodego.PageOptions{Page: 2}, PageSize: 25}
+	domains, err = client.ListDomains(ctx, page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(domains) != 5 || page.Pages != 2 || page.Results != 30 {
+		t.Fatalf("unexpected page: %d domains, %d pages, %d results", len(domains), page.Pages, page.Results)
+	}
+}
+
+func TestVolume(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	instance, err := client.CreateInstance(ctx, linodego.InstanceCreateOptions{Region: "us-east", Type: "g6-nanode-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	volume, err := client.CreateVolume(ctx, linodego.VolumeCreateOptions{Label: "test-volume", Region: "us-east"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if volume.Status != linodego.VolumeActive || volume.Size != 20 || volume.LinodeID != nil {
+		t.Fatalf("unexpected volume: %#v", volume)
+	}
+
+	if _, err := client.AttachVolume(ctx, volume.ID, &linodego.VolumeAttachOptions{LinodeID: instance.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.WaitForVolumeLinodeID(ctx, volume.ID, &instance.ID, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.DeleteVolume(ctx, volume.ID); err == nil {
+		t.Fatal("expected an error deleting an attached volume")
+	}
+
+	if err := client.DetachVolume(ctx, volume.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.ResizeVolume(ctx, volume.ID, 30); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.DeleteVolume(ctx, volume.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDomain(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	domain, err := client.CreateDomain(ctx, linodego.DomainCreateOptions{
+		Domain: "example.com", Type: linodego.DomainTypeMaster, SOAEmail: "admin@example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := client.CreateDomainRecord(ctx, domain.ID, linodego.DomainRecordCreateOptions{
+		Type: linodego.RecordTypeA, Name: "www", Target: "192.0.2.1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err = client.UpdateDomainRecord(ctx, domain.ID, record.ID, linodego.DomainRecordUpdateOptions{
+		Target: "192.0.2.2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if record.Name != "www" || record.Target != "192.0.2.2" {
+		t.Fatalf("unexpected record: %#v", record)
+	}
+
+	if err := client.DeleteDomain(ctx, domain.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetDomainRecord(ctx, domain.ID, record.ID); err == nil {
+		t.Fatal("expected records to be deleted with their domain")
+	}
+}
+
+func TestFirewall(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	instance, err := client.CreateInstance(ctx, linodego.InstanceCreateOptions{Region: "us-east", Type: "g6-nanode-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firewall, err := client.CreateFirewall(ctx, linodego.FirewallCreateOptions{
+		Label: "test-firewall",
+		Rules: linodego.FirewallRuleSet{InboundPolicy: "DROP", OutboundPolicy: "ACCEPT"},
+		Devices: linodego.DevicesCreationOptions{
+			Linodes: []int{instance.ID},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if firewall.Status != linodego.FirewallEnabled || firewall.Rules.InboundPolicy != "DROP" {
+		t.Fatalf("unexpected firewall: %#v", firewall)
+	}
+
+	devices, err := client.ListFirewallDevices(ctx, firewall.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(devices) != 1 || devices[0].Entity.ID != instance.ID || devices[0].Entity.Type != linodego.FirewallDeviceLinode {
+		t.Fatalf("unexpected devices: %#v", devices)
+	}
+
+	rules, err := client.UpdateFirewallRules(ctx, firewall.ID, linodego.FirewallRuleSet{
+		Inbound:        []linodego.FirewallRule{{Label: "ssh", Action: "ACCEPT", Protocol: "TCP", Ports: "22"}},
+		InboundPolicy:  "DROP",
+		OutboundPolicy: "ACCEPT",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rules.Inbound) != 1 || rules.Inbound[0].Ports != "22" {
+		t.Fatalf("unexpected rules: %#v", rules)
+	}
+}
+
+func TestLKECluster(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	cluster, err := client.CreateLKECluster(ctx, linodego.LKEClusterCreateOptions{
+		Label:      "test-cluster",
+		Region:     "us-central",
+		K8sVersion: "1.22",
+		NodePools:  []linodego.LKENodePoolCreateOptions{{Type: "g6-standard-2", Count: 3}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pools, err := client.ListLKENodePools(ctx, cluster.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pools) != 1 || len(pools[0].Linodes) != 3 || pools[0].Autoscaler.Max != 3 {
+		t.Fatalf("unexpected pools: %#v", pools)
+	}
+
+	count := 1
+	pool, err := client.UpdateLKENodePool(ctx, cluster.ID, pools[0].ID, linodego.LKENodePoolUpdateOptions{Count: count})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pool.Linodes) != count {
+		t.Fatalf("expected %d nodes, got %d", count, len(pool.Linodes))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := client.WaitForLKEClusterConditions(ctx, cluster.ID, linodego.LKEClusterPollOptions{
+		TimeoutSeconds: 10,
+	}, k8scondition.ClusterHasReadyNode); err != nil {
+		t.Fatalf("failed to wait for a ready node: %s", err)
+	}
+
+	endpoints, err := client.ListLKEClusterAPIEndpoints(ctx, cluster.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(endpoints) != 1 {
+		t.Fatalf("unexpected API endpoints: %#v", endpoints)
+	}
+}
+
+func TestNodeBalancer(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	label := "test-nodebalancer"
+	nodeBalancer, err := client.CreateNodeBalancer(ctx, linodego.NodeBalancerCreateOptions{
+		Label:  &label,
+		Region: "us-east",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if nodeBalancer.IPv4 == nil || nodeBalancer.Hostname == nil {
+		t.Fatalf("unexpected NodeBalancer: %#v", nodeBalancer)
+	}
+
+	config, err := client.CreateNodeBalancerConfig(ctx, nodeBalancer.ID, linodego.NodeBalancerConfigCreateOptions{
+		Port:     8080,
+		Protocol: linodego.ProtocolTCP,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CreateNodeBalancerNode(ctx, nodeBalancer.ID, config.ID, linodego.NodeBalancerNodeCreateOptions{
+		Address: "192.168.128.1:80",
+		Label:   "node",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err = client.GetNodeBalancerConfig(ctx, nodeBalancer.ID, config.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Port != 8080 || config.NodesStatus == nil || config.NodesStatus.Up != 1 {
+		t.Fatalf("unexpected config: %#v", config)
+	}
+
+	if err := client.DeleteNodeBalancer(ctx, nodeBalancer.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFail(t *testing.T) {
+	server, client := newClient(t)
+	ctx := context.Background()
+
+	server.Fail(http.MethodPost, "linode/instances/*/reboot", 1, http.StatusBadRequest)
+
+	instance, err := client.CreateInstance(ctx, linodego.InstanceCreateOptions{
+		Region: "us-east", Type: "g6-nanode-1", Label: "fail", Image: "linode/debian11", RootPass: "Sup3rS3cure!",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the second matching request fails
+	for i, expectError := range []bool{false, true, false} {
+		err := client.RebootInstance(ctx, instance.ID, 0)
+		if (err != nil) != expectError {
+			t.Errorf("request %d: expected error %t, got %v", i, expectError, err)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package mockapi
+
+import (
+	"net/http"
+)
+
+// Types are the Linode types known to the fake API.
+var Types = []Object{
+	newType("g6-nanode-1", "Nanode 1GB", "nanode", 25600, 1024, 1, 1000, 5),
+	newType("g6-standard-1", "Linode 2GB", "standard", 51200, 2048, 1, 2000, 10),
+	newType("g6-standard-2", "Linode 4GB", "standard", 81920, 4096, 2, 4000, 20),
+	newType("g6-standard-4", "Linode 8GB", "standard", 163840, 8192, 4, 5000, 40),
+	newType("g6-dedicated-2", "Dedicated 4GB", "dedicated", 81920, 4096, 2, 4000, 30),
+}
+
+// Regions are the regions known to the fake API.
+var Regions = []Object{
+	newRegion("us-east", "us", "Newark, NJ"),
+	newRegion("us-central", "us", "Dallas, TX"),
+	newRegion("us-west", "us", "Fremont, CA"),
+	newRegion("eu-west", "uk", "London, UK"),
+	newRegion("ap-south", "sg", "Singapore, SG"),
+}
+
+// Images are the images known to the fake API.
+var Images = []Object{
+	newImage("linode/alpine3.15", "Alpine 3.15", "Alpine"),
+	newImage("linode/alpine3.14", "Alpine 3.14", "Alpine"),
+	newImage("linode/debian11", "Debian 11", "Debian"),
+	newImage("linode/ubuntu20.04", "Ubuntu 20.04 LTS", "Ubuntu"),
+}
+
+// LKEVersions are the Kubernetes versions known to the fake API.
+var LKEVersions = []Object{
+	{"id": "1.22"},
+	{"id": "1.21"},
+}
+
+var kernels = []Object{
+	{"id": "linode/latest-64bit", "label": "Latest 64 bit", "architecture": "x86_64", "kvm": true, "xen": false,
+		"pvops": true, "deprecated": false, "version": "5.16.13"},
+	{"id": "linode/grub2", "label": "GRUB 2", "architecture": "x86_64", "kvm": true, "xen": false,
+		"pvops": false, "deprecated": false, "version": "2.06"},
+	{"id": "linode/direct-disk", "label": "Direct Disk", "architecture": "x86_64", "kvm": true, "xen": false,
+		"pvops": false, "deprecated": false, "version": ""},
+}
+
+func newType(id, label, class string, disk, memory, vcpus, transfer int, monthly float64) Object {
+	return Object{
+		"id":          id,
+		"label":       label,
+		"class":       class,
+		"disk":        disk,
+		"memory":      memory,
+		"vcpus":       vcpus,
+		"transfer":    transfer,
+		"network_out": 1000,
+		"gpus":        0,
+		"successor":   nil,
+		"price":       Object{"hourly": monthly / 730, "monthly": monthly},
+		"addons":      Object{"backups": Object{"price": Object{"hourly": monthly / 2920, "monthly": monthly / 4}}},
+	}
+}
+
+func newRegion(id, country, label string) Object {
+	return Object{
+		"id":           id,
+		"country":      country,
+		"label":        label,
+		"status":       "ok",
+		"capabilities": []string{"Linodes", "NodeBalancers", "Block Storage", "Kubernetes", "Cloud Firewall"},
+		"resolvers":    Object{"ipv4": "192.0.2.1", "ipv6": "2001:db8::1"},
+	}
+}
+
+func newImage(id, label, vendor string) Object {
+	return Object{
+		"id":          id,
+		"label":       label,
+		"vendor":      vendor,
+		"description": "",
+		"type":        "manual",
+		"is_public":   true,
+		"deprecated":  false,
+		"size":        1500,
+		"status":      "available",
+		"created_by":  "linode",
+		"created":     "2021-01-01T00:00:00",
+		"expiry":      nil,
+	}
+}
+
+// findStatic returns the object with the given string ID from a static list.
+func findStatic(objects []Object, id string) Object {
+	for _, obj := range objects {
+		if obj["id"] == id {
+			return normalize(obj)
+		}
+	}
+
+	return nil
+}
+
+func normalizeAll(objects []Object) []Object {
+	result := make([]Object, len(objects))
+	for i, obj := range objects {
+		result[i] = normalize(obj)
+	}
+
+	return result
+}
+
+func (s *Server) registerStatic(pattern string, objects []Object) {
+	s.handle(http.MethodGet, pattern, func(r *request) (int, interface{}) {
+		return list(r, normalizeAll(objects))
+	})
+
+	s.handle(http.MethodGet, pattern+"/*", func(r *request) (int, interface{}) {
+		obj := findStatic(objects, r.params[0])
+		if obj == nil {
+			return notFound()
+		}
+		return http.StatusOK, obj
+	})
+}
+
+func (s *Server) registerStaticRoutes() {
+	s.registerStatic("linode/types", Types)
+	s.registerStatic("regions", Regions)
+	s.registerStatic("lke/versions", LKEVersions)
+
+	// Image and kernel IDs contain a slash
+	for _, static := range []struct {
+		pattern string
+		objects []Object
+	}{
+		{"images", Images},
+		{"linode/kernels", kernels},
+	} {
+		static := static
+
+		s.handle(http.MethodGet, static.pattern, func(r *request) (int, interface{}) {
+			return list(r, normalizeAll(static.objects))
+		})
+
+		s.handle(http.MethodGet, static.pattern+"/*/*", func(r *request) (int, interface{}) {
+			obj := findStatic(static.objects, r.params[0]+"/"+r.params[1])
+			if obj == nil {
+				return notFound()
+			}
+			return http.StatusOK, obj
+		})
+	}
+}
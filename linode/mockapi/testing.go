@@ -0,0 +1,54 @@
+package mockapi
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/linode/terraform-provider-linode/linode"
+)
+
+// ProviderFactories returns a new linode provider for each resource.UnitTest case, so cases
+// pointed at different fake API servers do not share a configured provider.
+var ProviderFactories = map[string]func() (*schema.Provider, error){
+	"linode": func() (*schema.Provider, error) {
+		return linode.Provider(), nil
+	},
+}
+
+// PreCheck skips a resource.UnitTest case when no Terraform CLI is available to run it.
+// `make testunit` fails if any case was skipped, so CI can not pass without running them.
+func PreCheck(t *testing.T) {
+	t.Helper()
+
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" {
+		return
+	}
+
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("terraform must be installed or TF_ACC_TERRAFORM_PATH must be set for unit tests")
+	}
+}
+
+// CheckDestroy returns a CheckDestroy function that verifies that every resource of the given
+// types in the state no longer exists in the given collection of the fake API.
+func (s *Server) CheckDestroy(resourceType, collection string) func(*terraform.State) error {
+	return func(state *terraform.State) error {
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			path := strings.Trim(collection, "/") + "/" + rs.Primary.ID
+			if s.Get(path) != nil {
+				return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,142 @@
+package mockapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const volumesCollection = "volumes"
+
+func (s *Server) registerVolumeRoutes() {
+	s.handle(http.MethodDelete, "volumes/*", s.deleteVolume)
+	s.crud(volumesCollection, s.createVolume, []string{"label", "tags"})
+
+	s.handle(http.MethodPost, "volumes/*/attach", s.volumeAction("volume_attach", s.attachVolume))
+	s.handle(http.MethodPost, "volumes/*/detach", s.volumeAction("volume_detach", s.detachVolume))
+	s.handle(http.MethodPost, "volumes/*/resize", s.volumeAction("volume_resize", s.resizeVolume))
+	s.handle(http.MethodPost, "volumes/*/clone", s.cloneVolume)
+}
+
+func (s *Server) volumeAction(action string, apply func(r *request, volume Object) (int, interface{})) handlerFunc {
+	return func(r *request) (int, interface{}) {
+		volume := s.find(volumesCollection, r.id(0))
+		if volume == nil {
+			return notFound()
+		}
+
+		if status, body := apply(r, volume); status != http.StatusOK {
+			return status, body
+		}
+
+		volume["updated"] = timestamp()
+		s.event(action, entity("volume", volumesCollection, volume), nil)
+
+		return http.StatusOK, volume
+	}
+}
+
+func (s *Server) createVolume(r *request, collection string) (int, interface{}) {
+	if stringValue(r.body["label"]) == "" {
+		return apiError(http.StatusBadRequest, "label is required")
+	}
+
+	volume := defaults(pick(r.body, "label", "region", "size", "tags"), Object{
+		"size":      20,
+		"tags":      []string{},
+		"status":    "active",
+		"linode_id": nil,
+	})
+
+	if linodeID := intValue(r.body["linode_id"]); linodeID != 0 {
+		instance := s.find(instancesCollection, linodeID)
+		if instance == nil {
+			return apiError(http.StatusBadRequest, "linode_id is not valid")
+		}
+		volume["linode_id"] = linodeID
+		volume["region"] = instance["region"]
+	}
+
+	if findStatic(Regions, stringValue(volume["region"])) == nil {
+		return apiError(http.StatusBadRequest, "region is not valid")
+	}
+
+	volume["filesystem_path"] = fmt.Sprintf("/dev/disk/by-id/scsi-0Linode_Volume_%s",
+		strings.ReplaceAll(stringValue(volume["label"]), " ", "_"))
+
+	volume = s.insert(collection, volume)
+	s.event("volume_create", entity("volume", volumesCollection, volume), nil)
+
+	return http.StatusOK, volume
+}
+
+func (s *Server) deleteVolume(r *request) (int, interface{}) {
+	volume := s.find(volumesCollection, r.id(0))
+	if volume == nil {
+		return notFound()
+	}
+
+	if volume["linode_id"] != nil {
+		return apiError(http.StatusBadRequest, "Volume must be detached before it can be deleted")
+	}
+
+	s.remove(volumesCollection, r.id(0))
+	s.event("volume_delete", entity("volume", volumesCollection, volume), nil)
+
+	return http.StatusOK, Object{}
+}
+
+func (s *Server) attachVolume(r *request, volume Object) (int, interface{}) {
+	instance := s.find(instancesCollection, intValue(r.body["linode_id"]))
+	if instance == nil {
+		return apiError(http.StatusBadRequest, "linode_id is not valid")
+	}
+
+	if volume["linode_id"] != nil && intValue(volume["linode_id"]) != intValue(instance["id"]) {
+		return apiError(http.StatusBadRequest, "Volume is already attached to a Linode")
+	}
+
+	if volume["region"] != instance["region"] {
+		return apiError(http.StatusBadRequest, "Volume and Linode must be in the same region")
+	}
+
+	volume["linode_id"] = instance["id"]
+
+	return http.StatusOK, nil
+}
+
+func (s *Server) detachVolume(r *request, volume Object) (int, interface{}) {
+	volume["linode_id"] = nil
+	return http.StatusOK, nil
+}
+
+func (s *Server) resizeVolume(r *request, volume Object) (int, interface{}) {
+	if intValue(r.body["size"]) < intValue(volume["size"]) {
+		return apiError(http.StatusBadRequest, "Volumes can only be resized up")
+	}
+
+	volume["size"] = normalizeValue(r.body["size"])
+
+	return http.StatusOK, nil
+}
+
+func (s *Server) cloneVolume(r *request) (int, interface{}) {
+	source := s.find(volumesCollection, r.id(0))
+	if source == nil {
+		return notFound()
+	}
+
+	status, result := s.createVolume(&request{Request: r.Request, body: Object{
+		"label":  r.body["label"],
+		"region": source["region"],
+		"size":   source["size"],
+		"tags":   source["tags"],
+	}}, volumesCollection)
+	if status != http.StatusOK {
+		return status, result
+	}
+
+	s.event("volume_clone", entity("volume", volumesCollection, source), nil)
+
+	return status, result
+}
@@ -26,6 +26,7 @@ func Resource() *schema.Resource {
 		CreateContext: createResource,
 		UpdateContext: updateResource,
 		DeleteContext: deleteResource,
+		CustomizeDiff: helper.CustomizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -63,7 +64,7 @@ func readResource(ctx context.Context, d *schema.ResourceData, meta interface{})
 	d.Set("region", nodebalancer.Region)
 	d.Set("ipv4", nodebalancer.IPv4)
 	d.Set("ipv6", nodebalancer.IPv6)
-	d.Set("tags", nodebalancer.Tags)
+	helper.SetTags(d, meta, nodebalancer.Tags)
 	d.Set("client_conn_throttle", nodebalancer.ClientConnThrottle)
 	d.Set("created", nodebalancer.Created.Format(time.RFC3339))
 	d.Set("updated", nodebalancer.Updated.Format(time.RFC3339))
@@ -87,11 +88,7 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		ClientConnThrottle: &clientConnThrottle,
 	}
 
-	if tagsRaw, tagsOk := d.GetOk("tags"); tagsOk {
-		for _, tag := range tagsRaw.(*schema.Set).List() {
-			createOpts.Tags = append(createOpts.Tags, tag.(string))
-		}
-	}
+	createOpts.Tags = helper.ExpandTags(d, meta)
 
 	nodebalancer, err := client.CreateNodeBalancer(ctx, createOpts)
 	if err != nil {
@@ -115,7 +112,7 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		return diag.Errorf("Error fetching data about the current NodeBalancer: %s", err)
 	}
 
-	if d.HasChanges("label", "client_conn_throttle", "tags") {
+	if d.HasChanges("label", "client_conn_throttle", "tags", "tags_all") {
 		label := d.Get("label").(string)
 		clientConnThrottle := d.Get("client_conn_throttle").(int)
 
@@ -125,11 +122,7 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 			ClientConnThrottle: &clientConnThrottle,
 		}
 
-		tags := []string{}
-		for _, tag := range d.Get("tags").(*schema.Set).List() {
-			tags = append(tags, tag.(string))
-		}
-
+		tags := helper.ExpandTags(d, meta)
 		updateOpts.Tags = &tags
 
 		if nodebalancer, err = client.UpdateNodeBalancer(ctx, nodebalancer.ID, updateOpts); err != nil {
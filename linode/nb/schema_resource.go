@@ -3,6 +3,7 @@ package nb
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/linode/terraform-provider-linode/linode/helper"
 )
 
 var resourceSchemaTransfer = map[string]*schema.Schema{
@@ -80,4 +81,5 @@ var resourceSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "An array of tags applied to this object. Tags are for organizational purposes only.",
 	},
+	"tags_all": helper.TagsAllSchema(),
 }
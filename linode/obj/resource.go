@@ -36,10 +36,15 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 }
 
 func readResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := helper.S3ConnFromResourceData(d)
 	bucket := d.Get("bucket").(string)
 	key := d.Get("key").(string)
 
+	client, release, err := helper.S3ConnFromResourceData(ctx, d, meta, bucket)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer release()
+
 	headOutput, err := client.HeadObject(&s3.HeadObjectInput{
 		Bucket: &bucket,
 		Key:    &key,
@@ -80,7 +85,12 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 	acl := d.Get("acl").(string)
 
 	if d.HasChange("acl") {
-		client := helper.S3ConnFromResourceData(d)
+		client, release, err := helper.S3ConnFromResourceData(ctx, d, meta, bucket)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer release()
+
 		if _, err := client.PutObjectAcl(&s3.PutObjectAclInput{
 			Bucket: &bucket,
 			Key:    &key,
@@ -94,15 +104,20 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 }
 
 func deleteResource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn := helper.S3ConnFromResourceData(d)
-
 	if _, ok := d.GetOk("version_id"); ok {
-		return deleteAllObjectVersions(ctx, d)
+		return deleteAllObjectVersions(ctx, d, meta)
 	}
 
 	bucket := d.Get("bucket").(string)
 	key := strings.TrimPrefix(d.Get("key").(string), "/")
 	force := d.Get("force_destroy").(bool)
+
+	conn, release, err := helper.S3ConnFromResourceData(ctx, d, meta, bucket)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer release()
+
 	return diag.FromErr(deleteObject(conn, bucket, key, "", force))
 }
 
@@ -118,16 +133,21 @@ func diffResource(
 // specified bucket via the *schema.ResourceData, then it calls
 // readResource.
 func putObject(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := helper.S3ConnFromResourceData(d)
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+
+	client, release, err := helper.S3ConnFromResourceData(ctx, d, meta, bucket)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer release()
+
 	body, err := objectBodyFromResourceData(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer body.Close()
 
-	bucket := d.Get("bucket").(string)
-	key := d.Get("key").(string)
-
 	nilOrValue := func(s string) *string {
 		if s == "" {
 			return nil
@@ -164,12 +184,16 @@ func putObject(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 
 // deleteAllObjectVersions deletes all versions of a given
 // object.
-func deleteAllObjectVersions(ctx context.Context, d *schema.ResourceData) diag.Diagnostics {
+func deleteAllObjectVersions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	bucket := d.Get("bucket").(string)
 	key := d.Get("key").(string)
 	force := d.Get("force_destroy").(bool)
 
-	conn := helper.S3ConnFromResourceData(d)
+	conn, release, err := helper.S3ConnFromResourceData(ctx, d, meta, bucket)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer release()
 
 	var versions []string
 	listObjectVersionsInput := &s3.ListObjectVersionsInput{
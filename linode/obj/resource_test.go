@@ -91,7 +91,7 @@ func getObject(rs *terraform.ResourceState) (*s3.GetObjectOutput, error) {
 	conn := s3.New(session.New(&aws.Config{
 		Region:      aws.String("us-east-1"),
 		Credentials: credentials.NewStaticCredentials(accessKey, secretKey, ""),
-		Endpoint:    aws.String(fmt.Sprintf(helper.LinodeObjectsEndpoint, cluster)),
+		Endpoint:    aws.String(helper.ObjEndpoint(helper.DefaultObjEndpoint, cluster)),
 	}))
 
 	return conn.GetObject(
@@ -26,13 +26,13 @@ var resourceSchema = map[string]*schema.Schema{
 	},
 	"secret_key": {
 		Type:        schema.TypeString,
-		Description: "The S3 secret key with access to the target bucket.",
-		Required:    true,
+		Description: "The S3 secret key with access to the target bucket. Defaults to the provider's obj_secret_key.",
+		Optional:    true,
 	},
 	"access_key": {
 		Type:        schema.TypeString,
-		Description: "The S3 access key with access to the target bucket.",
-		Required:    true,
+		Description: "The S3 access key with access to the target bucket. Defaults to the provider's obj_access_key.",
+		Optional:    true,
 	},
 	"content": {
 		Type:         schema.TypeString,
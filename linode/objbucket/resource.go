@@ -71,18 +71,15 @@ func readResource(
 	}
 
 	// Functionality requiring direct S3 API access
-	accessKey := d.Get("access_key").(string)
-	secretKey := d.Get("secret_key").(string)
-
 	_, versioningPresent := d.GetOk("versioning")
 	_, lifecyclePresent := d.GetOk("lifecycle_rule")
 
 	if versioningPresent || lifecyclePresent {
-		if accessKey == "" || secretKey == "" {
-			return diag.Errorf("access_key and secret_key are required to get versioning and lifecycle info")
+		conn, release, err := helper.S3ConnFromResourceData(ctx, d, meta, label)
+		if err != nil {
+			return diag.Errorf("failed to get versioning and lifecycle info: %s", err)
 		}
-
-		conn := helper.S3ConnFromResourceData(d)
+		defer release()
 
 		if err := readBucketLifecycle(d, conn); err != nil {
 			return diag.Errorf("failed to find get object storage bucket lifecycle: %s", err)
@@ -132,11 +129,6 @@ func updateResource(
 	ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*helper.ProviderMeta).Client
 
-	accessKey := d.Get("access_key")
-	secretKey := d.Get("secret_key")
-
-	conn := helper.S3ConnFromResourceData(d)
-
 	if d.HasChanges("acl", "cors_enabled") {
 		if err := updateBucketAccess(ctx, d, client); err != nil {
 			return diag.FromErr(err)
@@ -153,9 +145,11 @@ func updateResource(
 	lifecycleChanged := d.HasChange("lifecycle_rule")
 
 	if versioningChanged || lifecycleChanged {
-		if accessKey == "" || secretKey == "" {
-			return diag.Errorf("access_key and secret_key are required to set versioning and lifecycle info")
+		conn, release, err := helper.S3ConnFromResourceData(ctx, d, meta, d.Get("label").(string))
+		if err != nil {
+			return diag.Errorf("failed to set versioning and lifecycle info: %s", err)
 		}
+		defer release()
 
 		// Ensure we only update what is changed
 		if versioningChanged {
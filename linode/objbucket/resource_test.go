@@ -120,7 +120,7 @@ func sweep(prefix string) error {
 			conn := s3.New(session.New(&aws.Config{
 				Region:      aws.String("us-east-1"),
 				Credentials: credentials.NewStaticCredentials(accessKey, secretKey, ""),
-				Endpoint:    aws.String(fmt.Sprintf(helper.LinodeObjectsEndpoint, objectStorageBucket.Cluster)),
+				Endpoint:    aws.String(helper.ObjEndpoint(helper.DefaultObjEndpoint, objectStorageBucket.Cluster)),
 			}))
 			iter := s3manager.NewDeleteListIterator(conn, &s3.ListObjectsInput{
 				Bucket: aws.String(bucket),
@@ -5,12 +5,12 @@ import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 var resourceSchema = map[string]*schema.Schema{
 	"secret_key": {
 		Type:        schema.TypeString,
-		Description: "The S3 secret key to use for this resource. (Required for lifecycle_rule and versioning)",
+		Description: "The S3 secret key to use for this resource. Defaults to the provider's obj_secret_key.",
 		Optional:    true,
 	},
 	"access_key": {
 		Type:        schema.TypeString,
-		Description: "The S3 access key to use for this resource. (Required for lifecycle_rule and versioning)",
+		Description: "The S3 access key to use for this resource. Defaults to the provider's obj_access_key.",
 		Optional:    true,
 	},
 	"cluster": {
@@ -38,18 +38,16 @@ var resourceSchema = map[string]*schema.Schema{
 		Default:     true,
 	},
 	"lifecycle_rule": {
-		Type:         schema.TypeList,
-		Description:  "Lifecycle rules to be applied to the bucket.",
-		Optional:     true,
-		RequiredWith: []string{"access_key", "secret_key"},
-		Elem:         resourceLifeCycle(),
+		Type:        schema.TypeList,
+		Description: "Lifecycle rules to be applied to the bucket.",
+		Optional:    true,
+		Elem:        resourceLifeCycle(),
 	},
 	"versioning": {
-		Type:         schema.TypeBool,
-		Description:  "Whether to enable versioning.",
-		Optional:     true,
-		RequiredWith: []string{"access_key", "secret_key"},
-		Computed:     true,
+		Type:        schema.TypeBool,
+		Description: "Whether to enable versioning.",
+		Optional:    true,
+		Computed:    true,
 	},
 	"cert": {
 		Type:        schema.TypeList,
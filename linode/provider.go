@@ -2,6 +2,8 @@ package linode
 
 import (
 	"context"
+	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -23,6 +25,7 @@ import (
 	"github.com/linode/terraform-provider-linode/linode/ipv6range"
 	"github.com/linode/terraform-provider-linode/linode/kernel"
 	"github.com/linode/terraform-provider-linode/linode/lke"
+	"github.com/linode/terraform-provider-linode/linode/lkeversions"
 	"github.com/linode/terraform-provider-linode/linode/nb"
 	"github.com/linode/terraform-provider-linode/linode/nbconfig"
 	"github.com/linode/terraform-provider-linode/linode/nbnode"
@@ -49,14 +52,22 @@ func Provider() *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			"token": {
 				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("LINODE_TOKEN", nil),
+				Optional:    true,
 				Description: "The token that allows you access to your Linode account",
 			},
+			"config_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path to the Linode CLI config file to load credentials from.",
+			},
+			"config_profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Linode CLI config file profile to load credentials from.",
+			},
 			"url": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				DefaultFunc:  schema.EnvDefaultFunc("LINODE_URL", nil),
 				Description:  "The HTTP(S) API address of the Linode API to use.",
 				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
 			},
@@ -69,8 +80,7 @@ func Provider() *schema.Provider {
 			"api_version": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("LINODE_API_VERSION", nil),
-				Description: "An HTTP User-Agent Prefix to prepend in API requests.",
+				Description: "The version of the Linode API to use.",
 			},
 
 			"skip_instance_ready_poll": {
@@ -98,6 +108,26 @@ func Provider() *schema.Provider {
 				Description: "Maximum delay in milliseconds before retrying a request.",
 			},
 
+			"api_requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "The maximum number of API requests to make per second. Unlimited if 0.",
+			},
+			"api_burst": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "The maximum number of API requests to make at once before " +
+					"api_requests_per_second applies. Defaults to api_requests_per_second.",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of API requests in flight at once. Unlimited if 0.",
+			},
+
 			"event_poll_ms": {
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -105,11 +135,58 @@ func Provider() *schema.Provider {
 				Description: "The rate in milliseconds to poll for events.",
 			},
 
+			"obj_endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LINODE_OBJ_ENDPOINT", helper.DefaultObjEndpoint),
+				Description: "The Object Storage endpoint to use. {cluster} is replaced by the cluster of the bucket.",
+			},
+			"obj_use_path_style": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Use path-style rather than virtual hosted-style Object Storage bucket URLs.",
+			},
+			"obj_insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip the verification of the Object Storage endpoint's TLS certificate.",
+			},
+			"obj_ca_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path to a PEM encoded CA bundle to verify the Object Storage endpoint's certificate.",
+			},
+
+			"obj_access_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("LINODE_OBJ_ACCESS_KEY", nil),
+				Description: "The access key of Object Storage resources that do not set access_key.",
+			},
+			"obj_secret_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("LINODE_OBJ_SECRET_KEY", nil),
+				Description: "The secret key of Object Storage resources that do not set secret_key.",
+			},
+			"obj_use_temp_keys": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Create a temporary key limited to the bucket for Object Storage operations " +
+					"without an access key, revoking it afterwards.",
+			},
+
 			"lke_event_poll_ms": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     300,
 				Description: "The rate in milliseconds to poll for LKE events.",
+				Deprecated:  "LKE events are polled along with all other events at the rate of event_poll_ms.",
 			},
 
 			"lke_node_ready_poll_ms": {
@@ -118,6 +195,24 @@ func Provider() *schema.Provider {
 				Default:     500,
 				Description: "The rate in milliseconds to poll for an LKE node to be ready.",
 			},
+
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tags to be applied to all taggable resources managed by this provider.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Optional: true,
+							Description: "An array of tags to apply to all taggable resources. Tags declared on a " +
+								"resource take precedence over default tags with the same name.",
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -133,6 +228,8 @@ func Provider() *schema.Provider {
 			"linode_instance_types":         instancetypes.DataSource(),
 			"linode_kernel":                 kernel.DataSource(),
 			"linode_lke_cluster":            lke.DataSource(),
+			"linode_lke_kubeconfig":         lke.KubeconfigDataSource(),
+			"linode_lke_versions":           lkeversions.DataSource(),
 			"linode_networking_ip":          networkingip.DataSource(),
 			"linode_nodebalancer":           nb.DataSource(),
 			"linode_nodebalancer_node":      nbnode.DataSource(),
@@ -155,9 +252,12 @@ func Provider() *schema.Provider {
 			"linode_firewall_device":       firewalldevice.Resource(),
 			"linode_image":                 image.Resource(),
 			"linode_instance":              instance.Resource(),
+			"linode_instance_config":       instance.ConfigResource(),
+			"linode_instance_disk":         instance.DiskResource(),
 			"linode_instance_ip":           instanceip.Resource(),
 			"linode_ipv6_range":            ipv6range.Resource(),
 			"linode_lke_cluster":           lke.Resource(),
+			"linode_lke_node_pool":         lke.NodePoolResource(),
 			"linode_nodebalancer":          nb.Resource(),
 			"linode_nodebalancer_node":     nbnode.Resource(),
 			"linode_nodebalancer_config":   nbconfig.Resource(),
@@ -182,6 +282,13 @@ func Provider() *schema.Provider {
 		}
 		return providerConfigure(ctx, d, terraformVersion)
 	}
+
+	helper.TagResourceContexts(provider.ResourcesMap)
+	helper.TagResourceContexts(provider.DataSourcesMap)
+
+	helper.RegisterSensitiveLogFields(provider.ResourcesMap)
+	helper.RegisterSensitiveLogFields(provider.DataSourcesMap)
+
 	return provider
 }
 
@@ -193,26 +300,83 @@ func providerConfigure(
 		APIVersion:  d.Get("api_version").(string),
 		UAPrefix:    d.Get("ua_prefix").(string),
 
+		ConfigPath:    d.Get("config_path").(string),
+		ConfigProfile: d.Get("config_profile").(string),
+
 		SkipInstanceReadyPoll:  d.Get("skip_instance_ready_poll").(bool),
 		SkipInstanceDeletePoll: d.Get("skip_instance_delete_poll").(bool),
 
 		MinRetryDelayMilliseconds: d.Get("min_retry_delay_ms").(int),
 		MaxRetryDelayMilliseconds: d.Get("max_retry_delay_ms").(int),
 
-		EventPollMilliseconds:    d.Get("event_poll_ms").(int),
-		LKEEventPollMilliseconds: d.Get("lke_event_poll_ms").(int),
+		APIRequestsPerSecond:  d.Get("api_requests_per_second").(float64),
+		APIBurst:              d.Get("api_burst").(int),
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+
+		EventPollMilliseconds: d.Get("event_poll_ms").(int),
 
 		LKENodeReadyPollMilliseconds: d.Get("lke_node_ready_poll_ms").(int),
+
+		ObjEndpoint:           d.Get("obj_endpoint").(string),
+		ObjUsePathStyle:       d.Get("obj_use_path_style").(bool),
+		ObjInsecureSkipVerify: d.Get("obj_insecure_skip_verify").(bool),
+		ObjCABundle:           d.Get("obj_ca_bundle").(string),
+
+		ObjAccessKey:   d.Get("obj_access_key").(string),
+		ObjSecretKey:   d.Get("obj_secret_key").(string),
+		ObjUseTempKeys: d.Get("obj_use_temp_keys").(bool),
+	}
+
+	if defaultTags, ok := d.GetOk("default_tags.0.tags"); ok {
+		config.DefaultTags = helper.ExpandStringSet(defaultTags.(*schema.Set))
 	}
+
+	// Explicit arguments take precedence over the config file, which takes precedence over the environment
+	if config.ConfigPath != "" || config.ConfigProfile != "" {
+		configPath := config.ConfigPath
+		if configPath == "" {
+			configPath = helper.DefaultConfigPath()
+		}
+
+		profile, err := helper.GetConfigProfile(configPath, config.ConfigProfile)
+		if err != nil {
+			return nil, diag.Errorf("Error loading the Linode config file: %s", err)
+		}
+
+		config.LoadConfigProfile(profile)
+	}
+
+	config.LoadEnvironment()
+
+	if config.AccessToken == "" {
+		return nil, diag.Errorf("A Linode API token must be provided through the token argument, " +
+			"a Linode CLI config file profile, or the LINODE_TOKEN environment variable")
+	}
+
 	config.TerraformVersion = terraformVersion
 	client := config.Client()
 
+	objectStorage, err := helper.NewObjectStorageClients(config)
+	if err != nil {
+		return nil, diag.Errorf("Error configuring Object Storage: %s", err)
+	}
+
 	// Ping the API for an empty response to verify the configuration works
 	if _, err := client.ListTypes(ctx, linodego.NewListOptions(100, "")); err != nil {
 		return nil, diag.Errorf("Error connecting to the Linode API: %s", err)
 	}
+
+	if config.ObjUseTempKeys {
+		if err := helper.RevokeStaleTempObjectStorageKeys(ctx, &client); err != nil {
+			log.Printf("[WARN] failed to revoke stale temporary Object Storage Keys: %s", err)
+		}
+	}
+
 	return &helper.ProviderMeta{
 		Client: client,
 		Config: config,
+		Events: helper.NewEventWatcher(&client, time.Duration(config.EventPollMilliseconds)*time.Millisecond),
+
+		ObjectStorage: objectStorage,
 	}, nil
 }
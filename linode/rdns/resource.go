@@ -119,7 +119,7 @@ func deleteResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		RDNS: nil,
 	}
 
-	if _, err := client.UpdateIPAddress(ctx, d.Id(), updateOpts); err != nil {
+	if _, err := lockedUpdateIPAddress(ctx, &client, d.Id(), updateOpts); err != nil {
 		if lerr, ok := err.(*linodego.Error); ok && lerr.Code == 404 {
 			d.SetId("")
 			return nil
@@ -143,7 +143,7 @@ func updateIPAddress(ctx context.Context, d *schema.ResourceData, meta interface
 		return updateIPAddressWithRetries(ctx, &client, address, updateOpts, time.Second*5)
 	}
 
-	return client.UpdateIPAddress(ctx, address, updateOpts)
+	return lockedUpdateIPAddress(ctx, &client, address, updateOpts)
 }
 
 func updateIPAddressWithRetries(ctx context.Context, client *linodego.Client, address string,
@@ -154,7 +154,7 @@ func updateIPAddressWithRetries(ctx context.Context, client *linodego.Client, ad
 	for {
 		select {
 		case <-ticker.C:
-			result, err := client.UpdateIPAddress(ctx, address, updateOpts)
+			result, err := lockedUpdateIPAddress(ctx, client, address, updateOpts)
 			if err != nil {
 				if lerr, ok := err.(*linodego.Error); ok && lerr.Code != 400 &&
 					!strings.Contains(lerr.Error(), "unable to perform a lookup") {
@@ -171,3 +171,15 @@ func updateIPAddressWithRetries(ctx context.Context, client *linodego.Client, ad
 		}
 	}
 }
+
+// lockedUpdateIPAddress updates the address while holding the lock of the Linode it is assigned to,
+// so its RDNS is not changed at the same time as other writes to the Linode.
+func lockedUpdateIPAddress(ctx context.Context, client *linodego.Client, address string,
+	updateOpts linodego.IPAddressUpdateOptions) (*linodego.InstanceIP, error) {
+	// Any error looking up the address is reported by the update itself
+	if ip, err := client.GetIPAddress(ctx, address); err == nil && ip.LinodeID != 0 {
+		defer helper.LockEntity("linode", ip.LinodeID)()
+	}
+
+	return client.UpdateIPAddress(ctx, address, updateOpts)
+}
@@ -16,6 +16,9 @@ var filterConfig = helper.FilterConfig{
 	"deployments_active": {TypeFunc: helper.FilterTypeInt},
 	"images":             {TypeFunc: helper.FilterTypeString},
 	"username":           {TypeFunc: helper.FilterTypeString},
+
+	"user_defined_fields.label": {TypeFunc: helper.FilterTypeString},
+	"user_defined_fields.name":  {TypeFunc: helper.FilterTypeString},
 }
 
 var dataSourceSchema = map[string]*schema.Schema{
@@ -25,9 +28,12 @@ var dataSourceSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Default:     false,
 	},
-	"order_by": filterConfig.OrderBySchema(),
-	"order":    filterConfig.OrderSchema(),
-	"filter":   filterConfig.FilterSchema(),
+	"order_by":     filterConfig.OrderBySchema(),
+	"order":        filterConfig.OrderSchema(),
+	"filter":       filterConfig.FilterSchema(),
+	"filter_group": filterConfig.FilterGroupSchema(),
+	"limit":        filterConfig.LimitSchema(),
+	"page_size":    filterConfig.PageSizeSchema(),
 	"stackscripts": {
 		Type:        schema.TypeList,
 		Description: "The returned list of StackScripts.",
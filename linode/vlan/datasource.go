@@ -19,9 +19,10 @@ func DataSource() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: readDataSource,
 		Schema: map[string]*schema.Schema{
-			"order_by": filterConfig.OrderBySchema(),
-			"order":    filterConfig.OrderSchema(),
-			"filter":   filterConfig.FilterSchema(),
+			"order_by":     filterConfig.OrderBySchema(),
+			"order":        filterConfig.OrderSchema(),
+			"filter":       filterConfig.FilterSchema(),
+			"filter_group": filterConfig.FilterGroupSchema(),
 			"vlans": {
 				Type:        schema.TypeList,
 				Description: "The returned list of VLANs.",
@@ -8,6 +8,8 @@ import (
 var filterConfig = helper.FilterConfig{
 	"label":  {APIFilterable: true, TypeFunc: helper.FilterTypeString},
 	"region": {APIFilterable: true, TypeFunc: helper.FilterTypeString},
+
+	"created": {TypeFunc: helper.FilterTypeString},
 }
 
 var resourceSchema = map[string]*schema.Schema{
@@ -26,6 +26,7 @@ func Resource() *schema.Resource {
 		CreateContext: createResource,
 		UpdateContext: updateResource,
 		DeleteContext: deleteResource,
+		CustomizeDiff: helper.CustomizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -60,7 +61,7 @@ func readResource(ctx context.Context, d *schema.ResourceData, meta interface{})
 	d.Set("size", volume.Size)
 	d.Set("linode_id", volume.LinodeID)
 	d.Set("filesystem_path", volume.FilesystemPath)
-	d.Set("tags", volume.Tags)
+	helper.SetTags(d, meta, volume.Tags)
 
 	return nil
 }
@@ -74,6 +75,7 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		Label:  d.Get("label").(string),
 		Region: d.Get("region").(string),
 		Size:   d.Get("size").(int),
+		Tags:   helper.ExpandTags(d, meta),
 	}
 
 	if lID, ok := d.GetOk("linode_id"); ok {
@@ -82,11 +84,8 @@ func createResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		createOpts.LinodeID = *linodeID
 	}
 
-	if tagsRaw, tagsOk := d.GetOk("tags"); tagsOk {
-		for _, tag := range tagsRaw.(*schema.Set).List() {
-			createOpts.Tags = append(createOpts.Tags, tag.(string))
-		}
-	}
+	// The Linode is held until the volume has been attached
+	defer lockLinode(linodeID)()
 
 	volume, err := client.CreateVolume(ctx, createOpts)
 	if err != nil {
@@ -142,12 +141,8 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 
 	updateOpts := linodego.VolumeUpdateOptions{}
 	doUpdate := false
-	if d.HasChange("tags") {
-		tags := []string{}
-		for _, tag := range d.Get("tags").(*schema.Set).List() {
-			tags = append(tags, tag.(string))
-		}
-
+	if d.HasChanges("tags", "tags_all") {
+		tags := helper.ExpandTags(d, meta)
 		updateOpts.Tags = &tags
 		doUpdate = true
 	}
@@ -161,7 +156,7 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 		if volume, err = client.UpdateVolume(ctx, volume.ID, updateOpts); err != nil {
 			return diag.FromErr(err)
 		}
-		d.Set("tags", volume.Tags)
+		helper.SetTags(d, meta, volume.Tags)
 		d.Set("label", volume.Label)
 	}
 
@@ -178,34 +173,13 @@ func updateResource(ctx context.Context, d *schema.ResourceData, meta interface{
 	if DetectVolumeIDChange(linodeID, volume.LinodeID) {
 		if linodeID == nil || volume.LinodeID != nil {
 			log.Printf("[INFO] Detaching Linode Volume %d", volume.ID)
-			if err = client.DetachVolume(ctx, volume.ID); err != nil {
-				return diag.FromErr(err)
-			}
-
-			log.Printf("[INFO] Waiting for Linode Volume %d to detach ...", volume.ID)
-			if _, err = client.WaitForVolumeLinodeID(
-				ctx, volume.ID, nil, int(d.Timeout(schema.TimeoutUpdate).Seconds()),
-			); err != nil {
+			if err := detachVolume(ctx, d, &client, volume.ID, volume.LinodeID); err != nil {
 				return diag.FromErr(err)
 			}
 		}
 
 		if linodeID != nil {
-			attachOptions := linodego.VolumeAttachOptions{
-				LinodeID: *linodeID,
-				ConfigID: 0,
-			}
-
-			log.Printf("[INFO] Attaching Linode Volume %d to Linode Instance %d", volume.ID, *linodeID)
-
-			if _, err = client.AttachVolume(ctx, volume.ID, &attachOptions); err != nil {
-				return diag.Errorf("Error attaching Linode Volume %d to Linode Instance %d: %s", volume.ID, *linodeID, err)
-			}
-
-			log.Printf("[INFO] Waiting for Linode Volume %d to attach ...", volume.ID)
-			if _, err = client.WaitForVolumeLinodeID(
-				ctx, volume.ID, linodeID, int(d.Timeout(schema.TimeoutUpdate).Seconds()),
-			); err != nil {
+			if err := attachVolume(ctx, d, &client, volume.ID, *linodeID); err != nil {
 				return diag.FromErr(err)
 			}
 		}
@@ -224,15 +198,14 @@ func deleteResource(ctx context.Context, d *schema.ResourceData, meta interface{
 	}
 	id := int(id64)
 
-	log.Printf("[INFO] Detaching Linode Volume %d for deletion", id)
-	if err := client.DetachVolume(ctx, id); err != nil {
-		return diag.Errorf("Error detaching Linode Volume %d: %s", id, err)
+	var linodeID *int
+	if lID, ok := d.GetOk("linode_id"); ok {
+		lidInt := lID.(int)
+		linodeID = &lidInt
 	}
 
-	log.Printf("[INFO] Waiting for Linode Volume %d to detach ...", id)
-	if _, err := client.WaitForVolumeLinodeID(
-		ctx, id, nil, int(d.Timeout(schema.TimeoutUpdate).Seconds()),
-	); err != nil {
+	log.Printf("[INFO] Detaching Linode Volume %d for deletion", id)
+	if err := detachVolume(ctx, d, &client, id, linodeID); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -244,6 +217,43 @@ func deleteResource(ctx context.Context, d *schema.ResourceData, meta interface{
 	return nil
 }
 
+// detachVolume detaches a volume from the given Linode, if any, and waits for it to be detached.
+func detachVolume(ctx context.Context, d *schema.ResourceData, client *linodego.Client, id int, linodeID *int) error {
+	defer lockLinode(linodeID)()
+
+	if err := client.DetachVolume(ctx, id); err != nil {
+		return fmt.Errorf("failed to detach Linode Volume %d: %s", id, err)
+	}
+
+	log.Printf("[INFO] Waiting for Linode Volume %d to detach ...", id)
+	_, err := client.WaitForVolumeLinodeID(ctx, id, nil, int(d.Timeout(schema.TimeoutUpdate).Seconds()))
+	return err
+}
+
+// attachVolume attaches a volume to the given Linode and waits for it to be attached.
+func attachVolume(ctx context.Context, d *schema.ResourceData, client *linodego.Client, id int, linodeID int) error {
+	defer lockLinode(&linodeID)()
+
+	log.Printf("[INFO] Attaching Linode Volume %d to Linode Instance %d", id, linodeID)
+
+	if _, err := client.AttachVolume(ctx, id, &linodego.VolumeAttachOptions{LinodeID: linodeID}); err != nil {
+		return fmt.Errorf("failed to attach Linode Volume %d to Linode Instance %d: %s", id, linodeID, err)
+	}
+
+	log.Printf("[INFO] Waiting for Linode Volume %d to attach ...", id)
+	_, err := client.WaitForVolumeLinodeID(ctx, id, &linodeID, int(d.Timeout(schema.TimeoutUpdate).Seconds()))
+	return err
+}
+
+// lockLinode serializes writes to the given Linode, if any, with other resources.
+func lockLinode(linodeID *int) func() {
+	if linodeID == nil {
+		return func() {}
+	}
+
+	return helper.LockEntity("linode", *linodeID)
+}
+
 func DetectVolumeIDChange(have *int, want *int) (changed bool) {
 	if have == nil && want == nil {
 		changed = false
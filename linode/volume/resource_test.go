@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/linode/linodego"
 	"github.com/linode/terraform-provider-linode/linode/acceptance"
+	"github.com/linode/terraform-provider-linode/linode/mockapi"
 	"github.com/linode/terraform-provider-linode/linode/volume"
 	"github.com/linode/terraform-provider-linode/linode/volume/tmpl"
 )
@@ -100,6 +101,36 @@ func TestAccResourceVolume_basic(t *testing.T) {
 	})
 }
 
+func TestAccResourceVolume_defaultTags(t *testing.T) {
+	t.Parallel()
+
+	resName := "linode_volume.foobar"
+	var volumeName = acctest.RandomWithPrefix("tf_test")
+	var volume = linodego.Volume{}
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.PreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CheckVolumeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tmpl.DefaultTags(t, volumeName),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CheckVolumeExists("linode_volume.foobar", &volume),
+					resource.TestCheckResourceAttr(resName, "tags.#", "1"),
+					resource.TestCheckResourceAttr(resName, "tags.0", "env:test"),
+					resource.TestCheckResourceAttr(resName, "tags_all.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resName, "tags_all.*", "env:test"),
+					resource.TestCheckTypeSetElemAttr(resName, "tags_all.*", "tf_test"),
+				),
+			},
+			{
+				Config:   tmpl.DefaultTags(t, volumeName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccResourceVolume_update(t *testing.T) {
 	t.Parallel()
 
@@ -278,3 +309,35 @@ func TestAccResourceVolume_reattachedBetweenInstances(t *testing.T) {
 		},
 	})
 }
+
+func TestUnitResourceVolume_basic(t *testing.T) {
+	server := mockapi.NewServer()
+	defer server.Close()
+
+	resName := "linode_volume.foobar"
+	volumeName := acctest.RandomWithPrefix("tf_test")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { mockapi.PreCheck(t) },
+		ProviderFactories: mockapi.ProviderFactories,
+		CheckDestroy:      server.CheckDestroy("linode_volume", "volumes"),
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + tmpl.Basic(t, volumeName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "label", volumeName),
+					resource.TestCheckResourceAttr(resName, "region", "us-west"),
+					resource.TestCheckResourceAttr(resName, "status", "active"),
+					resource.TestCheckResourceAttr(resName, "tags.#", "1"),
+				),
+			},
+			{
+				Config: server.ProviderConfig() + tmpl.Updates(t, volumeName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "label", volumeName+"_r"),
+					resource.TestCheckResourceAttr(resName, "tags.#", "2"),
+				),
+			},
+		},
+	})
+}
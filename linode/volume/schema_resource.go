@@ -1,6 +1,9 @@
 package volume
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/linode/terraform-provider-linode/linode/helper"
+)
 
 var resourceSchema = map[string]*schema.Schema{
 	"label": {
@@ -44,4 +47,5 @@ var resourceSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "An array of tags applied to this object. Tags are for organizational purposes only.",
 	},
+	"tags_all": helper.TagsAllSchema(),
 }
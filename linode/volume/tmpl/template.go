@@ -39,3 +39,8 @@ func DataBasic(t *testing.T, volume string) string {
 	return acceptance.ExecuteTemplate(t,
 		"volume_data_basic", TemplateData{Label: volume})
 }
+
+func DefaultTags(t *testing.T, volume string) string {
+	return acceptance.ExecuteTemplate(t,
+		"volume_default_tags", TemplateData{Label: volume})
+}
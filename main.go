@@ -1,11 +1,19 @@
 package main
 
 import (
+	"context"
+	"os"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/linode/terraform-provider-linode/linode"
+	"github.com/linode/terraform-provider-linode/linode/export"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		os.Exit(export.Run(context.Background(), os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: linode.Provider,
 	})
@@ -0,0 +1,25 @@
+#!/usr/bin/env bash
+
+# Run the unit tests and check that none of them were skipped
+echo "==> Running unit tests against the mock Linode API..."
+output=$(go test -v ./linode/... -run '^TestUnit' "$@" 2>&1)
+status=$?
+echo "${output}"
+if [[ ${status} -ne 0 ]]; then
+    exit ${status}
+fi
+
+skipped=$(grep -- '--- SKIP: TestUnit' <<< "${output}")
+if [[ -n ${skipped} ]]; then
+    echo 'The following unit tests were skipped:'
+    echo "${skipped}"
+    echo 'Install terraform or set TF_ACC_TERRAFORM_PATH to run them.'
+    exit 1
+fi
+
+if ! grep -q -- '--- PASS: TestUnit' <<< "${output}"; then
+    echo 'No unit tests were run.'
+    exit 1
+fi
+
+exit 0